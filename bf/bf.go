@@ -1,2 +1,448 @@
 // Package bf implements binary fields arithmetic in Go.
+// A binary field GF(2^m) is the quotient ring GF(2)[x]/(f(x)) where f is an
+// irreducible polynomial of degree m, elements are the polynomials of degree
+// less than m with coefficients in GF(2) (polynomial basis).
+// Addition is a XOR of the coefficients and multiplication is polynomial
+// multiplication followed by a reduction modulo f.
+// ref : Guide To Elliptic Curve Cryptography chapter 2.3
 package bf
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+const wordSize = 64
+
+var (
+	errBadReductionPolynomial = errors.New("reduction polynomial must be given by strictly decreasing exponents ending with 0")
+	errZeroInverse            = errors.New("zero has no multiplicative inverse")
+)
+
+// Reduction polynomials of the binary fields recommended by NIST (FIPS 186-4 D.1.3)
+var (
+	NISTPoly163 = []int{163, 7, 6, 3, 0}
+	NISTPoly233 = []int{233, 74, 0}
+	NISTPoly283 = []int{283, 12, 7, 5, 0}
+	NISTPoly409 = []int{409, 87, 0}
+	NISTPoly571 = []int{571, 10, 5, 2, 0}
+)
+
+// Element represents an element of GF(2^m) packed in 64-bit words,
+// the bit i of the word j is the coefficient of x^(64j+i).
+type Element []uint64
+
+// Field represents GF(2^m) with a reduction polynomial f(x) = x^m + ... + 1
+// given by its non zero exponents.
+type Field struct {
+	m     int
+	words int
+	// exps are the exponents of f in decreasing order m = exps[0] > ... > 0
+	exps []int
+	// tr holds the traces of the basis elements Tr(x^i)
+	tr Element
+}
+
+// NewField creates GF(2^m) given the exponents of the reduction polynomial
+// in decreasing order, NewField(233, 74, 0) is GF(2)[x]/(x^233 + x^74 + 1).
+// Irreducibility isn't checked.
+func NewField(exps ...int) (*Field, error) {
+	if len(exps) < 2 || exps[len(exps)-1] != 0 {
+		return nil, errBadReductionPolynomial
+	}
+	for i := 1; i < len(exps); i++ {
+		if exps[i] >= exps[i-1] {
+			return nil, errBadReductionPolynomial
+		}
+	}
+	m := exps[0]
+	e := make([]int, len(exps))
+	copy(e, exps)
+	f := &Field{
+		m:     m,
+		words: (m + wordSize - 1) / wordSize,
+		exps:  e,
+	}
+	f.tr = f.traceMask()
+	return f, nil
+}
+
+// Degree returns the extension degree m
+func (f *Field) Degree() int {
+	return f.m
+}
+
+// ReductionPolynomial returns the exponents of f(x)
+func (f *Field) ReductionPolynomial() []int {
+	e := make([]int, len(f.exps))
+	copy(e, f.exps)
+	return e
+}
+
+// String implements stringer
+func (f *Field) String() string {
+	return "GF(2)[x]/(" + NewPoly(f.exps...).String() + ")"
+}
+
+// Zero returns the additive identity
+func (f *Field) Zero() Element {
+	return make(Element, f.words)
+}
+
+// One returns the multiplicative identity
+func (f *Field) One() Element {
+	r := f.Zero()
+	r[0] = 1
+	return r
+}
+
+// NewElement returns the element whose coefficients are the bits of n
+// reduced modulo f.
+func (f *Field) NewElement(n *nt.Integer) Element {
+	words := n.BitLen()/wordSize + 1
+	if words < f.words {
+		words = f.words
+	}
+	r := make(Element, words)
+	for i := 0; i < n.BitLen(); i++ {
+		if n.Bit(i) == 1 {
+			r[i/wordSize] |= 1 << uint(i%wordSize)
+		}
+	}
+	return f.reduce(r)
+}
+
+// NewElementFromHex parses a big-endian hex string as in the SEC 2 parameters
+func (f *Field) NewElementFromHex(s string) (Element, error) {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		return nil, errors.New("invalid hex string")
+	}
+	if n.BitLen() > f.m {
+		return nil, errors.New("element is larger than the field")
+	}
+	return f.NewElement(n), nil
+}
+
+// Big returns the element as an integer whose bits are the coefficients
+func (f *Field) Big(a Element) *nt.Integer {
+	r := new(big.Int)
+	for i := len(a) - 1; i >= 0; i-- {
+		r.Lsh(r, wordSize)
+		r.Or(r, new(big.Int).SetUint64(a[i]))
+	}
+	return r
+}
+
+// Hex returns the big-endian hex encoding of the element
+func (f *Field) Hex(a Element) string {
+	return fmt.Sprintf("%0*x", (f.m+3)/4, f.Big(a))
+}
+
+// Copy returns a copy of a
+func (f *Field) Copy(a Element) Element {
+	r := f.Zero()
+	copy(r, a)
+	return r
+}
+
+// IsZero returns true if a = 0
+func (f *Field) IsZero(a Element) bool {
+	for _, w := range a {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsOne returns true if a = 1
+func (f *Field) IsOne(a Element) bool {
+	if a[0] != 1 {
+		return false
+	}
+	return f.IsZero(a[1:])
+}
+
+// Equal checks for equality between field elements
+func (f *Field) Equal(a, b Element) bool {
+	for i := 0; i < f.words; i++ {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Add sums two field elements i.e a XOR b
+func (f *Field) Add(a, b Element) Element {
+	r := f.Zero()
+	for i := range r {
+		r[i] = a[i] ^ b[i]
+	}
+	return r
+}
+
+// Mul multiplies two field elements
+func (f *Field) Mul(a, b Element) Element {
+	return f.reduce(clmul(a[:f.words], b[:f.words]))
+}
+
+// Square computes a^2, squaring is linear over GF(2) :
+// (sum a_i x^i)^2 = sum a_i x^2i so we only need to interleave zeros
+// between the bits of a before reducing.
+func (f *Field) Square(a Element) Element {
+	c := make(Element, 2*f.words)
+	for i := 0; i < f.words; i++ {
+		c[2*i] = spread32(uint32(a[i]))
+		c[2*i+1] = spread32(uint32(a[i] >> 32))
+	}
+	return f.reduce(c)
+}
+
+// SquareN computes a^(2^n)
+func (f *Field) SquareN(a Element, n int) Element {
+	r := f.Copy(a)
+	for i := 0; i < n; i++ {
+		r = f.Square(r)
+	}
+	return r
+}
+
+// Sqrt computes the unique square root a^(2^(m-1)), every element of a
+// binary field is a square.
+func (f *Field) Sqrt(a Element) Element {
+	return f.SquareN(a, f.m-1)
+}
+
+// sqrTable maps a byte to the 16-bit word with zeros interleaved between its bits
+var sqrTable = func() (t [256]uint16) {
+	for i := 0; i < 256; i++ {
+		for j := uint(0); j < 8; j++ {
+			if i>>j&1 == 1 {
+				t[i] |= 1 << (2 * j)
+			}
+		}
+	}
+	return
+}()
+
+// spread32 interleaves zeros between the bits of x
+func spread32(x uint32) uint64 {
+	return uint64(sqrTable[x&0xff]) |
+		uint64(sqrTable[x>>8&0xff])<<16 |
+		uint64(sqrTable[x>>16&0xff])<<32 |
+		uint64(sqrTable[x>>24])<<48
+}
+
+// Inv computes a^-1 using the extended euclidean algorithm for polynomials
+// (Guide To ECC algorithm 2.48), it maintains the invariants
+// g1*a = u mod f and g2*a = v mod f until u = 1.
+func (f *Field) Inv(a Element) (Element, error) {
+	if f.IsZero(a) {
+		return nil, errZeroInverse
+	}
+	// f has m+1 bits we use an extra word for the temporaries
+	n := f.m/wordSize + 1
+	u := make(Element, n)
+	copy(u, a)
+	v := f.poly(n)
+	g1 := make(Element, n)
+	g1[0] = 1
+	g2 := make(Element, n)
+
+	for degree(u) != 0 {
+		j := degree(u) - degree(v)
+		if j < 0 {
+			u, v = v, u
+			g1, g2 = g2, g1
+			j = -j
+		}
+		xorShifted(u, v, j)
+		xorShifted(g1, g2, j)
+	}
+	return f.reduce(g1), nil
+}
+
+// InvItohTsujii computes a^-1 = a^(2^m - 2) = (a^(2^(m-1) - 1))^2 using the
+// Itoh-Tsujii addition chain on m-1, with beta_k = a^(2^k - 1) :
+// beta_(i+j) = beta_i^(2^j) * beta_j
+// so an inversion costs m-1 squarings and about log2(m) multiplications.
+func (f *Field) InvItohTsujii(a Element) (Element, error) {
+	if f.IsZero(a) {
+		return nil, errZeroInverse
+	}
+	n := f.m - 1
+	// beta_1 = a
+	r := f.Copy(a)
+	k := 1
+	for i := bits.Len(uint(n)) - 2; i >= 0; i-- {
+		// beta_2k = beta_k^(2^k) * beta_k
+		r = f.Mul(f.SquareN(r, k), r)
+		k *= 2
+		if n>>uint(i)&1 == 1 {
+			// beta_(2k+1) = beta_2k^2 * a
+			r = f.Mul(f.Square(r), a)
+			k++
+		}
+	}
+	return f.Square(r), nil
+}
+
+// Div computes a/b
+func (f *Field) Div(a, b Element) (Element, error) {
+	bInv, err := f.Inv(b)
+	if err != nil {
+		return nil, err
+	}
+	return f.Mul(a, bInv), nil
+}
+
+// Exp computes a^e using square and multiply
+func (f *Field) Exp(a Element, e *nt.Integer) Element {
+	r := f.One()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = f.Square(r)
+		if e.Bit(i) == 1 {
+			r = f.Mul(r, a)
+		}
+	}
+	return r
+}
+
+// poly returns the reduction polynomial on n words
+func (f *Field) poly(n int) Element {
+	p := make(Element, n)
+	for _, e := range f.exps {
+		p[e/wordSize] |= 1 << uint(e%wordSize)
+	}
+	return p
+}
+
+// reduce computes c mod f for a polynomial of any length and returns
+// it on f.words words, c is modified in place.
+// The word level reduction replaces every word above x^m at once, it requires
+// the second term of f to be at least a word away from x^m which holds for
+// the trinomials and pentanomials used in practice.
+func (f *Field) reduce(c Element) Element {
+	if f.m-f.exps[1] < wordSize {
+		return f.reduceBitwise(c)
+	}
+	// x^(64i+j) = x^(64i+j-m) (f(x) - x^m)
+	for i := len(c) - 1; i >= f.words; i-- {
+		t := c[i]
+		if t == 0 {
+			continue
+		}
+		c[i] = 0
+		for _, e := range f.exps[1:] {
+			xorWordAt(c, t, i*wordSize-f.m+e)
+		}
+	}
+	// the top word holds the coefficients x^m...x^(64*words-1)
+	if rem := uint(f.m % wordSize); rem != 0 {
+		t := c[f.words-1] >> rem
+		c[f.words-1] &= 1<<rem - 1
+		for _, e := range f.exps[1:] {
+			xorWordAt(c, t, e)
+		}
+	}
+	r := f.Zero()
+	copy(r, c)
+	return r
+}
+
+// reduceBitwise replaces every set bit x^i with i >= m by x^(i-m)(f(x) - x^m).
+func (f *Field) reduceBitwise(c Element) Element {
+	for i := len(c)*wordSize - 1; i >= f.m; i-- {
+		if c[i/wordSize]>>uint(i%wordSize)&1 == 0 {
+			continue
+		}
+		c[i/wordSize] ^= 1 << uint(i%wordSize)
+		for _, e := range f.exps[1:] {
+			j := i - f.m + e
+			c[j/wordSize] ^= 1 << uint(j%wordSize)
+		}
+	}
+	r := f.Zero()
+	copy(r, c)
+	return r
+}
+
+// xorWordAt computes c ^= t * x^pos
+func xorWordAt(c Element, t uint64, pos int) {
+	w, b := pos/wordSize, uint(pos%wordSize)
+	c[w] ^= t << b
+	if b != 0 && w+1 < len(c) {
+		c[w+1] ^= t >> (wordSize - b)
+	}
+}
+
+// clmul64 computes the carry-less product of two words as (hi, lo) using
+// a 4-bit window, the multiples u(x)*a(x) for deg(u) < 4 are precomputed
+// and b is scanned a nibble at a time from the top.
+func clmul64(a, b uint64) (hi, lo uint64) {
+	// the multiples have up to 67 bits, the 3 top bits of a are handled apart
+	var table [16]uint64
+	a0 := a & (1<<(wordSize-3) - 1)
+	table[1] = a0
+	for u := 2; u < 16; u += 2 {
+		table[u] = table[u/2] << 1
+		table[u+1] = table[u] ^ a0
+	}
+	for i := wordSize - 4; i >= 0; i -= 4 {
+		hi = hi<<4 | lo>>(wordSize-4)
+		lo <<= 4
+		lo ^= table[b>>uint(i)&0xf]
+	}
+	// add the contribution of the 3 top bits of a
+	for j := uint(wordSize - 3); j < wordSize; j++ {
+		if a>>j&1 == 1 {
+			lo ^= b << j
+			hi ^= b >> (wordSize - j)
+		}
+	}
+	return
+}
+
+// clmul computes the product of two polynomials over GF(2) (schoolbook on words)
+func clmul(a, b Element) Element {
+	c := make(Element, len(a)+len(b))
+	for i := range a {
+		if a[i] == 0 {
+			continue
+		}
+		for j := range b {
+			hi, lo := clmul64(a[i], b[j])
+			c[i+j] ^= lo
+			c[i+j+1] ^= hi
+		}
+	}
+	return c
+}
+
+// degree returns the degree of a polynomial or -1 for zero
+func degree(a Element) int {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i] != 0 {
+			return i*wordSize + bits.Len64(a[i]) - 1
+		}
+	}
+	return -1
+}
+
+// xorShifted computes dst ^= src * x^j truncated to len(dst) words
+func xorShifted(dst, src Element, j int) {
+	ws, bs := j/wordSize, uint(j%wordSize)
+	for i := len(dst) - 1; i >= ws; i-- {
+		w := src[i-ws] << bs
+		if bs != 0 && i-ws-1 >= 0 {
+			w |= src[i-ws-1] >> (wordSize - bs)
+		}
+		dst[i] ^= w
+	}
+}
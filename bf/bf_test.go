@@ -0,0 +1,242 @@
+package bf
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+var nistPolys = [][]int{NISTPoly163, NISTPoly233, NISTPoly283, NISTPoly409, NISTPoly571}
+
+// randElement returns a random element of the field
+func randElement(f *Field) Element {
+	n, _ := rand.Int(rand.Reader, new(big.Int).Lsh(nt.One, uint(f.Degree())))
+	return f.NewElement(n)
+}
+
+// naiveMul multiplies polynomials over GF(2) encoded as integers and reduces
+// them bit by bit, it serves as a reference for the word level arithmetic.
+func naiveMul(f *Field, a, b Element) Element {
+	x, y := f.Big(a), f.Big(b)
+	r := new(big.Int)
+	for i := 0; i < y.BitLen(); i++ {
+		if y.Bit(i) == 1 {
+			r.Xor(r, new(big.Int).Lsh(x, uint(i)))
+		}
+	}
+	mod := new(big.Int)
+	for _, e := range f.ReductionPolynomial() {
+		mod.SetBit(mod, e, 1)
+	}
+	for r.BitLen() > f.Degree() {
+		r.Xor(r, new(big.Int).Lsh(mod, uint(r.BitLen()-1-f.Degree())))
+	}
+	return f.NewElement(r)
+}
+
+func TestBinaryField(t *testing.T) {
+
+	t.Run("TestNewField", func(t *testing.T) {
+		if _, err := NewField(163, 7, 6, 3); err == nil {
+			t.Error("reduction polynomial without constant term should fail")
+		}
+		if _, err := NewField(233, 234, 0); err == nil {
+			t.Error("exponents must be decreasing")
+		}
+		f, _ := NewField(NISTPoly233...)
+		if f.String() != "GF(2)[x]/(x^233 + x^74 + 1)" {
+			t.Error("wrong string representation got", f.String())
+		}
+	})
+
+	t.Run("TestAES", func(t *testing.T) {
+		// GF(2^8) with the AES polynomial x^8 + x^4 + x^3 + x + 1 (FIPS 197 section 4.2)
+		f, _ := NewField(8, 4, 3, 1, 0)
+		a := f.NewElement(nt.FromInt64(0x57))
+		b := f.NewElement(nt.FromInt64(0x83))
+		if f.Big(f.Mul(a, b)).Int64() != 0xc1 {
+			t.Error("{57}.{83} != {c1} got", f.Hex(f.Mul(a, b)))
+		}
+		if f.Big(f.Mul(a, f.NewElement(nt.FromInt64(0x13)))).Int64() != 0xfe {
+			t.Error("{57}.{13} != {fe}")
+		}
+		inv, _ := f.Inv(f.NewElement(nt.FromInt64(0x53)))
+		if f.Big(inv).Int64() != 0xca {
+			t.Error("{53}^-1 != {ca} got", f.Hex(inv))
+		}
+	})
+
+	t.Run("TestArithmetic", func(t *testing.T) {
+		for _, poly := range nistPolys {
+			f, _ := NewField(poly...)
+			for i := 0; i < 8; i++ {
+				a, b, c := randElement(f), randElement(f), randElement(f)
+				if !f.Equal(f.Mul(a, b), naiveMul(f, a, b)) {
+					t.Fatal("multiplication disagrees with the reference in", f)
+				}
+				if !f.Equal(f.Square(a), naiveMul(f, a, a)) {
+					t.Fatal("squaring disagrees with the reference in", f)
+				}
+				// a(b + c) = ab + ac
+				if !f.Equal(f.Mul(a, f.Add(b, c)), f.Add(f.Mul(a, b), f.Mul(a, c))) {
+					t.Error("multiplication isn't distributive in", f)
+				}
+				if !f.Equal(f.Square(f.Sqrt(a)), a) {
+					t.Error("sqrt(a)^2 != a in", f)
+				}
+				if f.IsZero(a) {
+					continue
+				}
+				inv, _ := f.Inv(a)
+				invIT, _ := f.InvItohTsujii(a)
+				if !f.IsOne(f.Mul(a, inv)) {
+					t.Error("a * a^-1 != 1 in", f)
+				}
+				if !f.Equal(inv, invIT) {
+					t.Error("Itoh-Tsujii disagrees with the extended euclidean algorithm in", f)
+				}
+			}
+			// a^(2^m) = a
+			a := randElement(f)
+			if !f.Equal(f.SquareN(a, f.Degree()), a) {
+				t.Error("Frobenius of order m failed in", f)
+			}
+			if _, err := f.Inv(f.Zero()); err == nil {
+				t.Error("inverting zero should fail")
+			}
+		}
+	})
+
+	t.Run("TestClmul", func(t *testing.T) {
+		// the top 3 bits of a are handled outside the window table
+		words := []uint64{0, 1, 1 << 63, 7 << 61, ^uint64(0), 0x8000000000000001}
+		var b [8]byte
+		for i := 0; i < 32; i++ {
+			rand.Read(b[:])
+			words = append(words, binary.LittleEndian.Uint64(b[:]))
+		}
+		for _, a := range words {
+			for _, b := range words {
+				var hi, lo uint64
+				for j := uint(0); j < 64; j++ {
+					if b>>j&1 == 1 {
+						lo ^= a << j
+						if j != 0 {
+							hi ^= a >> (64 - j)
+						}
+					}
+				}
+				if h, l := clmul64(a, b); h != hi || l != lo {
+					t.Fatalf("clmul64(%x, %x) = (%x, %x) expected (%x, %x)", a, b, h, l, hi, lo)
+				}
+			}
+		}
+	})
+
+	t.Run("TestReduce", func(t *testing.T) {
+		// the word level reduction agrees with the bit by bit one on
+		// unreduced products
+		for _, poly := range nistPolys {
+			f, _ := NewField(poly...)
+			for i := 0; i < 8; i++ {
+				c := clmul(randElement(f), randElement(f))
+				d := append(Element{}, c...)
+				if !f.Equal(f.reduce(c), f.reduceBitwise(d)) {
+					t.Fatal("word level reduction disagrees with the bitwise one in", f)
+				}
+			}
+		}
+	})
+
+	t.Run("TestExp", func(t *testing.T) {
+		for _, poly := range nistPolys {
+			f, _ := NewField(poly...)
+			a := randElement(f)
+			if !f.IsOne(f.Exp(a, nt.Zero)) || !f.Equal(f.Exp(a, nt.One), a) {
+				t.Error("a^0 != 1 or a^1 != a in", f)
+			}
+			cube := f.Mul(f.Square(a), a)
+			if !f.Equal(f.Exp(a, nt.FromInt64(3)), cube) {
+				t.Error("a^3 != a a a in", f)
+			}
+			// the multiplicative group has order 2^m - 1
+			order := new(big.Int).Sub(new(big.Int).Lsh(nt.One, uint(f.Degree())), nt.One)
+			if !f.IsZero(a) && !f.IsOne(f.Exp(a, order)) {
+				t.Error("a^(2^m - 1) != 1 in", f)
+			}
+			inv, _ := f.Inv(a)
+			if !f.IsZero(a) && !f.Equal(f.Exp(a, new(big.Int).Sub(order, nt.One)), inv) {
+				t.Error("a^(2^m - 2) != a^-1 in", f)
+			}
+		}
+	})
+
+	t.Run("TestTrace", func(t *testing.T) {
+		for _, poly := range nistPolys {
+			f, _ := NewField(poly...)
+			if f.Trace(f.One()) != uint(f.Degree()%2) {
+				t.Error("Tr(1) != m mod 2")
+			}
+			a, b := randElement(f), randElement(f)
+			// trace is linear and Tr(a^2) = Tr(a)
+			if f.Trace(f.Add(a, b)) != f.Trace(a)^f.Trace(b) {
+				t.Error("trace isn't linear in", f)
+			}
+			if f.Trace(f.Square(a)) != f.Trace(a) {
+				t.Error("Tr(a^2) != Tr(a) in", f)
+			}
+			// compare against the definition
+			naive := f.Zero()
+			sq := f.Copy(a)
+			for i := 0; i < f.Degree(); i++ {
+				naive = f.Add(naive, sq)
+				sq = f.Square(sq)
+			}
+			if !f.Equal(naive, f.NewElement(nt.FromInt64(int64(f.Trace(a))))) {
+				t.Error("trace disagrees with its definition in", f)
+			}
+		}
+	})
+
+	t.Run("TestQuadratic", func(t *testing.T) {
+		// the NIST fields have odd degree, GF(2^8) exercises the even case
+		aes, _ := NewField(8, 4, 3, 1, 0)
+		fields := []*Field{aes}
+		for _, poly := range nistPolys {
+			f, _ := NewField(poly...)
+			fields = append(fields, f)
+		}
+		for _, f := range fields {
+			solved := 0
+			for i := 0; i < 16; i++ {
+				c := randElement(f)
+				z, err := f.SolveQuadratic(c)
+				if f.Trace(c) == 1 {
+					if err == nil {
+						t.Error("z^2 + z = c has no solution when Tr(c) = 1")
+					}
+					continue
+				}
+				if err != nil || !f.Equal(f.Add(f.Square(z), z), c) {
+					t.Fatal("failed to solve z^2 + z = c in", f)
+				}
+				solved++
+				// ax^2 + bx + c = 0
+				a, b := randElement(f), randElement(f)
+				x, err := f.SolveQuadraticGeneral(a, b, c)
+				if err == nil && !f.IsZero(f.Add(f.Add(f.Mul(a, f.Square(x)), f.Mul(b, x)), c)) {
+					t.Error("wrong solution to ax^2 + bx + c = 0 in", f)
+				}
+			}
+			if solved == 0 {
+				t.Error("no quadratic equation was solved in", f)
+			}
+		}
+		if _, err := aes.HalfTrace(aes.One()); err == nil {
+			t.Error("half-trace requires an odd degree")
+		}
+	})
+}
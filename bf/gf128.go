@@ -0,0 +1,274 @@
+package bf
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Element128 is an element of GF(2^128) = GF(2)[x]/(x^128 + x^7 + x^2 + x + 1),
+// bit i of lo||hi is the coefficient of x^i.
+// GCM (SP 800-38D) stores the coefficient of x^0 in the most significant bit
+// of the first byte. POLYVAL (RFC 8452) reads blocks as little endian integers
+// modulo the reflected polynomial x^128 + x^127 + x^126 + x^121 + 1, reversing
+// the bits of the integer maps its field onto ours so the POLYVAL ordering of
+// an element is its GCM encoding with the bytes reversed.
+type Element128 struct {
+	lo, hi uint64
+}
+
+// gf128Poly is x^7 + x^2 + x + 1 i.e x^128 reduced modulo the field polynomial
+const gf128Poly = 0x87
+
+// gf128Reduction holds o(x)x^128 mod f(x) for the 16 polynomials o of degree < 4,
+// it reduces the four bits shifted out when multiplying by x^4.
+var gf128Reduction = func() [16]uint64 {
+	var t [16]uint64
+	for o := uint64(0); o < 16; o++ {
+		for i := uint(0); i < 4; i++ {
+			if o>>i&1 == 1 {
+				t[o] ^= gf128Poly << i
+			}
+		}
+	}
+	return t
+}()
+
+// GCMElement decodes a block in the GCM bit ordering
+func GCMElement(b [16]byte) Element128 {
+	return Element128{
+		lo: bits.Reverse64(binary.BigEndian.Uint64(b[:8])),
+		hi: bits.Reverse64(binary.BigEndian.Uint64(b[8:])),
+	}
+}
+
+// POLYVALElement decodes a block in the POLYVAL ordering
+func POLYVALElement(b [16]byte) Element128 {
+	return Element128{
+		lo: bits.Reverse64(binary.LittleEndian.Uint64(b[8:])),
+		hi: bits.Reverse64(binary.LittleEndian.Uint64(b[:8])),
+	}
+}
+
+// GCM encodes the element in the GCM bit ordering
+func (a Element128) GCM() [16]byte {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], bits.Reverse64(a.lo))
+	binary.BigEndian.PutUint64(b[8:], bits.Reverse64(a.hi))
+	return b
+}
+
+// POLYVAL encodes the element in the POLYVAL ordering
+func (a Element128) POLYVAL() [16]byte {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[:8], bits.Reverse64(a.hi))
+	binary.LittleEndian.PutUint64(b[8:], bits.Reverse64(a.lo))
+	return b
+}
+
+// IsZero returns true if a = 0
+func (a Element128) IsZero() bool {
+	return a.lo == 0 && a.hi == 0
+}
+
+// Equal returns true if a = b
+func (a Element128) Equal(b Element128) bool {
+	return a == b
+}
+
+// Add computes a + b
+func (a Element128) Add(b Element128) Element128 {
+	return Element128{lo: a.lo ^ b.lo, hi: a.hi ^ b.hi}
+}
+
+// MulX computes a*x
+func (a Element128) MulX() Element128 {
+	carry := a.hi >> 63
+	return Element128{
+		lo: a.lo<<1 ^ carry*gf128Poly,
+		hi: a.hi<<1 | a.lo>>63,
+	}
+}
+
+// Mul computes a*b bit by bit, it's slow and only meant as a reference,
+// repeated multiplications by the same element should use a MulTable128.
+func (a Element128) Mul(b Element128) Element128 {
+	var r Element128
+	for i := 127; i >= 0; i-- {
+		r = r.MulX()
+		var bit uint64
+		if i >= 64 {
+			bit = b.hi >> uint(i-64) & 1
+		} else {
+			bit = b.lo >> uint(i) & 1
+		}
+		r.lo ^= a.lo & -bit
+		r.hi ^= a.hi & -bit
+	}
+	return r
+}
+
+// Dot computes the POLYVAL product a*b*x^-128 of the reflected field which
+// becomes a*b*x once mapped to our field.
+func (a Element128) Dot(b Element128) Element128 {
+	return a.Mul(b).MulX()
+}
+
+// MulTable128 holds the multiples n(x)H of a fixed element H for the 16
+// polynomials n of degree < 4, products by H then process the other operand
+// four bits at a time (Shoup's method).
+type MulTable128 [16]Element128
+
+// NewMulTable128 precomputes the table of h
+func NewMulTable128(h Element128) *MulTable128 {
+	var t MulTable128
+	t[1] = h
+	for i := 2; i < 16; i <<= 1 {
+		t[i] = t[i>>1].MulX()
+		for j := 1; j < i; j++ {
+			t[i+j] = t[i].Add(t[j])
+		}
+	}
+	return &t
+}
+
+// Mul computes a*H using Horner's rule in x^4 starting from the high nibbles
+func (t *MulTable128) Mul(a Element128) Element128 {
+	var z Element128
+	for _, w := range [2]uint64{a.hi, a.lo} {
+		for i := 60; i >= 0; i -= 4 {
+			top := z.hi >> 60
+			z.hi = z.hi<<4 | z.lo>>60
+			z.lo = z.lo<<4 ^ gf128Reduction[top]
+			n := w >> uint(i) & 0xf
+			z.lo ^= t[n].lo
+			z.hi ^= t[n].hi
+		}
+	}
+	return z
+}
+
+// universalHash evaluates the polynomial sum X_i H^(n-i+1) by Horner's rule,
+// the input is split in 16 byte blocks and the last one is zero padded.
+type universalHash struct {
+	table    *MulTable128
+	decode   func([16]byte) Element128
+	encode   func(Element128) [16]byte
+	acc      Element128
+	buf      [16]byte
+	buffered int
+}
+
+func (u *universalHash) update(block [16]byte) {
+	u.acc = u.table.Mul(u.acc.Add(u.decode(block)))
+}
+
+// Write absorbs p, it never fails
+func (u *universalHash) Write(p []byte) (int, error) {
+	n := len(p)
+	if u.buffered > 0 {
+		k := copy(u.buf[u.buffered:], p)
+		u.buffered += k
+		p = p[k:]
+		if u.buffered < 16 {
+			return n, nil
+		}
+		u.update(u.buf)
+		u.buffered = 0
+	}
+	for len(p) >= 16 {
+		var block [16]byte
+		copy(block[:], p)
+		u.update(block)
+		p = p[16:]
+	}
+	u.buffered = copy(u.buf[:], p)
+	return n, nil
+}
+
+// Pad zero pads the pending partial block if any, GCM pads the additional
+// data and the ciphertext separately.
+func (u *universalHash) Pad() {
+	if u.buffered == 0 {
+		return
+	}
+	for i := u.buffered; i < 16; i++ {
+		u.buf[i] = 0
+	}
+	u.update(u.buf)
+	u.buffered = 0
+}
+
+// Sum appends the hash of the data written so far to b, a pending partial
+// block is zero padded without changing the state.
+func (u *universalHash) Sum(b []byte) []byte {
+	acc := u.acc
+	if u.buffered > 0 {
+		var block [16]byte
+		copy(block[:], u.buf[:u.buffered])
+		acc = u.table.Mul(acc.Add(u.decode(block)))
+	}
+	out := u.encode(acc)
+	return append(b, out[:]...)
+}
+
+// Reset clears the state but keeps the key
+func (u *universalHash) Reset() {
+	u.acc = Element128{}
+	u.buffered = 0
+}
+
+// Size returns the size of the hash in bytes
+func (u *universalHash) Size() int {
+	return 16
+}
+
+// BlockSize returns the size of the blocks in bytes
+func (u *universalHash) BlockSize() int {
+	return 16
+}
+
+// GHASH is the universal hash of GCM, Y_i = (Y_(i-1) + X_i)H with blocks
+// in the GCM ordering. It implements hash.Hash.
+type GHASH struct {
+	universalHash
+}
+
+// POLYVAL is the universal hash of AES-GCM-SIV (RFC 8452),
+// S_i = dot(S_(i-1) + X_i, H) with blocks in the POLYVAL ordering.
+// It implements hash.Hash.
+type POLYVAL struct {
+	universalHash
+}
+
+var errHashKeySize = errors.New("universal hash key must be 16 bytes long")
+
+// NewGHASH creates a GHASH instance with key H, for GCM H is the encryption
+// of the zero block.
+func NewGHASH(key []byte) (*GHASH, error) {
+	if len(key) != 16 {
+		return nil, errHashKeySize
+	}
+	var k [16]byte
+	copy(k[:], key)
+	return &GHASH{universalHash{
+		table:  NewMulTable128(GCMElement(k)),
+		decode: GCMElement,
+		encode: Element128.GCM,
+	}}, nil
+}
+
+// NewPOLYVAL creates a POLYVAL instance with key H, the factor x of the
+// dot product is folded into the precomputed table.
+func NewPOLYVAL(key []byte) (*POLYVAL, error) {
+	if len(key) != 16 {
+		return nil, errHashKeySize
+	}
+	var k [16]byte
+	copy(k[:], key)
+	return &POLYVAL{universalHash{
+		table:  NewMulTable128(POLYVALElement(k).MulX()),
+		decode: POLYVALElement,
+		encode: Element128.POLYVAL,
+	}}, nil
+}
@@ -0,0 +1,158 @@
+package bf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+)
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// gcmTag computes the GCM authentication tag of (A, C) with a 96 bit IV
+func gcmTag(t *testing.T, key, iv, aad, ciphertext []byte) []byte {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := make([]byte, 16)
+	block.Encrypt(h, h)
+	g, _ := NewGHASH(h)
+	g.Write(aad)
+	g.Pad()
+	g.Write(ciphertext)
+	g.Pad()
+	var lengths [16]byte
+	binary.BigEndian.PutUint64(lengths[:8], uint64(len(aad))*8)
+	binary.BigEndian.PutUint64(lengths[8:], uint64(len(ciphertext))*8)
+	g.Write(lengths[:])
+	s := g.Sum(nil)
+
+	j0 := make([]byte, 16)
+	copy(j0, iv)
+	j0[15] = 1
+	block.Encrypt(j0, j0)
+	for i := range s {
+		s[i] ^= j0[i]
+	}
+	return s
+}
+
+func TestGF128(t *testing.T) {
+
+	t.Run("TestArithmetic", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			a := Element128{lo: rng.Uint64(), hi: rng.Uint64()}
+			b := Element128{lo: rng.Uint64(), hi: rng.Uint64()}
+			c := Element128{lo: rng.Uint64(), hi: rng.Uint64()}
+			if !NewMulTable128(b).Mul(a).Equal(a.Mul(b)) {
+				t.Fatal("table multiplication disagrees with the reference")
+			}
+			if !a.Mul(b.Add(c)).Equal(a.Mul(b).Add(a.Mul(c))) {
+				t.Error("multiplication isn't distributive")
+			}
+			if a.GCM() != GCMElement(a.GCM()).GCM() || !POLYVALElement(a.POLYVAL()).Equal(a) {
+				t.Error("encoding doesn't round trip")
+			}
+		}
+		// x^127 * x = x^7 + x^2 + x + 1
+		x127 := Element128{hi: 1 << 63}
+		if !x127.MulX().Equal(Element128{lo: 0x87}) {
+			t.Error("x^128 isn't reduced")
+		}
+	})
+
+	t.Run("TestGHASH", func(t *testing.T) {
+		// Test Cases 2 and 3 from the GCM specification
+		testCases := []struct {
+			key, iv, plaintext, ciphertext, tag string
+		}{
+			{
+				"00000000000000000000000000000000", "000000000000000000000000",
+				"00000000000000000000000000000000", "0388dace60b6a392f328c2b971b2fe78",
+				"ab6e47d42cec13bdf53a67b21257bddf",
+			},
+			{
+				"feffe9928665731c6d6a8f9467308308", "cafebabefacedbaddecaf888",
+				"d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a721c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b391aafd255",
+				"42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091473f5985",
+				"4d5c2af327cd64a62cf35abd2ba6fab4",
+			},
+		}
+		for _, tc := range testCases {
+			tag := gcmTag(t, unhex(tc.key), unhex(tc.iv), nil, unhex(tc.ciphertext))
+			if !bytes.Equal(tag, unhex(tc.tag)) {
+				t.Error("wrong GCM tag got", hex.EncodeToString(tag))
+			}
+		}
+		// random inputs with additional data against crypto/cipher
+		rng := rand.New(rand.NewSource(2))
+		for i := 0; i < 20; i++ {
+			key, iv := make([]byte, 16), make([]byte, 12)
+			aad, pt := make([]byte, rng.Intn(70)), make([]byte, rng.Intn(70))
+			rng.Read(key)
+			rng.Read(iv)
+			rng.Read(aad)
+			rng.Read(pt)
+			block, _ := aes.NewCipher(key)
+			gcm, _ := cipher.NewGCM(block)
+			sealed := gcm.Seal(nil, iv, pt, aad)
+			ct, expected := sealed[:len(pt)], sealed[len(pt):]
+			if tag := gcmTag(t, key, iv, aad, ct); !bytes.Equal(tag, expected) {
+				t.Fatal("GCM tag disagrees with crypto/cipher")
+			}
+		}
+	})
+
+	t.Run("TestPOLYVAL", func(t *testing.T) {
+		// RFC 8452 Appendix A
+		p, _ := NewPOLYVAL(unhex("25629347589242761d31f826ba4b757b"))
+		p.Write(unhex("4f4f95668c83dfb6401762bb2d01a262"))
+		p.Write(unhex("d1a24ddd2721d006bbe45f20d3c9f362"))
+		if s := p.Sum(nil); !bytes.Equal(s, unhex("f7a3b47b846119fae5b7866cf5e5b77e")) {
+			t.Error("wrong POLYVAL got", hex.EncodeToString(s))
+		}
+		// the dot product agrees with the precomputed table
+		h := POLYVALElement([16]byte{1, 2, 3})
+		x := POLYVALElement([16]byte{4, 5, 6})
+		q, _ := NewPOLYVAL([]byte{1, 2, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+		q.Write([]byte{4, 5, 6})
+		if s := x.Dot(h).POLYVAL(); !bytes.Equal(q.Sum(nil), s[:]) {
+			t.Error("POLYVAL disagrees with the dot product")
+		}
+		// POLYVAL(H, X) = ByteReverse(GHASH(mulX_GHASH(ByteReverse(H)), ByteReverse(X)))
+		rev := func(b []byte) []byte {
+			r := make([]byte, len(b))
+			for i := range b {
+				r[len(b)-1-i] = b[i]
+			}
+			return r
+		}
+		hg := GCMElement(bytesToBlock(rev(unhex("25629347589242761d31f826ba4b757b")))).MulX().GCM()
+		g, _ := NewGHASH(hg[:])
+		g.Write(rev(unhex("4f4f95668c83dfb6401762bb2d01a262")))
+		g.Write(rev(unhex("d1a24ddd2721d006bbe45f20d3c9f362")))
+		if s := g.Sum(nil); !bytes.Equal(rev(s), unhex("f7a3b47b846119fae5b7866cf5e5b77e")) {
+			t.Error("wrong GHASH got", hex.EncodeToString(s))
+		}
+		if _, err := NewPOLYVAL(make([]byte, 15)); err == nil {
+			t.Error("short keys should fail")
+		}
+	})
+}
+
+func bytesToBlock(b []byte) [16]byte {
+	var block [16]byte
+	copy(block[:], b)
+	return block
+}
@@ -0,0 +1,189 @@
+package bf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Common reduction polynomials of GF(2^8)
+const (
+	// PolyAES is x^8 + x^4 + x^3 + x + 1 used by AES (FIPS 197), it's
+	// irreducible but not primitive, x+1 generates the multiplicative group.
+	PolyAES = 0x11b
+	// PolyRS is x^8 + x^4 + x^3 + x^2 + 1 the primitive polynomial used by most
+	// Reed-Solomon codes (QR codes, CCSDS...), x generates the multiplicative group.
+	PolyRS = 0x11d
+)
+
+// GF256 represents GF(2^8) = GF(2)[x]/(f(x)) with elements stored as bytes,
+// multiplication goes through discrete logarithms in base g a generator of
+// the multiplicative group : a*b = g^(log(a) + log(b)).
+type GF256 struct {
+	poly uint16
+	gen  byte
+	// exp is doubled so that exp[log(a)+log(b)] needs no reduction mod 255
+	exp [510]byte
+	log [256]byte
+}
+
+// NewGF256 builds the log and antilog tables of GF(2^8) given a reduction
+// polynomial of degree 8, the generator is the smallest element of order 255.
+func NewGF256(poly uint16) (*GF256, error) {
+	if poly>>8 != 1 {
+		return nil, errors.New("reduction polynomial must have degree 8")
+	}
+	field := &GF256{poly: poly}
+	for g := 2; g < 256; g++ {
+		if field.buildTables(byte(g)) {
+			field.gen = byte(g)
+			return field, nil
+		}
+	}
+	return nil, errors.New("reduction polynomial isn't irreducible")
+}
+
+// buildTables fills the tables with the powers of g and returns false if g
+// doesn't generate the multiplicative group.
+func (gf *GF256) buildTables(g byte) bool {
+	var seen [256]bool
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		if seen[x] {
+			return false
+		}
+		seen[x] = true
+		gf.exp[i] = x
+		gf.exp[i+255] = x
+		gf.log[x] = byte(i)
+		x = gf.mulSlow(x, g)
+	}
+	return true
+}
+
+// mulSlow multiplies by shift and add, it's only used to build the tables.
+func (gf *GF256) mulSlow(a, b byte) byte {
+	var r uint16
+	x := uint16(a)
+	for i := uint(0); i < 8; i++ {
+		if b>>i&1 == 1 {
+			r ^= x
+		}
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= gf.poly
+		}
+	}
+	return byte(r)
+}
+
+// Poly returns the reduction polynomial
+func (gf *GF256) Poly() uint16 {
+	return gf.poly
+}
+
+// Generator returns the generator of the multiplicative group used for the tables
+func (gf *GF256) Generator() byte {
+	return gf.gen
+}
+
+// String implements stringer
+func (gf *GF256) String() string {
+	return fmt.Sprintf("GF(2^8)/0x%x", gf.poly)
+}
+
+// Add sums two elements i.e a XOR b
+func (gf *GF256) Add(a, b byte) byte {
+	return a ^ b
+}
+
+// Mul multiplies two elements
+func (gf *GF256) Mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gf.exp[int(gf.log[a])+int(gf.log[b])]
+}
+
+// Div computes a/b it panics when b = 0
+func (gf *GF256) Div(a, b byte) byte {
+	if b == 0 {
+		panic("division by zero in GF(2^8)")
+	}
+	if a == 0 {
+		return 0
+	}
+	return gf.exp[int(gf.log[a])+255-int(gf.log[b])]
+}
+
+// Inv computes a^-1 it panics when a = 0
+func (gf *GF256) Inv(a byte) byte {
+	return gf.Div(1, a)
+}
+
+// Exp computes a^n for any integer n, negative powers require a != 0
+func (gf *GF256) Exp(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	e := (int(gf.log[a]) * n) % 255
+	if e < 0 {
+		e += 255
+	}
+	return gf.exp[e]
+}
+
+// Pow returns g^n where g is the generator
+func (gf *GF256) Pow(n int) byte {
+	return gf.Exp(gf.gen, n)
+}
+
+// Log returns the discrete logarithm of a != 0 in base g
+func (gf *GF256) Log(a byte) int {
+	if a == 0 {
+		panic("logarithm of zero in GF(2^8)")
+	}
+	return int(gf.log[a])
+}
+
+// mulTable returns the row of the multiplication table of c
+func (gf *GF256) mulTable(c byte) *[256]byte {
+	var row [256]byte
+	if c == 0 {
+		return &row
+	}
+	lc := int(gf.log[c])
+	for x := 1; x < 256; x++ {
+		row[x] = gf.exp[lc+int(gf.log[x])]
+	}
+	return &row
+}
+
+// AddSlice computes out[i] ^= in[i]
+func (gf *GF256) AddSlice(in, out []byte) {
+	for i := range in {
+		out[i] ^= in[i]
+	}
+}
+
+// MulSlice computes out[i] = c*in[i], the product is looked up in the
+// multiplication table of c which amortizes the logarithms over the slice.
+func (gf *GF256) MulSlice(c byte, in, out []byte) {
+	row := gf.mulTable(c)
+	for i := range in {
+		out[i] = row[in[i]]
+	}
+}
+
+// MulAddSlice computes out[i] ^= c*in[i]
+func (gf *GF256) MulAddSlice(c byte, in, out []byte) {
+	if c == 0 {
+		return
+	}
+	row := gf.mulTable(c)
+	for i := range in {
+		out[i] ^= row[in[i]]
+	}
+}
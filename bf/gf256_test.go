@@ -0,0 +1,92 @@
+package bf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGF256(t *testing.T) {
+
+	t.Run("TestNewGF256", func(t *testing.T) {
+		aes, err := NewGF256(PolyAES)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// x isn't primitive modulo the AES polynomial
+		if aes.Generator() != 3 {
+			t.Error("expected generator 3 for the AES polynomial got", aes.Generator())
+		}
+		rs, _ := NewGF256(PolyRS)
+		if rs.Generator() != 2 {
+			t.Error("expected generator 2 for the RS polynomial got", rs.Generator())
+		}
+		// x^8 + 1 = (x + 1)^8
+		if _, err := NewGF256(0x101); err == nil {
+			t.Error("reducible polynomial should fail")
+		}
+		if _, err := NewGF256(0x1b); err == nil {
+			t.Error("polynomial of degree < 8 should fail")
+		}
+	})
+
+	t.Run("TestAES", func(t *testing.T) {
+		gf, _ := NewGF256(PolyAES)
+		if gf.Mul(0x57, 0x83) != 0xc1 {
+			t.Error("{57}.{83} != {c1}")
+		}
+		if gf.Mul(0x57, 0x13) != 0xfe {
+			t.Error("{57}.{13} != {fe}")
+		}
+		if gf.Inv(0x53) != 0xca {
+			t.Error("{53}^-1 != {ca}")
+		}
+	})
+
+	t.Run("TestArithmetic", func(t *testing.T) {
+		for _, poly := range []uint16{PolyAES, PolyRS} {
+			gf, _ := NewGF256(poly)
+			for a := 0; a < 256; a++ {
+				for b := 0; b < 256; b++ {
+					if gf.Mul(byte(a), byte(b)) != gf.mulSlow(byte(a), byte(b)) {
+						t.Fatal("table multiplication disagrees with shift and add in", gf)
+					}
+					if b != 0 && gf.Mul(gf.Div(byte(a), byte(b)), byte(b)) != byte(a) {
+						t.Fatal("(a/b)*b != a in", gf)
+					}
+				}
+				if a != 0 {
+					if gf.Pow(gf.Log(byte(a))) != byte(a) {
+						t.Error("g^log(a) != a in", gf)
+					}
+					if gf.Exp(byte(a), -1) != gf.Inv(byte(a)) || gf.Exp(byte(a), 255) != 1 {
+						t.Error("exponentiation failed in", gf)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("TestSliceOps", func(t *testing.T) {
+		gf, _ := NewGF256(PolyRS)
+		in := make([]byte, 256)
+		for i := range in {
+			in[i] = byte(i)
+		}
+		out := make([]byte, len(in))
+		gf.MulSlice(0x8e, in, out)
+		for i := range in {
+			if out[i] != gf.Mul(0x8e, in[i]) {
+				t.Fatal("MulSlice failed")
+			}
+		}
+		// out + c*in = c*in + c*in = 0
+		gf.MulAddSlice(0x8e, in, out)
+		if !bytes.Equal(out, make([]byte, len(in))) {
+			t.Error("MulAddSlice failed")
+		}
+		gf.AddSlice(in, out)
+		if !bytes.Equal(out, in) {
+			t.Error("AddSlice failed")
+		}
+	})
+}
@@ -0,0 +1,282 @@
+package bf
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// Poly is a polynomial of any degree over GF(2), as for field elements the
+// bit i of the word j is the coefficient of x^(64j+i).
+type Poly []uint64
+
+// NewPoly returns the polynomial sum x^e for the given exponents,
+// NewPoly(233, 74, 0) is x^233 + x^74 + 1.
+func NewPoly(exps ...int) Poly {
+	var p Poly
+	for _, e := range exps {
+		for len(p) <= e/wordSize {
+			p = append(p, 0)
+		}
+		p[e/wordSize] ^= 1 << uint(e%wordSize)
+	}
+	return p.trim()
+}
+
+// trim drops the zero words of high degree
+func (p Poly) trim() Poly {
+	n := len(p)
+	for n > 0 && p[n-1] == 0 {
+		n--
+	}
+	return p[:n]
+}
+
+// Degree returns the degree of p or -1 for the zero polynomial
+func (p Poly) Degree() int {
+	return degree(Element(p))
+}
+
+// IsZero returns true if p = 0
+func (p Poly) IsZero() bool {
+	return p.Degree() < 0
+}
+
+// Equal returns true if p = q
+func (p Poly) Equal(q Poly) bool {
+	p, q = p.trim(), q.trim()
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Exponents returns the exponents of the non zero terms in decreasing order,
+// for an irreducible p they can be passed to NewField.
+func (p Poly) Exponents() []int {
+	var exps []int
+	for i := p.Degree(); i >= 0; i-- {
+		if p[i/wordSize]>>uint(i%wordSize)&1 == 1 {
+			exps = append(exps, i)
+		}
+	}
+	return exps
+}
+
+// String implements stringer
+func (p Poly) String() string {
+	if p.IsZero() {
+		return "0"
+	}
+	s := ""
+	for i, e := range p.Exponents() {
+		if i > 0 {
+			s += " + "
+		}
+		switch e {
+		case 0:
+			s += "1"
+		case 1:
+			s += "x"
+		default:
+			s += fmt.Sprintf("x^%d", e)
+		}
+	}
+	return s
+}
+
+// Add computes p + q
+func (p Poly) Add(q Poly) Poly {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := make(Poly, len(p))
+	copy(r, p)
+	for i := range q {
+		r[i] ^= q[i]
+	}
+	return r.trim()
+}
+
+// Mul computes p * q
+func (p Poly) Mul(q Poly) Poly {
+	if p.IsZero() || q.IsZero() {
+		return Poly{}
+	}
+	return Poly(clmul(Element(p), Element(q))).trim()
+}
+
+// DivMod computes the quotient and remainder of p by q, it panics when q = 0
+func (p Poly) DivMod(q Poly) (quo, rem Poly) {
+	dq := q.Degree()
+	if dq < 0 {
+		panic("division by the zero polynomial")
+	}
+	rem = make(Poly, len(p))
+	copy(rem, p)
+	dr := rem.Degree()
+	if dr < dq {
+		return Poly{}, rem.trim()
+	}
+	quo = make(Poly, (dr-dq)/wordSize+1)
+	// pad q so that the shifts read inside it
+	padded := make(Element, len(rem))
+	copy(padded, q)
+	for ; dr >= dq; dr = rem.Degree() {
+		j := dr - dq
+		quo[j/wordSize] |= 1 << uint(j%wordSize)
+		xorShifted(Element(rem), padded, j)
+	}
+	return quo.trim(), rem.trim()
+}
+
+// Mod computes p mod q
+func (p Poly) Mod(q Poly) Poly {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// GCD computes the greatest common divisor of p and q
+func (p Poly) GCD(q Poly) Poly {
+	for !q.IsZero() {
+		p, q = q, p.Mod(q)
+	}
+	return p.trim()
+}
+
+// MulMod computes p * q mod f
+func (p Poly) MulMod(q, f Poly) Poly {
+	return p.Mul(q).Mod(f)
+}
+
+// ExpMod computes p^k mod f using square and multiply
+func (p Poly) ExpMod(k *nt.Integer, f Poly) Poly {
+	res := NewPoly(0).Mod(f)
+	base := p.Mod(f)
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		res = res.MulMod(res, f)
+		if k.Bit(i) == 1 {
+			res = res.MulMod(base, f)
+		}
+	}
+	return res
+}
+
+// coprimeWithXPow returns true if gcd(f, h + x) = 1
+func (p Poly) coprimeWithXPow(h Poly) bool {
+	return p.GCD(h.Add(NewPoly(1))).Degree() == 0
+}
+
+// IsIrreducible tests whether p is irreducible with Ben-Or's algorithm :
+// p of degree n is irreducible if gcd(p, x^(2^i) + x) = 1 for 1 <= i <= n/2.
+func (p Poly) IsIrreducible() bool {
+	n := p.Degree()
+	if n < 1 {
+		return false
+	}
+	h := NewPoly(1)
+	for i := 1; i <= n/2; i++ {
+		h = h.MulMod(h, p)
+		if !p.coprimeWithXPow(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsIrreducibleRabin tests whether p is irreducible with Rabin's algorithm :
+// p of degree n is irreducible if and only if it divides x^(2^n) + x and
+// gcd(p, x^(2^(n/q)) + x) = 1 for every prime q dividing n.
+func (p Poly) IsIrreducibleRabin() bool {
+	n := p.Degree()
+	if n < 1 {
+		return false
+	}
+	chain := make([]Poly, n)
+	h := NewPoly(1)
+	for i := 0; i < n; i++ {
+		h = h.MulMod(h, p)
+		chain[i] = h
+	}
+	for _, q := range nt.PrimeFactors(nt.FromInt64(int64(n))) {
+		if !p.coprimeWithXPow(chain[n/int(q.Int64())-1]) {
+			return false
+		}
+	}
+	return chain[n-1].Equal(NewPoly(1).Mod(p))
+}
+
+// IsPrimitive tests whether p is irreducible and x generates the
+// multiplicative group of GF(2)[x]/(p), this requires factoring 2^n - 1.
+func (p Poly) IsPrimitive() bool {
+	if !p.IsIrreducible() || p[0]&1 == 0 {
+		return false
+	}
+	order := nt.Sub(new(big.Int).Lsh(nt.One, uint(p.Degree())), nt.One)
+	one := NewPoly(0).Mod(p)
+	for _, q := range nt.PrimeFactors(order) {
+		if NewPoly(1).ExpMod(nt.Div(order, q), p).Equal(one) {
+			return false
+		}
+	}
+	return true
+}
+
+// RandomIrreducible samples polynomials of degree m with a constant term
+// until one is irreducible.
+func RandomIrreducible(m int) (Poly, error) {
+	if m < 1 {
+		return nil, errors.New("degree must be positive")
+	}
+	bound := new(big.Int).Lsh(nt.One, uint(m))
+	for {
+		n, err := rand.Int(rand.Reader, bound)
+		if err != nil {
+			return nil, err
+		}
+		p := make(Poly, m/wordSize+1)
+		for i := 0; i < m; i++ {
+			if n.Bit(i) == 1 {
+				p[i/wordSize] |= 1 << uint(i%wordSize)
+			}
+		}
+		p[0] |= 1
+		p[m/wordSize] |= 1 << uint(m%wordSize)
+		if p.IsIrreducible() {
+			return p, nil
+		}
+	}
+}
+
+// LowWeightIrreducible returns the exponents of an irreducible trinomial
+// x^m + x^k + 1 with the smallest k or if there's none the pentanomial
+// x^m + x^k3 + x^k2 + x^k1 + 1 with the smallest k3 then k2 then k1, which
+// is the choice of ANSI X9.62 and gives the NIST reduction polynomials.
+func LowWeightIrreducible(m int) ([]int, error) {
+	if m < 2 {
+		return nil, errors.New("degree must be at least 2")
+	}
+	for k := 1; k < m; k++ {
+		if NewPoly(m, k, 0).IsIrreducible() {
+			return []int{m, k, 0}, nil
+		}
+	}
+	for k3 := 3; k3 < m; k3++ {
+		for k2 := 2; k2 < k3; k2++ {
+			for k1 := 1; k1 < k2; k1++ {
+				if NewPoly(m, k3, k2, k1, 0).IsIrreducible() {
+					return []int{m, k3, k2, k1, 0}, nil
+				}
+			}
+		}
+	}
+	return nil, errors.New("no irreducible trinomial or pentanomial of degree " + fmt.Sprint(m))
+}
@@ -0,0 +1,70 @@
+package bf
+
+import (
+	"testing"
+)
+
+func TestPoly(t *testing.T) {
+
+	t.Run("TestArithmetic", func(t *testing.T) {
+		p := NewPoly(200, 130, 64, 63, 1)
+		q := NewPoly(70, 3, 0)
+		quo, rem := p.DivMod(q)
+		if !quo.Mul(q).Add(rem).Equal(p) || rem.Degree() >= q.Degree() {
+			t.Error("p != quo*q + rem")
+		}
+		// (x + 1)^2 = x^2 + 1 in characteristic 2
+		if !NewPoly(1, 0).Mul(NewPoly(1, 0)).Equal(NewPoly(2, 0)) {
+			t.Error("(x + 1)^2 != x^2 + 1")
+		}
+		g := p.Mul(q).GCD(q.Mul(NewPoly(5, 2, 0)))
+		if !g.Equal(q) {
+			t.Error("gcd(pq, q(x^5 + x^2 + 1)) != q got", g)
+		}
+		if NewPoly(233, 74, 0).String() != "x^233 + x^74 + 1" {
+			t.Error("wrong string representation")
+		}
+	})
+
+	t.Run("TestIrreducible", func(t *testing.T) {
+		for _, exps := range nistPolys {
+			p := NewPoly(exps...)
+			if !p.IsIrreducible() || !p.IsIrreducibleRabin() {
+				t.Error(p, "is irreducible")
+			}
+			low, err := LowWeightIrreducible(exps[0])
+			if err != nil || !NewPoly(low...).Equal(p) {
+				t.Error("low weight polynomial of degree", exps[0], "isn't the NIST one got", low)
+			}
+		}
+		// x^8 + 1 and x^4 + x^2 + 1 = (x^2 + x + 1)^2
+		for _, p := range []Poly{NewPoly(8, 0), NewPoly(4, 2, 0), NewPoly(0), NewPoly(233, 74, 1)} {
+			if p.IsIrreducible() || p.IsIrreducibleRabin() {
+				t.Error(p, "is reducible")
+			}
+		}
+		p, err := RandomIrreducible(127)
+		if err != nil || p.Degree() != 127 || !p.IsIrreducibleRabin() {
+			t.Error("random polynomial isn't irreducible")
+		}
+	})
+
+	t.Run("TestPrimitive", func(t *testing.T) {
+		aes := NewPoly(8, 4, 3, 1, 0)
+		rs := NewPoly(8, 4, 3, 2, 0)
+		if !aes.IsIrreducible() || aes.IsPrimitive() {
+			t.Error("the AES polynomial is irreducible but not primitive")
+		}
+		if !rs.IsPrimitive() {
+			t.Error("the Reed-Solomon polynomial is primitive")
+		}
+		// 2^127 - 1 is prime so every irreducible polynomial of degree 127 is primitive
+		if !NewPoly(127, 1, 0).IsPrimitive() {
+			t.Error("x^127 + x + 1 is primitive")
+		}
+		// x^4 + x^3 + x^2 + x + 1 divides x^5 - 1
+		if NewPoly(4, 3, 2, 1, 0).IsPrimitive() {
+			t.Error("x has order 5 modulo x^4 + x^3 + x^2 + x + 1")
+		}
+	})
+}
@@ -0,0 +1,281 @@
+package bf
+
+import (
+	"errors"
+)
+
+// ReedSolomon is a systematic Reed-Solomon code over GF(2^8) with nsym parity
+// bytes, codewords are at most 255 bytes long and the generator polynomial is
+// g(x) = (x - a^0)(x - a^1)...(x - a^(nsym-1)) where a generates the field.
+// A codeword c(x) = m(x)x^nsym - (m(x)x^nsym mod g(x)) carries the message
+// in its first bytes, it corrects e errors and f erasures when 2e + f <= nsym.
+//
+// Polynomials over GF(2^8) are byte slices with the leading coefficient
+// first so that the first byte of a codeword is its highest degree term.
+type ReedSolomon struct {
+	gf   *GF256
+	nsym int
+	gen  []byte
+}
+
+var errTooManyErrors = errors.New("too many errors to correct")
+
+// NewReedSolomon creates a code with nsym parity bytes over the field
+func NewReedSolomon(gf *GF256, nsym int) (*ReedSolomon, error) {
+	if nsym <= 0 || nsym >= 255 {
+		return nil, errors.New("number of parity bytes must be in [1, 254]")
+	}
+	gen := []byte{1}
+	for i := 0; i < nsym; i++ {
+		gen = gf.polyMul(gen, []byte{1, gf.Pow(i)})
+	}
+	return &ReedSolomon{gf: gf, nsym: nsym, gen: gen}, nil
+}
+
+// ParityLen returns the number of parity bytes
+func (rs *ReedSolomon) ParityLen() int {
+	return rs.nsym
+}
+
+// Generator returns a copy of the generator polynomial
+func (rs *ReedSolomon) Generator() []byte {
+	return append([]byte(nil), rs.gen...)
+}
+
+// Encode returns the codeword msg || parity, the parity bytes are the
+// remainder of m(x)x^nsym by g(x) computed by synthetic division.
+func (rs *ReedSolomon) Encode(msg []byte) ([]byte, error) {
+	if len(msg)+rs.nsym > 255 {
+		return nil, errors.New("message is too long for the code")
+	}
+	out := make([]byte, len(msg)+rs.nsym)
+	copy(out, msg)
+	for i := range msg {
+		if coef := out[i]; coef != 0 {
+			rs.gf.MulAddSlice(coef, rs.gen[1:], out[i+1:])
+		}
+	}
+	copy(out, msg)
+	return out, nil
+}
+
+// Syndromes evaluates the codeword at the roots of the generator, they're
+// all zero if and only if the codeword is valid.
+func (rs *ReedSolomon) Syndromes(codeword []byte) []byte {
+	synd := make([]byte, rs.nsym)
+	for i := range synd {
+		synd[i] = rs.gf.polyEval(codeword, rs.gf.Pow(i))
+	}
+	return synd
+}
+
+// Check returns true if the codeword has no detectable errors
+func (rs *ReedSolomon) Check(codeword []byte) bool {
+	for _, s := range rs.Syndromes(codeword) {
+		if s != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Decode corrects the codeword and returns the message, erasures are the
+// indices of the bytes known to be corrupted, repeated indices count once.
+// The erasures are folded into the syndromes (Forney syndromes) so that
+// Berlekamp-Massey only finds the locator of the unknown errors, their
+// positions are found by Chien search and all the magnitudes are computed
+// with Forney's algorithm.
+func (rs *ReedSolomon) Decode(codeword []byte, erasures []int) ([]byte, error) {
+	n := len(codeword)
+	if n > 255 || n <= rs.nsym {
+		return nil, errors.New("invalid codeword length")
+	}
+	out := append([]byte(nil), codeword...)
+	seen := make(map[int]bool, len(erasures))
+	unique := make([]int, 0, len(erasures))
+	for _, pos := range erasures {
+		if pos < 0 || pos >= n {
+			return nil, errors.New("erasure position out of range")
+		}
+		if !seen[pos] {
+			seen[pos] = true
+			unique = append(unique, pos)
+		}
+		out[pos] = 0
+	}
+	erasures = unique
+	if len(erasures) > rs.nsym {
+		return nil, errTooManyErrors
+	}
+	synd := rs.Syndromes(out)
+	if isZeroPoly(synd) {
+		return out[:n-rs.nsym], nil
+	}
+	fsynd := rs.forneySyndromes(synd, erasures, n)
+	errLoc, err := rs.errorLocator(fsynd, len(erasures))
+	if err != nil {
+		return nil, err
+	}
+	errPos, err := rs.chienSearch(errLoc, n)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.correctErrata(out, synd, append(erasures, errPos...)); err != nil {
+		return nil, err
+	}
+	if !rs.Check(out) {
+		return nil, errors.New("failed to correct the codeword")
+	}
+	return out[:n-rs.nsym], nil
+}
+
+// forneySyndromes removes the contribution of the erasures from the syndromes
+// by multiplying the syndrome polynomial with (1 + X_i x) for each erasure.
+func (rs *ReedSolomon) forneySyndromes(synd []byte, erasures []int, n int) []byte {
+	fsynd := append([]byte(nil), synd...)
+	for _, pos := range erasures {
+		x := rs.gf.Pow(n - 1 - pos)
+		for j := 0; j < len(fsynd)-1; j++ {
+			fsynd[j] = rs.gf.Mul(fsynd[j], x) ^ fsynd[j+1]
+		}
+	}
+	return fsynd
+}
+
+// errorLocator runs Berlekamp-Massey on the Forney syndromes to find the
+// error locator polynomial, only nsym - erasures syndromes are usable.
+func (rs *ReedSolomon) errorLocator(synd []byte, erasures int) ([]byte, error) {
+	errLoc := []byte{1}
+	oldLoc := []byte{1}
+	for k := 0; k < rs.nsym-erasures; k++ {
+		// discrepancy between the syndrome and the one predicted by the locator
+		delta := synd[k]
+		for j := 1; j < len(errLoc) && j <= k; j++ {
+			delta ^= rs.gf.Mul(errLoc[len(errLoc)-1-j], synd[k-j])
+		}
+		oldLoc = append(oldLoc, 0)
+		if delta == 0 {
+			continue
+		}
+		if len(oldLoc) > len(errLoc) {
+			newLoc := rs.gf.polyScale(oldLoc, delta)
+			oldLoc = rs.gf.polyScale(errLoc, rs.gf.Inv(delta))
+			errLoc = newLoc
+		}
+		errLoc = polyAdd(errLoc, rs.gf.polyScale(oldLoc, delta))
+	}
+	for len(errLoc) > 1 && errLoc[0] == 0 {
+		errLoc = errLoc[1:]
+	}
+	if 2*(len(errLoc)-1)+erasures > rs.nsym {
+		return nil, errTooManyErrors
+	}
+	return errLoc, nil
+}
+
+// chienSearch finds the error positions as the inverses of the roots of the
+// error locator, we evaluate its reciprocal at every a^i instead.
+func (rs *ReedSolomon) chienSearch(errLoc []byte, n int) ([]int, error) {
+	rev := make([]byte, len(errLoc))
+	for i, c := range errLoc {
+		rev[len(errLoc)-1-i] = c
+	}
+	var pos []int
+	for i := 0; i < n; i++ {
+		if rs.gf.polyEval(rev, rs.gf.Pow(i)) == 0 {
+			pos = append(pos, n-1-i)
+		}
+	}
+	if len(pos) != len(errLoc)-1 {
+		return nil, errTooManyErrors
+	}
+	return pos, nil
+}
+
+// correctErrata computes the magnitudes of the errors and erasures at the
+// given positions with Forney's algorithm e_i = X_i Ω(X_i^-1) / Λ'(X_i^-1)
+// and subtracts them from the codeword. The positions must be distinct, Λ'
+// vanishes at a repeated root which happens when Chien search lands on an
+// erasure of a codeword with too many errors.
+func (rs *ReedSolomon) correctErrata(codeword []byte, synd []byte, pos []int) error {
+	n := len(codeword)
+	gf := rs.gf
+	X := make([]byte, len(pos))
+	// errata locator Λ(x) = prod (1 + X_i x)
+	loc := []byte{1}
+	for i, p := range pos {
+		X[i] = gf.Pow(n - 1 - p)
+		loc = gf.polyMul(loc, []byte{X[i], 1})
+	}
+	// errata evaluator Ω(x) = S(x)Λ(x) mod x^(deg Λ + 1) with S(x) = sum S_i x^(i+1)
+	s := make([]byte, len(synd)+1)
+	for i, si := range synd {
+		s[len(synd)-1-i] = si
+	}
+	prod := gf.polyMul(s, loc)
+	omega := prod[len(prod)-len(loc):]
+
+	for i, Xi := range X {
+		XiInv := gf.Inv(Xi)
+		// formal derivative Λ'(X_i^-1) = prod_(j != i) (1 + X_j X_i^-1)
+		derivative := byte(1)
+		for j, Xj := range X {
+			if j != i {
+				derivative = gf.Mul(derivative, 1^gf.Mul(XiInv, Xj))
+			}
+		}
+		if derivative == 0 {
+			return errTooManyErrors
+		}
+		y := gf.Mul(Xi, gf.polyEval(omega, XiInv))
+		codeword[pos[i]] ^= gf.Div(y, derivative)
+	}
+	return nil
+}
+
+// polyMul multiplies two polynomials over GF(2^8)
+func (gf *GF256) polyMul(p, q []byte) []byte {
+	r := make([]byte, len(p)+len(q)-1)
+	for i, c := range p {
+		gf.MulAddSlice(c, q, r[i:])
+	}
+	return r
+}
+
+// polyScale multiplies a polynomial by a scalar
+func (gf *GF256) polyScale(p []byte, c byte) []byte {
+	r := make([]byte, len(p))
+	gf.MulSlice(c, p, r)
+	return r
+}
+
+// polyEval evaluates a polynomial at x using Horner's rule
+func (gf *GF256) polyEval(p []byte, x byte) byte {
+	var y byte
+	for _, c := range p {
+		y = gf.Mul(y, x) ^ c
+	}
+	return y
+}
+
+// polyAdd sums two polynomials aligning their constant terms
+func polyAdd(p, q []byte) []byte {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := append([]byte(nil), p...)
+	off := len(p) - len(q)
+	for i, c := range q {
+		r[off+i] ^= c
+	}
+	return r
+}
+
+func isZeroPoly(p []byte) bool {
+	for _, c := range p {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
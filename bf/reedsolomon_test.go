@@ -0,0 +1,107 @@
+package bf
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestReedSolomon(t *testing.T) {
+	gf, _ := NewGF256(PolyRS)
+
+	t.Run("TestEncode", func(t *testing.T) {
+		rs, _ := NewReedSolomon(gf, 10)
+		// QR code version 1-M data codewords from "Reed-Solomon codes for coders"
+		msg := []byte{
+			0x40, 0xd2, 0x75, 0x47, 0x76, 0x17, 0x32, 0x06,
+			0x27, 0x26, 0x96, 0xc6, 0xc6, 0x96, 0x70, 0xec,
+		}
+		parity := []byte{0xbc, 0x2a, 0x90, 0x13, 0x6b, 0xaf, 0xef, 0xfd, 0x4b, 0xe0}
+		codeword, err := rs.Encode(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(codeword, append(msg, parity...)) {
+			t.Error("wrong codeword got", codeword)
+		}
+		if !rs.Check(codeword) {
+			t.Error("codeword has non zero syndromes")
+		}
+		if _, err := rs.Encode(make([]byte, 246)); err == nil {
+			t.Error("messages longer than 255 - nsym should fail")
+		}
+	})
+
+	t.Run("TestDecode", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(42))
+		for _, nsym := range []int{2, 10, 32} {
+			rs, _ := NewReedSolomon(gf, nsym)
+			for trial := 0; trial < 50; trial++ {
+				msg := make([]byte, 1+rng.Intn(255-nsym))
+				rng.Read(msg)
+				codeword, _ := rs.Encode(msg)
+				// split the budget 2e + f <= nsym between errors and erasures
+				nerasures := rng.Intn(nsym + 1)
+				nerrors := (nsym - nerasures) / 2
+				if nerasures+nerrors > len(codeword) {
+					continue
+				}
+				perm := rng.Perm(len(codeword))
+				erasures := perm[:nerasures]
+				corrupted := append([]byte(nil), codeword...)
+				for _, pos := range perm[:nerasures+nerrors] {
+					corrupted[pos] ^= byte(1 + rng.Intn(255))
+				}
+				decoded, err := rs.Decode(corrupted, erasures)
+				if err != nil {
+					t.Fatal("failed to decode with", nerrors, "errors and", nerasures, "erasures :", err)
+				}
+				if !bytes.Equal(decoded, msg) {
+					t.Fatal("decoded message differs with", nerrors, "errors and", nerasures, "erasures")
+				}
+			}
+		}
+	})
+
+	t.Run("TestTooManyErrors", func(t *testing.T) {
+		rs, _ := NewReedSolomon(gf, 4)
+		codeword, _ := rs.Encode([]byte("hello world"))
+		if _, err := rs.Decode(codeword, []int{0, 1, 2, 3, 4}); err == nil {
+			t.Error("more erasures than parity bytes should fail")
+		}
+		// 3 errors exceed the capacity, decoding must fail or miscorrect
+		corrupted := append([]byte(nil), codeword...)
+		corrupted[0] ^= 1
+		corrupted[5] ^= 2
+		corrupted[9] ^= 3
+		if msg, err := rs.Decode(corrupted, nil); err == nil && bytes.Equal(msg, []byte("hello world")) {
+			t.Error("decoder can't recover more than nsym/2 errors")
+		}
+		// Chien search finds the erased position 4 so the errata locator has
+		// a double root, decoding must fail instead of dividing by zero
+		rs, _ = NewReedSolomon(gf, 3)
+		codeword, _ = rs.Encode([]byte("hello"))
+		corrupted = append([]byte(nil), codeword...)
+		corrupted[1] ^= 158
+		corrupted[4] ^= 210
+		corrupted[5] ^= 3
+		if _, err := rs.Decode(corrupted, []int{4}); err != errTooManyErrors {
+			t.Error("expected", errTooManyErrors, "got", err)
+		}
+	})
+
+	t.Run("TestDuplicateErasures", func(t *testing.T) {
+		rs, _ := NewReedSolomon(gf, 4)
+		codeword, _ := rs.Encode([]byte("hello world"))
+		corrupted := append([]byte(nil), codeword...)
+		corrupted[3] ^= 0x5a
+		corrupted[7] ^= 0x11
+		msg, err := rs.Decode(corrupted, []int{3, 3})
+		if err != nil || !bytes.Equal(msg, []byte("hello world")) {
+			t.Error("repeated erasures should count once got", msg, err)
+		}
+		if _, err := rs.Decode(corrupted, []int{3, 3, 3, 3, 3}); err != nil {
+			t.Error("a single repeated erasure is within the capacity got", err)
+		}
+	})
+}
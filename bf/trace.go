@@ -0,0 +1,117 @@
+package bf
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var errNoSolution = errors.New("equation has no solution in the field")
+
+// traceMask computes the traces of the basis elements Tr(x^i) for 0 <= i < m.
+// Tr(x^i) is the i-th power sum of the roots of f, writing
+// f(x) = x^m + c_1x^(m-1) + ... + c_m Newton's identities give in char 2
+// s_k = k*c_k + c_1s_(k-1) + ... + c_(k-1)s_1 and s_0 = m mod 2.
+func (f *Field) traceMask() Element {
+	// the non zero c_j are c_(m-e) for e in exps
+	var cs []int
+	for _, e := range f.exps[1:] {
+		cs = append(cs, f.m-e)
+	}
+	s := make([]uint, f.m)
+	s[0] = uint(f.m & 1)
+	for k := 1; k < f.m; k++ {
+		var sk uint
+		for _, j := range cs {
+			if j == k {
+				sk ^= uint(k & 1)
+			} else if j < k {
+				sk ^= s[k-j]
+			}
+		}
+		s[k] = sk
+	}
+	mask := f.Zero()
+	for i, si := range s {
+		if si == 1 {
+			mask[i/wordSize] |= 1 << uint(i%wordSize)
+		}
+	}
+	return mask
+}
+
+// Trace computes Tr(a) = a + a^2 + a^4 + ... + a^(2^(m-1)) which is 0 or 1,
+// the trace is linear so it's the parity of the bits of a selected by the
+// traces of the basis elements.
+func (f *Field) Trace(a Element) uint {
+	var acc uint64
+	for i := 0; i < f.words; i++ {
+		acc ^= a[i] & f.tr[i]
+	}
+	return uint(bits.OnesCount64(acc) & 1)
+}
+
+// HalfTrace computes H(a) = sum a^(2^2i) for 0 <= i <= (m-1)/2 which is
+// only defined for odd m, when Tr(a) = 0 it's a solution of z^2 + z = a.
+func (f *Field) HalfTrace(a Element) (Element, error) {
+	if f.m%2 == 0 {
+		return nil, errors.New("half-trace requires an odd degree")
+	}
+	r := f.Copy(a)
+	t := f.Copy(a)
+	for i := 1; i <= (f.m-1)/2; i++ {
+		t = f.SquareN(t, 2)
+		r = f.Add(r, t)
+	}
+	return r, nil
+}
+
+// SolveQuadratic finds z such that z^2 + z = c, the other solution is z + 1.
+// A solution exists if and only if Tr(c) = 0 (IEEE 1363 A.4.7),
+// for odd m z is the half-trace of c otherwise we build it from an element
+// of trace 1.
+func (f *Field) SolveQuadratic(c Element) (Element, error) {
+	if f.Trace(c) != 0 {
+		return nil, errNoSolution
+	}
+	if f.m%2 == 1 {
+		return f.HalfTrace(c)
+	}
+	// pick tau = x^i with Tr(x^i) = 1
+	tau := f.Zero()
+	for i := 0; i < f.m; i++ {
+		if f.tr[i/wordSize]>>uint(i%wordSize)&1 == 1 {
+			tau[i/wordSize] |= 1 << uint(i%wordSize)
+			break
+		}
+	}
+	z := f.Zero()
+	w := f.Copy(c)
+	for i := 1; i < f.m; i++ {
+		z = f.Add(f.Square(z), f.Mul(f.Square(w), tau))
+		w = f.Add(f.Square(w), c)
+	}
+	if !f.Equal(f.Add(f.Square(z), z), c) {
+		return nil, errNoSolution
+	}
+	return z, nil
+}
+
+// SolveQuadraticGeneral finds x such that ax^2 + bx + c = 0 with a != 0.
+// When b = 0 the solution is the square root of c/a otherwise we substitute
+// x = (b/a)z which gives z^2 + z = ac/b^2.
+func (f *Field) SolveQuadraticGeneral(a, b, c Element) (Element, error) {
+	if f.IsZero(a) {
+		return nil, errors.New("leading coefficient is zero")
+	}
+	if f.IsZero(b) {
+		t, _ := f.Div(c, a)
+		return f.Sqrt(t), nil
+	}
+	t, _ := f.Div(f.Mul(a, c), f.Square(b))
+	z, err := f.SolveQuadratic(t)
+	if err != nil {
+		return nil, err
+	}
+	s, _ := f.Div(b, a)
+	return f.Mul(s, z), nil
+}
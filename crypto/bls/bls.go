@@ -0,0 +1,289 @@
+// Package bls implements BLS signatures on the BLS12-381 curve following the
+// IETF draft, a signature is the hash of the message to a curve point
+// multiplied by the secret key and it's verified by a pairing equation
+// e(pk, H(m)) = e(g, sig). Signatures on the same group aggregate to a single
+// point by addition.
+// ref : draft-irtf-cfrg-bls-signature-05 and RFC 9380 for hashing to curves
+package bls
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	errShortIKM  = errors.New("key generation requires at least 32 bytes of key material")
+	errAggregate = errors.New("aggregation requires at least one signature")
+)
+
+// Variant selects the groups of public keys and signatures
+type Variant int
+
+const (
+	// MinPk places public keys in G1 (48 bytes) and signatures in G2 (96 bytes)
+	MinPk Variant = iota
+	// MinSig places public keys in G2 (96 bytes) and signatures in G1 (48 bytes)
+	MinSig
+)
+
+// Mode selects how aggregate signatures are protected against rogue key
+// attacks where an adversary picks its public key as a function of others.
+type Mode int
+
+const (
+	// Basic requires the messages of an aggregate signature to be distinct
+	Basic Mode = iota
+	// Aug prepends the public key to each signed message
+	Aug
+	// Pop requires each public key to come with a proof of possession of the
+	// secret key, it allows fast verification of signatures on one message.
+	Pop
+)
+
+// SecretKey is a scalar in [1, r - 1]
+type SecretKey struct {
+	K *nt.Integer
+}
+
+// PublicKey is a compressed point of G1 for MinPk and of G2 for MinSig
+type PublicKey []byte
+
+// Signature is a compressed point of G2 for MinPk and of G1 for MinSig
+type Signature []byte
+
+// Scheme is a BLS signature scheme instantiated with a variant and a mode
+type Scheme struct {
+	Variant Variant
+	Mode    Mode
+	// dst and popDST are the domain separation tags of signatures and
+	// proofs of possession
+	dst    []byte
+	popDST []byte
+}
+
+// NewScheme returns the ciphersuite BLS_SIG_BLS12381G{1,2}_XMD:SHA-256_SSWU_RO_
+// with the given variant and mode
+func NewScheme(v Variant, m Mode) *Scheme {
+	group := "G2"
+	if v == MinSig {
+		group = "G1"
+	}
+	suite := "BLS12381" + group + "_XMD:SHA-256_SSWU_RO_"
+	tag := []string{"NUL_", "AUG_", "POP_"}[m]
+	return &Scheme{
+		Variant: v,
+		Mode:    m,
+		dst:     []byte("BLS_SIG_" + suite + tag),
+		popDST:  []byte("BLS_POP_" + suite + tag),
+	}
+}
+
+// KeyGen derives a secret key from at least 32 bytes of secret key material
+// ikm and optional key information, SK = HKDF(ikm, keyInfo) mod r where the
+// salt is rehashed until the key isn't zero.
+func KeyGen(ikm, keyInfo []byte) (*SecretKey, error) {
+	if len(ikm) < 32 {
+		return nil, errShortIKM
+	}
+	// L = ceil((3 * ceil(log2(r)))/16)
+	const L = 48
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	secret := append(append([]byte{}, ikm...), 0)
+	info := append(append([]byte{}, keyInfo...), 0, L)
+	okm := make([]byte, L)
+	for {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		prk := hkdf.Extract(sha256.New, secret, salt)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), okm); err != nil {
+			return nil, err
+		}
+		sk := nt.Mod(new(nt.Integer).SetBytes(okm), curve.R)
+		if sk.Sign() != 0 {
+			return &SecretKey{K: sk}, nil
+		}
+	}
+}
+
+// SkToPk computes the public key sk*g
+func (s *Scheme) SkToPk(sk *SecretKey) PublicKey {
+	if s.Variant == MinSig {
+		return EncodeG2(curve.G2.ScalarMul(curve.G2.Generator(), sk.K))
+	}
+	return EncodeG1(curve.G1.ScalarMul(curve.G1.Generator(), sk.K))
+}
+
+// KeyValidate checks that pk encodes a point of the prime order subgroup
+// other than the identity
+func (s *Scheme) KeyValidate(pk PublicKey) bool {
+	if s.Variant == MinSig {
+		p, err := DecodeG2(pk)
+		return err == nil && !p.Inf
+	}
+	p, err := DecodeG1(pk)
+	return err == nil && !p.Inf
+}
+
+// coreSign computes sk*H(msg) with the domain separation tag dst
+func (s *Scheme) coreSign(sk *SecretKey, msg, dst []byte) Signature {
+	if s.Variant == MinSig {
+		h, _ := HashToG1(msg, dst)
+		return EncodeG1(curve.G1.ScalarMul(h, sk.K))
+	}
+	h, _ := HashToG2(msg, dst)
+	return EncodeG2(curve.G2.ScalarMul(h, sk.K))
+}
+
+// coreAggregateVerify checks that prod e(pk_i, H(msg_i)) = e(g, sig) as
+// prod e(pk_i, H(msg_i)) e(-g, sig) = 1 with a single final exponentiation
+func (s *Scheme) coreAggregateVerify(pks []PublicKey, msgs [][]byte, sig Signature, dst []byte) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	var ps []*pairing.G1Point
+	var qs []*pairing.G2Point
+	for i := range pks {
+		if s.Variant == MinSig {
+			pk, err := DecodeG2(pks[i])
+			if err != nil || pk.Inf {
+				return false
+			}
+			h, _ := HashToG1(msgs[i], dst)
+			ps, qs = append(ps, h), append(qs, pk)
+			continue
+		}
+		pk, err := DecodeG1(pks[i])
+		if err != nil || pk.Inf {
+			return false
+		}
+		h, _ := HashToG2(msgs[i], dst)
+		ps, qs = append(ps, pk), append(qs, h)
+	}
+	if s.Variant == MinSig {
+		sigma, err := DecodeG1(sig)
+		if err != nil {
+			return false
+		}
+		ps, qs = append(ps, sigma), append(qs, curve.G2.Neg(curve.G2.Generator()))
+	} else {
+		sigma, err := DecodeG2(sig)
+		if err != nil {
+			return false
+		}
+		ps, qs = append(ps, curve.G1.Neg(curve.G1.Generator())), append(qs, sigma)
+	}
+	ok, err := curve.PairingCheck(ps, qs)
+	return err == nil && ok
+}
+
+// augment prepends pk to msg for the message augmentation mode
+func (s *Scheme) augment(pk PublicKey, msg []byte) []byte {
+	if s.Mode != Aug {
+		return msg
+	}
+	return append(append([]byte{}, pk...), msg...)
+}
+
+// Sign computes the signature of msg under sk
+func (s *Scheme) Sign(sk *SecretKey, msg []byte) Signature {
+	if s.Mode == Aug {
+		msg = s.augment(s.SkToPk(sk), msg)
+	}
+	return s.coreSign(sk, msg, s.dst)
+}
+
+// Verify checks that sig is a signature of msg under pk
+func (s *Scheme) Verify(pk PublicKey, msg []byte, sig Signature) bool {
+	return s.coreAggregateVerify([]PublicKey{pk}, [][]byte{s.augment(pk, msg)}, sig, s.dst)
+}
+
+// aggregate sums the encoded points of G1 or G2
+func aggregate(inG1 bool, encs [][]byte) ([]byte, error) {
+	if len(encs) == 0 {
+		return nil, errAggregate
+	}
+	if inG1 {
+		acc := curve.G1.Infinity()
+		for _, b := range encs {
+			p, err := DecodeG1(b)
+			if err != nil {
+				return nil, err
+			}
+			acc = curve.G1.Add(acc, p)
+		}
+		return EncodeG1(acc), nil
+	}
+	acc := curve.G2.Infinity()
+	for _, b := range encs {
+		p, err := DecodeG2(b)
+		if err != nil {
+			return nil, err
+		}
+		acc = curve.G2.Add(acc, p)
+	}
+	return EncodeG2(acc), nil
+}
+
+// Aggregate combines signatures into a single signature by adding them
+func (s *Scheme) Aggregate(sigs []Signature) (Signature, error) {
+	encs := make([][]byte, len(sigs))
+	for i := range sigs {
+		encs[i] = sigs[i]
+	}
+	return aggregate(s.Variant == MinSig, encs)
+}
+
+// AggregateVerify checks an aggregate of signatures of msgs[i] under pks[i],
+// in Basic mode the messages must be distinct.
+func (s *Scheme) AggregateVerify(pks []PublicKey, msgs [][]byte, sig Signature) bool {
+	if len(pks) != len(msgs) {
+		return false
+	}
+	augmented := make([][]byte, len(msgs))
+	for i := range msgs {
+		if s.Mode == Basic {
+			for j := 0; j < i; j++ {
+				if bytes.Equal(msgs[i], msgs[j]) {
+					return false
+				}
+			}
+		}
+		augmented[i] = s.augment(pks[i], msgs[i])
+	}
+	return s.coreAggregateVerify(pks, augmented, sig, s.dst)
+}
+
+// FastAggregateVerify checks an aggregate of signatures of the same message
+// by verifying it under the sum of the public keys, this is only secure when
+// the public keys come with a proof of possession so it requires Pop mode.
+func (s *Scheme) FastAggregateVerify(pks []PublicKey, msg []byte, sig Signature) bool {
+	if s.Mode != Pop {
+		return false
+	}
+	encs := make([][]byte, len(pks))
+	for i := range pks {
+		encs[i] = pks[i]
+	}
+	pk, err := aggregate(s.Variant == MinPk, encs)
+	if err != nil {
+		return false
+	}
+	return s.Verify(pk, msg, sig)
+}
+
+// PopProve computes a proof of possession of sk which is a signature of the
+// public key with a distinct domain separation tag
+func (s *Scheme) PopProve(sk *SecretKey) Signature {
+	return s.coreSign(sk, s.SkToPk(sk), s.popDST)
+}
+
+// PopVerify checks a proof of possession of the secret key of pk
+func (s *Scheme) PopVerify(pk PublicKey, proof Signature) bool {
+	return s.coreAggregateVerify([]PublicKey{pk}, [][]byte{pk}, proof, s.popDST)
+}
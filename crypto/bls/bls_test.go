@@ -0,0 +1,265 @@
+package bls
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// keys derived from ikm = 00 01 ... 1f, signatures of "abc" as computed by
+// cloudflare/circl for the Basic mode and protolambda/bls12-381-util (the
+// Ethereum consensus scheme) for the Pop mode
+const (
+	vectorSK         = "23360db7e337b0a32b264e06bc11c1b474d16f55665373de1ce93cf15ddb3456"
+	vectorMinPkPK    = "9112a0386a2340714ba0c6d2df235377a8679c3899d03e6ef04dba7a50ef49e5a1dc93105e9374e93ed301b63487e17c"
+	vectorMinPkBasic = "81c205d22fbb8d1c017ebdb997efa7f77c53c7ecd75a15dc128388071e12fa07658d2bc9f95cb78cd3dfd2eddb6c1e21100b30f603611416f7a4760d964167c99577b67c6d053d90a91095feaa810c315c45b7a26b0df37b8d5a3af7d7219d66"
+	vectorMinPkPop   = "8aa7045c01536c9a17aeb42fcebb2e77c64317a930d180ac501c12587c8229fd0ba5cf392328f0fe0fd347e6013da7480457006f3ba2f8988dacad37493cb527658e5d0ca11f4cf5fc610b177df2eafda790aefa8c435726a960a0c7f56cab4b"
+	vectorMinPkProof = "915993b4e43e717ec8079234490be46018bdc7d70e81de1bbec515844a3754cc0a387ddf825a2faa0984fa794a96b5a20da605161aa42c1d4028abeb3c52ffbf35d41bd26398e7110d0b6566e0b74b30b3431c4b821cc85a9d61ad5ffd3f9042"
+	// derived with key info "info"
+	vectorInfoSK      = "4f73ca10a24bd1989a6ca6a99e5f1b795f4118c0f61ebf2a84b10f33c950e602"
+	vectorMinSigPK    = "a0265ca5bd0b6f4dc38ab659108390027ad880b6f1de545087530d892b3866fcbac6f38ee9700c5af5399c05dbbbaec404425a22d1c2df14c4ff70db606597eb6fff176b4bd1a285c0cc32d6295cb019a9f439e7df8bd258b7632450aea5e755"
+	vectorMinSigBasic = "8bff0e5cbb446c3c1c3de8f04bbe213423eb9cfcee50978677e0bd2a10db4564db289a437f1a5f5b1d0b022f8eb11d1b"
+)
+
+func vectorIKM() []byte {
+	ikm := make([]byte, 32)
+	for i := range ikm {
+		ikm[i] = byte(i)
+	}
+	return ikm
+}
+
+// testKeys derives n secret keys from distinct key material
+func testKeys(t *testing.T, n int) []*SecretKey {
+	sks := make([]*SecretKey, n)
+	for i := range sks {
+		ikm := vectorIKM()
+		ikm[0] = byte(0x80 + i)
+		sk, err := KeyGen(ikm, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sks[i] = sk
+	}
+	return sks
+}
+
+func TestBLS(t *testing.T) {
+
+	msg := []byte("abc")
+
+	t.Run("TestKeyGen", func(t *testing.T) {
+		if _, err := KeyGen(make([]byte, 31), nil); err == nil {
+			t.Error("KeyGen should reject short key material")
+		}
+		sk, err := KeyGen(vectorIKM(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sk.K.Bytes()) != vectorSK {
+			t.Error("KeyGen doesn't match the test vector", sk.K)
+		}
+		sk, _ = KeyGen(vectorIKM(), []byte("info"))
+		if hex.EncodeToString(sk.K.Bytes()) != vectorInfoSK {
+			t.Error("KeyGen with key info doesn't match the test vector", sk.K)
+		}
+	})
+
+	t.Run("TestVectors", func(t *testing.T) {
+		sk, _ := KeyGen(vectorIKM(), nil)
+		skInfo, _ := KeyGen(vectorIKM(), []byte("info"))
+		vectors := []struct {
+			s       *Scheme
+			sk      *SecretKey
+			pk, sig string
+		}{
+			{NewScheme(MinPk, Basic), sk, vectorMinPkPK, vectorMinPkBasic},
+			{NewScheme(MinPk, Pop), sk, vectorMinPkPK, vectorMinPkPop},
+			{NewScheme(MinSig, Basic), skInfo, vectorMinSigPK, vectorMinSigBasic},
+		}
+		for _, v := range vectors {
+			pk := v.s.SkToPk(v.sk)
+			if hex.EncodeToString(pk) != v.pk {
+				t.Errorf("SkToPk = %x", pk)
+			}
+			sig := v.s.Sign(v.sk, msg)
+			if hex.EncodeToString(sig) != v.sig {
+				t.Errorf("Sign = %x", sig)
+			}
+			if !v.s.Verify(pk, msg, sig) {
+				t.Error("signature should verify")
+			}
+		}
+		proof := NewScheme(MinPk, Pop).PopProve(sk)
+		if hex.EncodeToString(proof) != vectorMinPkProof {
+			t.Errorf("PopProve = %x", proof)
+		}
+	})
+
+	t.Run("TestEncoding", func(t *testing.T) {
+		p1, _ := HashToG1(msg, nil)
+		q1, err := DecodeG1(EncodeG1(p1))
+		if err != nil || !curve.G1.Equal(p1, q1) {
+			t.Error("G1 encoding doesn't round trip")
+		}
+		n1, _ := DecodeG1(EncodeG1(curve.G1.Neg(p1)))
+		if !curve.G1.Equal(curve.G1.Neg(p1), n1) {
+			t.Error("G1 encoding loses the sign of y")
+		}
+		p2, _ := HashToG2(msg, nil)
+		q2, err := DecodeG2(EncodeG2(p2))
+		if err != nil || !curve.G2.Equal(p2, q2) {
+			t.Error("G2 encoding doesn't round trip")
+		}
+		n2, _ := DecodeG2(EncodeG2(curve.G2.Neg(p2)))
+		if !curve.G2.Equal(curve.G2.Neg(p2), n2) {
+			t.Error("G2 encoding loses the sign of y")
+		}
+		if inf, err := DecodeG2(EncodeG2(curve.G2.Infinity())); err != nil || !inf.Inf {
+			t.Error("point at infinity doesn't round trip")
+		}
+		// uncompressed flag, infinity with a sign and non canonical x
+		bad := [][]byte{
+			make([]byte, G1Size),
+			append([]byte{compressedFlag | infinityFlag | signFlag}, make([]byte, G1Size-1)...),
+			append([]byte{compressedFlag | 0x1f}, bytes.Repeat([]byte{0xff}, G1Size-1)...),
+		}
+		for _, b := range bad {
+			if _, err := DecodeG1(b); err == nil {
+				t.Errorf("%x should be rejected", b)
+			}
+		}
+		// points of E1 outside of G1 are rejected
+		for x := int64(1); ; x++ {
+			p, err := curve.G1.At(nt.FromInt64(x))
+			if err != nil {
+				continue
+			}
+			if curve.G1.InSubgroup(p) {
+				continue
+			}
+			if _, err := DecodeG1(EncodeG1(p)); err != errSubgroup {
+				t.Error("points outside of G1 should be rejected")
+			}
+			break
+		}
+	})
+
+	schemes := []*Scheme{
+		NewScheme(MinPk, Basic), NewScheme(MinPk, Aug), NewScheme(MinPk, Pop),
+		NewScheme(MinSig, Basic), NewScheme(MinSig, Aug), NewScheme(MinSig, Pop),
+	}
+	for _, s := range schemes {
+		s := s
+		name := string(s.dst)
+		t.Run("TestSignVerify"+name, func(t *testing.T) {
+			sks := testKeys(t, 2)
+			pk := s.SkToPk(sks[0])
+			if !s.KeyValidate(pk) {
+				t.Fatal("public key should be valid")
+			}
+			sig := s.Sign(sks[0], msg)
+			if !s.Verify(pk, msg, sig) {
+				t.Error("signature should verify")
+			}
+			if s.Verify(pk, []byte("abd"), sig) {
+				t.Error("signature of another message shouldn't verify")
+			}
+			if s.Verify(s.SkToPk(sks[1]), msg, sig) {
+				t.Error("signature under another key shouldn't verify")
+			}
+			if s.Verify(pk, msg, sig[1:]) {
+				t.Error("truncated signature shouldn't verify")
+			}
+		})
+
+		t.Run("TestAggregateVerify"+name, func(t *testing.T) {
+			sks := testKeys(t, 3)
+			pks := make([]PublicKey, len(sks))
+			sigs := make([]Signature, len(sks))
+			msgs := [][]byte{[]byte("m0"), []byte("m1"), []byte("m2")}
+			for i := range sks {
+				pks[i] = s.SkToPk(sks[i])
+				sigs[i] = s.Sign(sks[i], msgs[i])
+			}
+			agg, err := s.Aggregate(sigs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !s.AggregateVerify(pks, msgs, agg) {
+				t.Error("aggregate signature should verify")
+			}
+			msgs[2] = []byte("m3")
+			if s.AggregateVerify(pks, msgs, agg) {
+				t.Error("aggregate signature of other messages shouldn't verify")
+			}
+			if s.AggregateVerify(pks[:2], msgs[:2], agg) {
+				t.Error("aggregate signature with a missing key shouldn't verify")
+			}
+			if _, err := s.Aggregate(nil); err == nil {
+				t.Error("empty aggregation should fail")
+			}
+			// Basic mode rejects repeated messages
+			msgs[2] = msgs[0]
+			sigs[2] = s.Sign(sks[2], msgs[2])
+			agg, _ = s.Aggregate(sigs)
+			if ok := s.AggregateVerify(pks, msgs, agg); ok == (s.Mode == Basic) {
+				t.Error("repeated messages are accepted only outside of Basic mode")
+			}
+		})
+	}
+
+	t.Run("TestProofOfPossession", func(t *testing.T) {
+		for _, v := range []Variant{MinPk, MinSig} {
+			s := NewScheme(v, Pop)
+			sks := testKeys(t, 3)
+			pks := make([]PublicKey, len(sks))
+			sigs := make([]Signature, len(sks))
+			for i := range sks {
+				pks[i] = s.SkToPk(sks[i])
+				if !s.PopVerify(pks[i], s.PopProve(sks[i])) {
+					t.Error("proof of possession should verify")
+				}
+				sigs[i] = s.Sign(sks[i], msg)
+			}
+			if s.PopVerify(pks[0], s.PopProve(sks[1])) {
+				t.Error("proof of possession of another key shouldn't verify")
+			}
+			// a proof of possession isn't a signature of the public key
+			if s.PopVerify(pks[0], s.Sign(sks[0], pks[0])) {
+				t.Error("signatures and proofs must use distinct tags")
+			}
+			agg, _ := s.Aggregate(sigs)
+			if !s.FastAggregateVerify(pks, msg, agg) {
+				t.Error("fast aggregate verification should succeed")
+			}
+			if s.FastAggregateVerify(pks[1:], msg, agg) {
+				t.Error("fast aggregate verification with a missing key shouldn't verify")
+			}
+			if NewScheme(v, Basic).FastAggregateVerify(pks, msg, agg) {
+				t.Error("fast aggregate verification requires proofs of possession")
+			}
+		}
+	})
+
+	t.Run("TestRogueKey", func(t *testing.T) {
+		// the rogue key pk' = x*g - pk lets the adversary forge x*H(m) as an
+		// aggregate signature of pk and pk' without knowing the secret of pk'
+		s := NewScheme(MinPk, Pop)
+		sks := testKeys(t, 2)
+		honest := curve.G1.ScalarMul(curve.G1.Generator(), sks[0].K)
+		x := sks[1]
+		rogue := curve.G1.Add(curve.G1.ScalarMul(curve.G1.Generator(), x.K), curve.G1.Neg(honest))
+		pks := []PublicKey{EncodeG1(honest), EncodeG1(rogue)}
+		forged := s.Sign(x, msg)
+		if !s.FastAggregateVerify(pks, msg, forged) {
+			t.Fatal("the forgery should pass without proofs of possession")
+		}
+		// but no proof of possession can be produced for pk', x isn't its secret
+		if s.PopVerify(pks[1], s.PopProve(x)) {
+			t.Error("rogue key shouldn't have a valid proof of possession")
+		}
+	})
+}
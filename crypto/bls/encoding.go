@@ -0,0 +1,167 @@
+package bls
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+)
+
+// Points are serialized in the compressed format of the zcash BLS12-381
+// specification, the x coordinate is written big endian and the three most
+// significant bits of the first byte are flags.
+const (
+	// G1Size is the size of a compressed point of G1
+	G1Size = 48
+	// G2Size is the size of a compressed point of G2
+	G2Size = 96
+
+	compressedFlag = 0x80
+	infinityFlag   = 0x40
+	// signFlag is set when y is the lexicographically largest root
+	signFlag = 0x20
+	flagMask = compressedFlag | infinityFlag | signFlag
+)
+
+var (
+	errEncoding = errors.New("invalid point encoding")
+	errSubgroup = errors.New("point isn't in the prime order subgroup")
+)
+
+// largest checks if y > (p - 1)/2
+func largest(y ff.FieldElement) bool {
+	half := nt.Div(nt.Sub(curve.Fp.Modulus(), nt.One), nt.FromInt64(2))
+	return y.Big().Cmp(half) > 0
+}
+
+// largestFp2 compares y1 first and y0 when y1 is zero
+func largestFp2(y pairing.Fp2) bool {
+	if y.C1.IsZero() {
+		return largest(y.C0)
+	}
+	return largest(y.C1)
+}
+
+// readFp reads a big endian integer and checks that it's smaller than p
+func readFp(b []byte) (ff.FieldElement, error) {
+	x := new(nt.Integer).SetBytes(b)
+	if x.Cmp(curve.Fp.Modulus()) >= 0 {
+		return ff.FieldElement{}, errEncoding
+	}
+	return curve.Fp.NewFieldElement(x), nil
+}
+
+// readFlags strips the flags of b and checks their consistency
+func readFlags(b []byte) (flags byte, rest []byte, err error) {
+	flags = b[0] & flagMask
+	if flags&compressedFlag == 0 {
+		return 0, nil, errEncoding
+	}
+	rest = append([]byte{}, b...)
+	rest[0] &^= flagMask
+	if flags&infinityFlag != 0 {
+		if flags&signFlag != 0 {
+			return 0, nil, errEncoding
+		}
+		for _, c := range rest {
+			if c != 0 {
+				return 0, nil, errEncoding
+			}
+		}
+	}
+	return flags, rest, nil
+}
+
+// EncodeG1 returns the compressed encoding of p
+func EncodeG1(p *pairing.G1Point) []byte {
+	b := make([]byte, G1Size)
+	if p.Inf {
+		b[0] = compressedFlag | infinityFlag
+		return b
+	}
+	p.X.Big().FillBytes(b)
+	b[0] |= compressedFlag
+	if largest(p.Y) {
+		b[0] |= signFlag
+	}
+	return b
+}
+
+// DecodeG1 parses a compressed point and checks that it's in G1
+func DecodeG1(b []byte) (*pairing.G1Point, error) {
+	if len(b) != G1Size {
+		return nil, errEncoding
+	}
+	flags, rest, err := readFlags(b)
+	if err != nil {
+		return nil, err
+	}
+	if flags&infinityFlag != 0 {
+		return curve.G1.Infinity(), nil
+	}
+	x, err := readFp(rest)
+	if err != nil {
+		return nil, err
+	}
+	p, err := curve.G1.At(x.Big())
+	if err != nil {
+		return nil, errEncoding
+	}
+	if largest(p.Y) != (flags&signFlag != 0) {
+		p = curve.G1.Neg(p)
+	}
+	if !curve.G1.InSubgroup(p) {
+		return nil, errSubgroup
+	}
+	return p, nil
+}
+
+// EncodeG2 returns the compressed encoding of p, x1 is written before x0
+func EncodeG2(p *pairing.G2Point) []byte {
+	b := make([]byte, G2Size)
+	if p.Inf {
+		b[0] = compressedFlag | infinityFlag
+		return b
+	}
+	p.X.C1.Big().FillBytes(b[:G1Size])
+	p.X.C0.Big().FillBytes(b[G1Size:])
+	b[0] |= compressedFlag
+	if largestFp2(p.Y) {
+		b[0] |= signFlag
+	}
+	return b
+}
+
+// DecodeG2 parses a compressed point and checks that it's in G2
+func DecodeG2(b []byte) (*pairing.G2Point, error) {
+	if len(b) != G2Size {
+		return nil, errEncoding
+	}
+	flags, rest, err := readFlags(b)
+	if err != nil {
+		return nil, err
+	}
+	if flags&infinityFlag != 0 {
+		return curve.G2.Infinity(), nil
+	}
+	x1, err := readFp(rest[:G1Size])
+	if err != nil {
+		return nil, err
+	}
+	x0, err := readFp(rest[G1Size:])
+	if err != nil {
+		return nil, err
+	}
+	p, err := curve.G2.At(pairing.Fp2{C0: x0, C1: x1})
+	if err != nil {
+		return nil, errEncoding
+	}
+	if largestFp2(p.Y) != (flags&signFlag != 0) {
+		p = curve.G2.Neg(p)
+	}
+	if !curve.G2.InSubgroup(p) {
+		return nil, errSubgroup
+	}
+	return p, nil
+}
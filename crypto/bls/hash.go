@@ -0,0 +1,303 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+)
+
+var errExpandLength = errors.New("requested too many bytes from expand_message_xmd")
+
+// curve is BLS12-381 on which every scheme of the package is instantiated
+var curve = pairing.BLS12381()
+
+// hashFieldLength is the number of uniform bytes L = ceil((ceil(log2(p)) + k)/8)
+// hashed to each coordinate for the security level k = 128
+const hashFieldLength = 64
+
+// expandMessageXMD implements expand_message_xmd with SHA-256, it stretches
+// msg into n pseudo random bytes bound to the domain separation tag dst.
+// ref : RFC 9380 Section 5.3.1
+func expandMessageXMD(msg, dst []byte, n int) ([]byte, error) {
+	if len(dst) > 255 {
+		h := sha256.Sum256(append([]byte("H2C-OVERSIZE-DST-"), dst...))
+		dst = h[:]
+	}
+	ell := (n + sha256.Size - 1) / sha256.Size
+	if ell > 255 || n > 65535 {
+		return nil, errExpandLength
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+	h := sha256.New()
+	// b0 = H(Z_pad || msg || I2OSP(n, 2) || I2OSP(0, 1) || DST_prime)
+	h.Write(make([]byte, sha256.BlockSize))
+	h.Write(msg)
+	h.Write([]byte{byte(n >> 8), byte(n), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+	// bi = H(b0 xor b(i-1) || I2OSP(i, 1) || DST_prime) with b(0) xor b0 = b0
+	out := make([]byte, 0, ell*sha256.Size)
+	bi := make([]byte, sha256.Size)
+	for i := 1; i <= ell; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+	return out[:n], nil
+}
+
+// hashToField hashes msg to count*m integers modulo p where m is the degree
+// of the field extension.
+// ref : RFC 9380 Section 5.2
+func hashToField(msg, dst []byte, count, m int) ([]*nt.Integer, error) {
+	b, err := expandMessageXMD(msg, dst, count*m*hashFieldLength)
+	if err != nil {
+		return nil, err
+	}
+	p := curve.Fp.Modulus()
+	e := make([]*nt.Integer, count*m)
+	for i := range e {
+		chunk := b[i*hashFieldLength : (i+1)*hashFieldLength]
+		e[i] = nt.Mod(new(big.Int).SetBytes(chunk), p)
+	}
+	return e, nil
+}
+
+// sgn0 returns the parity of x
+func sgn0(x ff.FieldElement) uint {
+	return x.Big().Bit(0)
+}
+
+// sgn0Fp2 returns the parity of x0 unless it's zero in which case it
+// returns the parity of x1
+func sgn0Fp2(x pairing.Fp2) uint {
+	if x.C0.IsZero() {
+		return sgn0(x.C1)
+	}
+	return sgn0(x.C0)
+}
+
+// isoG1 is the 11-isogeny from E1': y^2 = x^3 + A'x + B' to E1 along with
+// the constants of the simplified SWU map to E1'
+type isoG1 struct {
+	a, b, z                ff.FieldElement
+	xNum, xDen, yNum, yDen []ff.FieldElement
+}
+
+// isoG2 is the 3-isogeny from E2': y^2 = x^3 + A'x + B' to E2 along with
+// the constants of the simplified SWU map to E2'
+type isoG2 struct {
+	a, b, z                pairing.Fp2
+	xNum, xDen, yNum, yDen []pairing.Fp2
+}
+
+var (
+	g1Iso = newIsoG1()
+	g2Iso = newIsoG2()
+)
+
+func newIsoG1() *isoG1 {
+	fp := curve.Fp
+	coeffs := func(s []string) []ff.FieldElement {
+		c := make([]ff.FieldElement, len(s))
+		for i := range s {
+			c[i] = fp.NewFieldElement(fromHex(s[i]))
+		}
+		return c
+	}
+	return &isoG1{
+		a:    fp.NewFieldElement(fromHex(g1IsoA)),
+		b:    fp.NewFieldElement(fromHex(g1IsoB)),
+		z:    fp.NewFieldElementFromInt64(11),
+		xNum: coeffs(g1IsoXNum),
+		xDen: coeffs(g1IsoXDen),
+		yNum: coeffs(g1IsoYNum),
+		yDen: coeffs(g1IsoYDen),
+	}
+}
+
+func newIsoG2() *isoG2 {
+	fp2 := curve.Fp2
+	coeffs := func(s [][2]string) []pairing.Fp2 {
+		c := make([]pairing.Fp2, len(s))
+		for i := range s {
+			c[i] = fp2.New(fromHex(s[i][0]), fromHex(s[i][1]))
+		}
+		return c
+	}
+	return &isoG2{
+		a:    fp2.NewFromInt64(0, 240),
+		b:    fp2.NewFromInt64(1012, 1012),
+		z:    fp2.NewFromInt64(-2, -1),
+		xNum: coeffs(g2IsoXNum),
+		xDen: coeffs(g2IsoXDen),
+		yNum: coeffs(g2IsoYNum),
+		yDen: coeffs(g2IsoYDen),
+	}
+}
+
+// horner evaluates the polynomial of coefficients c at x
+func (iso *isoG1) horner(c []ff.FieldElement, x ff.FieldElement) ff.FieldElement {
+	fp := curve.Fp
+	r := c[len(c)-1]
+	for i := len(c) - 2; i >= 0; i-- {
+		r = fp.Add(fp.Mul(r, x), c[i])
+	}
+	return r
+}
+
+// horner evaluates the polynomial of coefficients c at x
+func (iso *isoG2) horner(c []pairing.Fp2, x pairing.Fp2) pairing.Fp2 {
+	fp2 := curve.Fp2
+	r := c[len(c)-1]
+	for i := len(c) - 2; i >= 0; i-- {
+		r = fp2.Add(fp2.Mul(r, x), c[i])
+	}
+	return r
+}
+
+// mapToCurve computes the simplified SWU map of u to E1' followed by the
+// isogeny to E1, this is the straight line version of the map which isn't
+// constant time.
+// ref : RFC 9380 Section 6.6.2
+func (iso *isoG1) mapToCurve(u ff.FieldElement) *pairing.G1Point {
+	fp := curve.Fp
+	rhs := func(x ff.FieldElement) ff.FieldElement {
+		return fp.Add(fp.Mul(fp.Add(x.Square(), iso.a), x), iso.b)
+	}
+	sqrt := func(x ff.FieldElement) (ff.FieldElement, bool) {
+		y := new(nt.Integer).ModSqrt(x.Big(), fp.Modulus())
+		if y == nil {
+			return ff.FieldElement{}, false
+		}
+		return fp.NewFieldElement(y), true
+	}
+	// x1 = (-B/A)(1 + 1/(Z^2u^4 + Zu^2)) or B/(ZA) when the denominator is zero
+	zu2 := fp.Mul(iso.z, u.Square())
+	tv := fp.Add(zu2.Square(), zu2)
+	var x1 ff.FieldElement
+	if tv.IsZero() {
+		x1 = fp.Div(iso.b, fp.Mul(iso.z, iso.a))
+	} else {
+		x1 = fp.Mul(fp.Div(iso.b.Neg(), iso.a), fp.Add(fp.One(), tv.Inv()))
+	}
+	// either g(x1) or g(Zu^2x1) is a square
+	x := x1
+	y, ok := sqrt(rhs(x))
+	if !ok {
+		x = fp.Mul(zu2, x1)
+		y, _ = sqrt(rhs(x))
+	}
+	if sgn0(u) != sgn0(y) {
+		y = y.Neg()
+	}
+	xDen, yDen := iso.horner(iso.xDen, x), iso.horner(iso.yDen, x)
+	if xDen.IsZero() || yDen.IsZero() {
+		return curve.G1.Infinity()
+	}
+	return &pairing.G1Point{
+		X: fp.Div(iso.horner(iso.xNum, x), xDen),
+		Y: fp.Mul(y, fp.Div(iso.horner(iso.yNum, x), yDen)),
+	}
+}
+
+// mapToCurve computes the simplified SWU map of u to E2' followed by the
+// isogeny to E2.
+// ref : RFC 9380 Section 6.6.2
+func (iso *isoG2) mapToCurve(u pairing.Fp2) *pairing.G2Point {
+	fp2 := curve.Fp2
+	rhs := func(x pairing.Fp2) pairing.Fp2 {
+		return fp2.Add(fp2.Mul(fp2.Add(fp2.Square(x), iso.a), x), iso.b)
+	}
+	div := func(a, b pairing.Fp2) pairing.Fp2 {
+		inv, _ := fp2.Inv(b)
+		return fp2.Mul(a, inv)
+	}
+	zu2 := fp2.Mul(iso.z, fp2.Square(u))
+	tv := fp2.Add(fp2.Square(zu2), zu2)
+	var x1 pairing.Fp2
+	if fp2.IsZero(tv) {
+		x1 = div(iso.b, fp2.Mul(iso.z, iso.a))
+	} else {
+		tvInv, _ := fp2.Inv(tv)
+		x1 = fp2.Mul(div(fp2.Neg(iso.b), iso.a), fp2.Add(fp2.One(), tvInv))
+	}
+	x := x1
+	y, err := fp2.Sqrt(rhs(x))
+	if err != nil {
+		x = fp2.Mul(zu2, x1)
+		y, _ = fp2.Sqrt(rhs(x))
+	}
+	if sgn0Fp2(u) != sgn0Fp2(y) {
+		y = fp2.Neg(y)
+	}
+	xDen, yDen := iso.horner(iso.xDen, x), iso.horner(iso.yDen, x)
+	if fp2.IsZero(xDen) || fp2.IsZero(yDen) {
+		return curve.G2.Infinity()
+	}
+	return &pairing.G2Point{
+		X: div(iso.horner(iso.xNum, x), xDen),
+		Y: fp2.Mul(y, div(iso.horner(iso.yNum, x), yDen)),
+	}
+}
+
+// clearCofactorG1 multiplies p by h_eff = 1 - z which maps E1 to G1
+func clearCofactorG1(p *pairing.G1Point) *pairing.G1Point {
+	return curve.G1.ScalarMul(p, nt.Sub(nt.One, curve.U))
+}
+
+// clearCofactorG2 multiplies p by h_eff using the endomorphism psi as
+// [z^2 - z - 1]P + [z - 1]psi(P) + psi^2(2P).
+// ref : RFC 9380 Appendix G.3 and Efficient hash maps to G2 on BLS curves
+// (Budroni, Pintore)
+func clearCofactorG2(p *pairing.G2Point) *pairing.G2Point {
+	g2 := curve.G2
+	z := curve.U
+	t1 := g2.ScalarMul(p, z)
+	t2 := g2.Frobenius(p)
+	t3 := g2.Frobenius(g2.Frobenius(g2.Double(p)))
+	t3 = g2.Add(t3, g2.Neg(t2))
+	t2 = g2.ScalarMul(g2.Add(t1, t2), z)
+	t3 = g2.Add(t3, t2)
+	t3 = g2.Add(t3, g2.Neg(t1))
+	return g2.Add(t3, g2.Neg(p))
+}
+
+// HashToG1 hashes msg to a point of G1 with the suite
+// BLS12381G1_XMD:SHA-256_SSWU_RO_ and the domain separation tag dst.
+func HashToG1(msg, dst []byte) (*pairing.G1Point, error) {
+	u, err := hashToField(msg, dst, 2, 1)
+	if err != nil {
+		return nil, err
+	}
+	q0 := g1Iso.mapToCurve(curve.Fp.NewFieldElement(u[0]))
+	q1 := g1Iso.mapToCurve(curve.Fp.NewFieldElement(u[1]))
+	return clearCofactorG1(curve.G1.Add(q0, q1)), nil
+}
+
+// HashToG2 hashes msg to a point of G2 with the suite
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ and the domain separation tag dst.
+func HashToG2(msg, dst []byte) (*pairing.G2Point, error) {
+	u, err := hashToField(msg, dst, 2, 2)
+	if err != nil {
+		return nil, err
+	}
+	q0 := g2Iso.mapToCurve(curve.Fp2.New(u[0], u[1]))
+	q1 := g2Iso.mapToCurve(curve.Fp2.New(u[2], u[3]))
+	return clearCofactorG2(curve.G2.Add(q0, q1)), nil
+}
+
+func fromHex(s string) *nt.Integer {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
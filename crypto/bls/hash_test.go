@@ -0,0 +1,118 @@
+package bls
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// test vectors of RFC 9380 Appendix J.9.1, J.10.1 and K.1
+var (
+	expandDST     = []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	expandVectors = []struct {
+		msg string
+		n   int
+		out string
+	}{
+		{"", 0x20, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", 0x20, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{"abc", 0x80, "abba86a6129e366fc877aab32fc4ffc70120d8996c88aee2fe4b32d6c7b6437a647e6c3163d40b76a73cf6a5674ef1d890f95b664ee0afa5359a5c4e07985635bbecbac65d747d3d2da7ec2b8221b17b0ca9dc8a1ac1c07ea6a1e60583e2cb00058e77b7b72a298425cd1b941ad4ec65e8afc50303a22c0f99b0509b4c895f40"},
+	}
+
+	g1HashDST     = []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	g1HashVectors = []struct {
+		msg, x, y string
+	}{
+		{"", "052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1", "08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265"},
+		{"abc", "03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903", "0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d"},
+		{"abcdef0123456789", "11e0b079dea29a68f0383ee94fed1b940995272407e3bb916bbf268c263ddd57a6a27200a784cbc248e84f357ce82d98", "03a87ae2caf14e8ee52e51fa2ed8eefe80f02457004ba4d486d6aa1f517c0889501dc7413753f9599b099ebcbbd2d709"},
+	}
+
+	g2HashDST     = []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	g2HashVectors = []struct {
+		msg, x0, x1, y0, y1 string
+	}{
+		{
+			"",
+			"0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+			"05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+			"0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+			"12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+		},
+		{
+			"abc",
+			"02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6",
+			"139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8",
+			"1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48",
+			"00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16",
+		},
+	}
+)
+
+func TestHashToCurve(t *testing.T) {
+
+	t.Run("TestExpandMessageXMD", func(t *testing.T) {
+		for _, v := range expandVectors {
+			out, err := expandMessageXMD([]byte(v.msg), expandDST, v.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(out) != v.out {
+				t.Errorf("expand_message_xmd(%q, %d) = %x", v.msg, v.n, out)
+			}
+		}
+		if _, err := expandMessageXMD(nil, expandDST, 256*32); err == nil {
+			t.Error("expand_message_xmd can't output more than 255 blocks")
+		}
+	})
+
+	t.Run("TestIsogenies", func(t *testing.T) {
+		// the isogenies map points of E1' and E2' to E1 and E2
+		for i := int64(1); i < 4; i++ {
+			p := g1Iso.mapToCurve(curve.Fp.NewFieldElementFromInt64(i))
+			if !curve.G1.IsOnCurve(p) {
+				t.Error("SWU map to G1 isn't on the curve")
+			}
+			q := g2Iso.mapToCurve(curve.Fp2.NewFromInt64(i, i+1))
+			if !curve.G2.IsOnCurve(q) {
+				t.Error("SWU map to G2 isn't on the twist")
+			}
+		}
+	})
+
+	t.Run("TestHashToG1", func(t *testing.T) {
+		for _, v := range g1HashVectors {
+			p, err := HashToG1([]byte(v.msg), g1HashDST)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.X.Big().Cmp(fromHex(v.x)) != 0 || p.Y.Big().Cmp(fromHex(v.y)) != 0 {
+				t.Errorf("HashToG1(%q) = %v", v.msg, p)
+			}
+			if !curve.G1.InSubgroup(p) {
+				t.Error("HashToG1 isn't in G1")
+			}
+		}
+	})
+
+	t.Run("TestHashToG2", func(t *testing.T) {
+		for _, v := range g2HashVectors {
+			p, err := HashToG2([]byte(v.msg), g2HashDST)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*nt.Integer{fromHex(v.x0), fromHex(v.x1), fromHex(v.y0), fromHex(v.y1)}
+			got := []*nt.Integer{p.X.C0.Big(), p.X.C1.Big(), p.Y.C0.Big(), p.Y.C1.Big()}
+			for i := range want {
+				if want[i].Cmp(got[i]) != 0 {
+					t.Errorf("HashToG2(%q) = %v", v.msg, p)
+					break
+				}
+			}
+			if !curve.G2.InSubgroup(p) {
+				t.Error("HashToG2 isn't in G2")
+			}
+		}
+	})
+}
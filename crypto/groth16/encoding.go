@@ -0,0 +1,147 @@
+package groth16
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/actuallyachraf/algebra/pairing"
+)
+
+var errEncoding = errors.New("malformed encoding")
+
+// Points are encoded uncompressed with big endian affine coordinates on the
+// byte size of p, x || y for G1 and x1 || x0 || y1 || y0 for G2 which is the
+// layout of the Ethereum precompiles. The point at infinity is all zeros.
+
+func isZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func appendG1(b []byte, c *pairing.Curve, p *pairing.G1Point) []byte {
+	if p.Inf {
+		return append(b, make([]byte, 2*c.FpSize())...)
+	}
+	return c.AppendFp(b, p.X.Big(), p.Y.Big())
+}
+
+func appendG2(b []byte, c *pairing.Curve, p *pairing.G2Point) []byte {
+	if p.Inf {
+		return append(b, make([]byte, 4*c.FpSize())...)
+	}
+	return c.AppendFp(b, p.X.C1.Big(), p.X.C0.Big(), p.Y.C1.Big(), p.Y.C0.Big())
+}
+
+// readG1 decodes a G1 point and checks it belongs to the subgroup of order r
+func readG1(c *pairing.Curve, b []byte) (*pairing.G1Point, error) {
+	if isZero(b) {
+		return c.G1.Infinity(), nil
+	}
+	xy := c.ReadFp(b, 2)
+	p, err := c.G1.NewPoint(xy[0], xy[1])
+	if err != nil {
+		return nil, err
+	}
+	if !c.G1.InSubgroup(p) {
+		return nil, errEncoding
+	}
+	return p, nil
+}
+
+// readG2 decodes a G2 point and checks it belongs to the subgroup of order r
+func readG2(c *pairing.Curve, b []byte) (*pairing.G2Point, error) {
+	if isZero(b) {
+		return c.G2.Infinity(), nil
+	}
+	xy := c.ReadFp(b, 4)
+	p, err := c.G2.NewPoint(c.Fp2.New(xy[1], xy[0]), c.Fp2.New(xy[3], xy[2]))
+	if err != nil {
+		return nil, err
+	}
+	if !c.G2.InSubgroup(p) {
+		return nil, errEncoding
+	}
+	return p, nil
+}
+
+// Marshal encodes the proof as A || B || C
+func (p *Proof) Marshal(c *pairing.Curve) []byte {
+	b := appendG1(nil, c, p.A)
+	b = appendG2(b, c, p.B)
+	return appendG1(b, c, p.C)
+}
+
+// UnmarshalProof decodes a proof encoded with Marshal
+func UnmarshalProof(c *pairing.Curve, b []byte) (*Proof, error) {
+	size := c.FpSize()
+	if len(b) != 8*size {
+		return nil, errEncoding
+	}
+	a, err := readG1(c, b[:2*size])
+	if err != nil {
+		return nil, err
+	}
+	bb, err := readG2(c, b[2*size:6*size])
+	if err != nil {
+		return nil, err
+	}
+	cc, err := readG1(c, b[6*size:])
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{A: a, B: bb, C: cc}, nil
+}
+
+// Marshal encodes the verifying key as
+// alpha1 || beta2 || gamma2 || delta2 || len(IC) || IC
+// where the number of IC points is a 4 byte big endian integer.
+func (vk *VerifyingKey) Marshal(c *pairing.Curve) []byte {
+	b := appendG1(nil, c, vk.Alpha1)
+	b = appendG2(b, c, vk.Beta2)
+	b = appendG2(b, c, vk.Gamma2)
+	b = appendG2(b, c, vk.Delta2)
+	var n [4]byte
+	binary.BigEndian.PutUint32(n[:], uint32(len(vk.IC)))
+	b = append(b, n[:]...)
+	for _, p := range vk.IC {
+		b = appendG1(b, c, p)
+	}
+	return b
+}
+
+// UnmarshalVerifyingKey decodes a verifying key encoded with Marshal
+func UnmarshalVerifyingKey(c *pairing.Curve, b []byte) (*VerifyingKey, error) {
+	size := c.FpSize()
+	head := 14*size + 4
+	if len(b) < head {
+		return nil, errEncoding
+	}
+	n := binary.BigEndian.Uint32(b[head-4 : head])
+	if n == 0 || uint64(len(b)-head) != uint64(n)*uint64(2*size) {
+		return nil, errEncoding
+	}
+	vk := &VerifyingKey{}
+	var err error
+	if vk.Alpha1, err = readG1(c, b[:2*size]); err != nil {
+		return nil, err
+	}
+	g2 := []**pairing.G2Point{&vk.Beta2, &vk.Gamma2, &vk.Delta2}
+	for i, p := range g2 {
+		off := 2*size + 4*size*i
+		if *p, err = readG2(c, b[off:off+4*size]); err != nil {
+			return nil, err
+		}
+	}
+	vk.IC = make([]*pairing.G1Point, n)
+	for i := range vk.IC {
+		off := head + 2*size*i
+		if vk.IC[i], err = readG1(c, b[off:off+2*size]); err != nil {
+			return nil, err
+		}
+	}
+	return vk, nil
+}
@@ -0,0 +1,192 @@
+// Package groth16 implements the Groth16 zk-SNARK for rank one constraint
+// systems, proofs are three group elements and verification is a single
+// pairing product equation regardless of the size of the circuit.
+// ref : On the Size of Pairing-based Non-interactive Arguments (Groth)
+package groth16
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+)
+
+var (
+	errPublicInputs = errors.New("number of public inputs doesn't match the verifying key")
+	errToxicWaste   = errors.New("toxic waste must be non zero and tau outside of the QAP domain")
+)
+
+// ToxicWaste holds the secrets of the setup, anyone who knows them can forge
+// proofs so they must be erased once the keys are generated.
+type ToxicWaste struct {
+	Alpha, Beta, Gamma, Delta, Tau *nt.Integer
+}
+
+// NewToxicWaste samples the setup secrets uniformly in Fr*
+func NewToxicWaste(r *nt.Integer) (*ToxicWaste, error) {
+	var s [5]*nt.Integer
+	for i := range s {
+		for s[i] == nil || s[i].Sign() == 0 {
+			x, err := rand.Int(rand.Reader, r)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = x
+		}
+	}
+	return &ToxicWaste{Alpha: s[0], Beta: s[1], Gamma: s[2], Delta: s[3], Tau: s[4]}, nil
+}
+
+// ProvingKey holds the evaluations at tau of the QAP polynomials in the
+// exponent.
+type ProvingKey struct {
+	Alpha1, Beta1, Delta1 *pairing.G1Point
+	Beta2, Delta2         *pairing.G2Point
+	// A1[i] = [U_i(tau)]1, B1[i] = [V_i(tau)]1 and B2[i] = [V_i(tau)]2
+	A1, B1 []*pairing.G1Point
+	B2     []*pairing.G2Point
+	// K1[i] = [(beta U_i(tau) + alpha V_i(tau) + W_i(tau))/delta]1 for the
+	// private variables
+	K1 []*pairing.G1Point
+	// H1[k] = [tau^k T(tau)/delta]1 for 0 <= k <= m - 2
+	H1 []*pairing.G1Point
+	// QAP is the reduction of the constraint system, it's computed once by
+	// Setup since the interpolations cost O(m^3) and the prover needs it to
+	// find H.
+	QAP *QAP
+}
+
+// VerifyingKey holds the elements needed to check a proof
+type VerifyingKey struct {
+	Alpha1                *pairing.G1Point
+	Beta2, Gamma2, Delta2 *pairing.G2Point
+	// IC[i] = [(beta U_i(tau) + alpha V_i(tau) + W_i(tau))/gamma]1 for the
+	// constant and the public variables
+	IC []*pairing.G1Point
+}
+
+// Proof is the Groth16 proof (A, B, C)
+type Proof struct {
+	A *pairing.G1Point
+	B *pairing.G2Point
+	C *pairing.G1Point
+}
+
+// Setup computes the proving and verifying keys of the constraint system
+// cs with the secrets tw
+func Setup(c *pairing.Curve, cs *R1CS, tw *ToxicWaste) (*ProvingKey, *VerifyingKey, error) {
+	r := c.R
+	q := cs.ToQAP()
+	tT := q.T.Eval(tw.Tau, r)
+	for _, x := range []*nt.Integer{tw.Alpha, tw.Beta, tw.Gamma, tw.Delta, tT} {
+		if nt.Mod(x, r).Sign() == 0 {
+			return nil, nil, errToxicWaste
+		}
+	}
+	g1, g2 := c.G1, c.G2
+	mul1 := func(k *nt.Integer) *pairing.G1Point {
+		return g1.ScalarMul(g1.Generator(), nt.Mod(k, r))
+	}
+	mul2 := func(k *nt.Integer) *pairing.G2Point {
+		return g2.ScalarMul(g2.Generator(), nt.Mod(k, r))
+	}
+	gammaInv := nt.ModInv(tw.Gamma, r)
+	deltaInv := nt.ModInv(tw.Delta, r)
+	pk := &ProvingKey{
+		Alpha1: mul1(tw.Alpha),
+		Beta1:  mul1(tw.Beta),
+		Delta1: mul1(tw.Delta),
+		Beta2:  mul2(tw.Beta),
+		Delta2: mul2(tw.Delta),
+		QAP:    q,
+	}
+	vk := &VerifyingKey{
+		Alpha1: pk.Alpha1,
+		Beta2:  pk.Beta2,
+		Gamma2: mul2(tw.Gamma),
+		Delta2: pk.Delta2,
+	}
+	for i := 0; i < cs.NumVars(); i++ {
+		u, v, w := q.U[i].Eval(tw.Tau, r), q.V[i].Eval(tw.Tau, r), q.W[i].Eval(tw.Tau, r)
+		pk.A1 = append(pk.A1, mul1(u))
+		pk.B1 = append(pk.B1, mul1(v))
+		pk.B2 = append(pk.B2, mul2(v))
+		// beta U_i + alpha V_i + W_i
+		k := nt.Add(nt.Add(nt.Mul(tw.Beta, u), nt.Mul(tw.Alpha, v)), w)
+		if i <= cs.NumPublic {
+			vk.IC = append(vk.IC, mul1(nt.Mul(k, gammaInv)))
+		} else {
+			pk.K1 = append(pk.K1, mul1(nt.Mul(k, deltaInv)))
+		}
+	}
+	// deg H <= 2(m - 1) - m = m - 2
+	t := nt.ModMul(tT, deltaInv, r)
+	for k := 0; k+1 < len(cs.Constraints); k++ {
+		pk.H1 = append(pk.H1, mul1(t))
+		t = nt.ModMul(t, tw.Tau, r)
+	}
+	return pk, vk, nil
+}
+
+// msm computes sum k_i P_i over the shortest of ps and ks
+func msm(c *pairing.Curve, ps []*pairing.G1Point, ks []*nt.Integer) *pairing.G1Point {
+	acc := c.G1.Infinity()
+	for i := 0; i < len(ps) && i < len(ks); i++ {
+		acc = c.G1.Add(acc, c.G1.ScalarMul(ps[i], nt.Mod(ks[i], c.R)))
+	}
+	return acc
+}
+
+// Prove computes a proof that w satisfies cs, the proof is randomized by
+// r and s which makes it zero knowledge:
+// A = [alpha + sum w_i U_i(tau) + r delta]1
+// B = [beta + sum w_i V_i(tau) + s delta]2
+// C = [(sum_priv w_i K_i + H(tau)T(tau))/delta + sA + rB - rs delta]1
+func Prove(c *pairing.Curve, cs *R1CS, pk *ProvingKey, w []*nt.Integer) (*Proof, error) {
+	if !cs.IsSatisfied(w) {
+		return nil, errWitness
+	}
+	h, err := pk.QAP.H(w)
+	if err != nil {
+		return nil, err
+	}
+	r, err := rand.Int(rand.Reader, c.R)
+	if err != nil {
+		return nil, err
+	}
+	s, err := rand.Int(rand.Reader, c.R)
+	if err != nil {
+		return nil, err
+	}
+	g1, g2 := c.G1, c.G2
+	a := g1.Add(pk.Alpha1, msm(c, pk.A1, w))
+	a = g1.Add(a, g1.ScalarMul(pk.Delta1, r))
+	b1 := g1.Add(pk.Beta1, msm(c, pk.B1, w))
+	b1 = g1.Add(b1, g1.ScalarMul(pk.Delta1, s))
+	b := pk.Beta2
+	for i := range pk.B2 {
+		b = g2.Add(b, g2.ScalarMul(pk.B2[i], nt.Mod(w[i], c.R)))
+	}
+	b = g2.Add(b, g2.ScalarMul(pk.Delta2, s))
+	cp := msm(c, pk.K1, w[cs.NumPublic+1:])
+	cp = g1.Add(cp, msm(c, pk.H1, h))
+	cp = g1.Add(cp, g1.ScalarMul(a, s))
+	cp = g1.Add(cp, g1.ScalarMul(b1, r))
+	cp = g1.Add(cp, g1.Neg(g1.ScalarMul(pk.Delta1, nt.ModMul(r, s, c.R))))
+	return &Proof{A: a, B: b, C: cp}, nil
+}
+
+// Verify checks a proof against the public inputs x with
+// e(A, B) = e(alpha, beta) e(sum x_i IC_i, gamma) e(C, delta)
+func Verify(c *pairing.Curve, vk *VerifyingKey, x []*nt.Integer, proof *Proof) (bool, error) {
+	if len(x)+1 != len(vk.IC) {
+		return false, errPublicInputs
+	}
+	g1 := c.G1
+	ic := g1.Add(vk.IC[0], msm(c, vk.IC[1:], x))
+	return c.PairingCheck(
+		[]*pairing.G1Point{proof.A, g1.Neg(vk.Alpha1), g1.Neg(ic), g1.Neg(proof.C)},
+		[]*pairing.G2Point{proof.B, vk.Beta2, vk.Gamma2, vk.Delta2},
+	)
+}
@@ -0,0 +1,190 @@
+package groth16
+
+import (
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+)
+
+func ints(xs ...int64) []*nt.Integer {
+	out := make([]*nt.Integer, len(xs))
+	for i := range xs {
+		out[i] = nt.FromInt64(xs[i])
+	}
+	return out
+}
+
+func lc(terms ...int64) LinearCombination {
+	var l LinearCombination
+	for i := 0; i+1 < len(terms); i += 2 {
+		l = append(l, Term{Var: int(terms[i]), Coeff: nt.FromInt64(terms[i+1])})
+	}
+	return l
+}
+
+// cubic returns the circuit x^3 + x + 5 = out with the variables
+// (one, out, x, x^2, x^3, x^3 + x)
+func cubic(r *nt.Integer) *R1CS {
+	cs := NewR1CS(r, 1, 4)
+	cs.AddConstraint(lc(2, 1), lc(2, 1), lc(3, 1))
+	cs.AddConstraint(lc(3, 1), lc(2, 1), lc(4, 1))
+	cs.AddConstraint(lc(4, 1, 2, 1), lc(0, 1), lc(5, 1))
+	cs.AddConstraint(lc(5, 1, 0, 5), lc(0, 1), lc(1, 1))
+	return cs
+}
+
+func TestGroth16(t *testing.T) {
+
+	c := pairing.BN254()
+	cs := cubic(c.R)
+	w := ints(1, 35, 3, 9, 27, 30)
+	bad := ints(1, 35, 3, 9, 27, 31)
+
+	t.Run("TestR1CS", func(t *testing.T) {
+		if !cs.IsSatisfied(w) {
+			t.Error("witness should satisfy the circuit")
+		}
+		if cs.IsSatisfied(bad) {
+			t.Error("wrong witness shouldn't satisfy the circuit")
+		}
+		if cs.IsSatisfied(w[1:]) {
+			t.Error("short witness shouldn't satisfy the circuit")
+		}
+		if err := cs.AddConstraint(lc(6, 1), lc(0, 1), lc(0, 1)); err == nil {
+			t.Error("unknown variables should be rejected")
+		}
+	})
+
+	t.Run("TestQAP", func(t *testing.T) {
+		q := cs.ToQAP()
+		if q.T.Degree() != len(cs.Constraints) {
+			t.Error("target polynomial should have a root per constraint")
+		}
+		// U_i evaluated at the j-th point is the coefficient of i in A_j
+		for j, con := range cs.Constraints {
+			x := nt.FromInt64(int64(j + 1))
+			for _, term := range con.A {
+				if q.U[term.Var].Eval(x, c.R).Cmp(nt.Mod(term.Coeff, c.R)) != 0 {
+					t.Error("U doesn't interpolate the A coefficients")
+				}
+			}
+		}
+		h, err := q.H(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Degree() > len(cs.Constraints)-2 {
+			t.Error("H has too large a degree")
+		}
+		if _, err := q.H(bad); err == nil {
+			t.Error("T shouldn't divide the QAP of a wrong witness")
+		}
+	})
+
+	tw := &ToxicWaste{
+		Alpha: nt.FromInt64(0xa1),
+		Beta:  nt.FromInt64(0xbe),
+		Gamma: nt.FromInt64(0x9a),
+		Delta: nt.FromInt64(0xde),
+		Tau:   nt.FromInt64(0xc0ffee),
+	}
+	pk, vk, err := Setup(c, cs, tw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("TestProve", func(t *testing.T) {
+		// the prover reuses the QAP computed by Setup
+		if pk.QAP == nil || pk.QAP.T.Compare(&cs.ToQAP().T) != 0 {
+			t.Fatal("proving key should hold the QAP of the constraint system")
+		}
+		proof, err := Prove(c, cs, pk, w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := Verify(c, vk, ints(35), proof)
+		if err != nil || !ok {
+			t.Error("proof should verify")
+		}
+		ok, _ = Verify(c, vk, ints(36), proof)
+		if ok {
+			t.Error("proof shouldn't verify for another output")
+		}
+		if _, err := Verify(c, vk, ints(35, 1), proof); err == nil {
+			t.Error("extra public inputs should be rejected")
+		}
+		if _, err := Prove(c, cs, pk, bad); err == nil {
+			t.Error("proving a wrong witness should fail")
+		}
+		// proofs are randomized
+		other, _ := Prove(c, cs, pk, w)
+		if c.G1.Equal(proof.A, other.A) {
+			t.Error("proofs should be rerandomized")
+		}
+		forged := &Proof{A: proof.A, B: proof.B, C: other.C}
+		if ok, _ := Verify(c, vk, ints(35), forged); ok {
+			t.Error("mixed proofs shouldn't verify")
+		}
+	})
+
+	t.Run("TestSetup", func(t *testing.T) {
+		// tau on the QAP domain makes T(tau) = 0
+		if _, _, err := Setup(c, cs, &ToxicWaste{tw.Alpha, tw.Beta, tw.Gamma, tw.Delta, nt.FromInt64(2)}); err == nil {
+			t.Error("tau in the QAP domain should be rejected")
+		}
+		if _, _, err := Setup(c, cs, &ToxicWaste{tw.Alpha, tw.Beta, nt.FromInt64(0), tw.Delta, tw.Tau}); err == nil {
+			t.Error("zero gamma should be rejected")
+		}
+		rtw, err := NewToxicWaste(c.R)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, vk, err := Setup(c, cs, rtw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, _ := Prove(c, cs, pk, w)
+		if ok, _ := Verify(c, vk, ints(35), proof); !ok {
+			t.Error("proof should verify with random toxic waste")
+		}
+	})
+
+	t.Run("TestEncoding", func(t *testing.T) {
+		proof, _ := Prove(c, cs, pk, w)
+		b := proof.Marshal(c)
+		if len(b) != 256 {
+			t.Errorf("proof should encode on 256 bytes got %d", len(b))
+		}
+		dec, err := UnmarshalProof(c, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !c.G1.Equal(dec.A, proof.A) || !c.G2.Equal(dec.B, proof.B) || !c.G1.Equal(dec.C, proof.C) {
+			t.Error("proof doesn't round trip")
+		}
+		vb := vk.Marshal(c)
+		dvk, err := UnmarshalVerifyingKey(c, vb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, _ := Verify(c, dvk, ints(35), dec); !ok {
+			t.Error("decoded proof should verify with the decoded key")
+		}
+		if _, err := UnmarshalProof(c, b[1:]); err == nil {
+			t.Error("truncated proof should be rejected")
+		}
+		b[10] ^= 1
+		if _, err := UnmarshalProof(c, b); err == nil {
+			t.Error("point off the curve should be rejected")
+		}
+		if _, err := UnmarshalVerifyingKey(c, vb[:len(vb)-1]); err == nil {
+			t.Error("truncated verifying key should be rejected")
+		}
+		inf := (&Proof{A: c.G1.Infinity(), B: c.G2.Infinity(), C: c.G1.Infinity()}).Marshal(c)
+		dec, err = UnmarshalProof(c, inf)
+		if err != nil || !dec.A.Inf || !dec.B.Inf {
+			t.Error("points at infinity should round trip")
+		}
+	})
+}
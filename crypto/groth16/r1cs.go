@@ -0,0 +1,174 @@
+package groth16
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var (
+	errWitness    = errors.New("witness doesn't satisfy the constraint system")
+	errWitnessLen = errors.New("witness length doesn't match the number of variables")
+	errVariable   = errors.New("linear combination refers to an unknown variable")
+)
+
+// Term is the product of a coefficient and the variable of index Var
+type Term struct {
+	Var   int
+	Coeff *nt.Integer
+}
+
+// LinearCombination is a sum of terms
+type LinearCombination []Term
+
+// Constraint is the rank one constraint <A, w> * <B, w> = <C, w>
+type Constraint struct {
+	A, B, C LinearCombination
+}
+
+// R1CS is a rank one constraint system over Fr, a witness w assigns every
+// variable and is laid out as w = (1, public inputs, private inputs) so the
+// variable 0 is the constant one.
+type R1CS struct {
+	R           *nt.Integer
+	NumPublic   int
+	NumPrivate  int
+	Constraints []Constraint
+}
+
+// NewR1CS returns an empty constraint system over Fr with variables 1 to
+// numPublic public and the next numPrivate private.
+func NewR1CS(r *nt.Integer, numPublic, numPrivate int) *R1CS {
+	return &R1CS{R: r, NumPublic: numPublic, NumPrivate: numPrivate}
+}
+
+// NumVars returns the number of variables including the constant one
+func (cs *R1CS) NumVars() int {
+	return 1 + cs.NumPublic + cs.NumPrivate
+}
+
+// AddConstraint appends the constraint a * b = c
+func (cs *R1CS) AddConstraint(a, b, c LinearCombination) error {
+	for _, lc := range []LinearCombination{a, b, c} {
+		for _, t := range lc {
+			if t.Var < 0 || t.Var >= cs.NumVars() {
+				return errVariable
+			}
+		}
+	}
+	cs.Constraints = append(cs.Constraints, Constraint{a, b, c})
+	return nil
+}
+
+// eval computes <lc, w> mod r
+func (cs *R1CS) eval(lc LinearCombination, w []*nt.Integer) *nt.Integer {
+	s := nt.FromInt64(0)
+	for _, t := range lc {
+		s = nt.Add(s, nt.Mul(t.Coeff, w[t.Var]))
+	}
+	return nt.Mod(s, cs.R)
+}
+
+// IsSatisfied checks every constraint on the witness w
+func (cs *R1CS) IsSatisfied(w []*nt.Integer) bool {
+	if len(w) != cs.NumVars() || nt.Mod(w[0], cs.R).Cmp(nt.One) != 0 {
+		return false
+	}
+	for _, c := range cs.Constraints {
+		ab := nt.ModMul(cs.eval(c.A, w), cs.eval(c.B, w), cs.R)
+		if ab.Cmp(cs.eval(c.C, w)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// QAP is the quadratic arithmetic program of an R1CS, the constraints are
+// attached to the points 1, ..., m and the polynomials U_i, V_i and W_i
+// interpolate the coefficients of variable i in A, B and C. A witness
+// satisfies the constraints iff T divides
+// (sum w_i U_i)(sum w_i V_i) - sum w_i W_i where T = (X - 1)...(X - m).
+// ref : Quadratic Span Programs and Succinct NIZKs without PCPs
+// (Gennaro, Gentry, Parno, Raykova)
+type QAP struct {
+	R       *nt.Integer
+	U, V, W []poly.Polynomial
+	T       poly.Polynomial
+}
+
+// lagrangeBasis returns the polynomials L_j of degree < m with
+// L_j(k) = 1 if j = k - 1 and 0 otherwise for k in 1, ..., m
+func lagrangeBasis(m int, r *nt.Integer) []poly.Polynomial {
+	basis := make([]poly.Polynomial, m)
+	for j := range basis {
+		points := make([]poly.Point, m)
+		for k := range points {
+			y := nt.FromInt64(0)
+			if k == j {
+				y = nt.FromInt64(1)
+			}
+			points[k] = poly.NewPoint(nt.FromInt64(int64(k+1)), y)
+		}
+		basis[j] = poly.Lagrange(points, r)
+	}
+	return basis
+}
+
+// ToQAP reduces the constraint system to a QAP by Lagrange interpolation
+func (cs *R1CS) ToQAP() *QAP {
+	r := cs.R
+	m, n := len(cs.Constraints), cs.NumVars()
+	basis := lagrangeBasis(m, r)
+	q := &QAP{
+		R: r,
+		U: make([]poly.Polynomial, n),
+		V: make([]poly.Polynomial, n),
+		W: make([]poly.Polynomial, n),
+		T: poly.NewPolynomialInts(1),
+	}
+	for i := 0; i < n; i++ {
+		q.U[i], q.V[i], q.W[i] = poly.NewPolynomialInts(0), poly.NewPolynomialInts(0), poly.NewPolynomialInts(0)
+	}
+	scaled := func(j int, c *nt.Integer) poly.Polynomial {
+		return basis[j].Mul(poly.NewPolynomialBigInt(nt.Mod(c, r)), r)
+	}
+	for j, c := range cs.Constraints {
+		for _, t := range c.A {
+			q.U[t.Var] = q.U[t.Var].Add(scaled(j, t.Coeff), r)
+		}
+		for _, t := range c.B {
+			q.V[t.Var] = q.V[t.Var].Add(scaled(j, t.Coeff), r)
+		}
+		for _, t := range c.C {
+			q.W[t.Var] = q.W[t.Var].Add(scaled(j, t.Coeff), r)
+		}
+		q.T = q.T.Mul(poly.NewPolynomialBigInt(nt.Mod(nt.FromInt64(int64(-j-1)), r), nt.FromInt64(1)), r)
+	}
+	return q
+}
+
+// combine computes sum w_i P_i, the polynomials are cloned since Mul reduces
+// its operands in place and a QAP is shared by every proof.
+func (q *QAP) combine(ps []poly.Polynomial, w []*nt.Integer) poly.Polynomial {
+	s := poly.NewPolynomialInts(0)
+	for i := range ps {
+		s = s.Add(ps[i].Clone(0).Mul(poly.NewPolynomialBigInt(nt.Mod(w[i], q.R)), q.R), q.R)
+	}
+	return s
+}
+
+// H returns the quotient ((sum w_i U_i)(sum w_i V_i) - sum w_i W_i)/T which
+// exists only for satisfying witnesses
+func (q *QAP) H(w []*nt.Integer) (poly.Polynomial, error) {
+	if len(w) != len(q.U) {
+		return nil, errWitnessLen
+	}
+	r := q.R
+	p := q.combine(q.U, w).Mul(q.combine(q.V, w), r).Sub(q.combine(q.W, w), r)
+	h, rem := p.Div(q.T.Clone(0), r)
+	if rem.Degree() > 0 || rem[0].Sign() != 0 {
+		return nil, errWitness
+	}
+	return h, nil
+}
@@ -0,0 +1,218 @@
+// Package kzg implements the KZG polynomial commitment scheme, a polynomial
+// p of degree at most d is committed to as the single point [p(tau)]G1 using
+// the powers [tau^i]G1 of a secret tau. An opening at z is the commitment
+// to the quotient q(X) = (p(X) - p(z))/(X - z) and it's checked with the
+// pairing equation e(C - [y]G1, G2) = e(W, [tau - z]G2).
+// ref : Constant-Size Commitments to Polynomials and Their Applications
+// (Kate, Zaverucha, Goldberg)
+package kzg
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var (
+	errDegree = errors.New("polynomial degree exceeds the size of the SRS")
+	errPoints = errors.New("opening points must be distinct and as many as the evaluations")
+	errSetup  = errors.New("SRS requires at least one power in G1 and two in G2")
+)
+
+// SRS is the structured reference string ([tau^i]G1) for 0 <= i <= d and
+// ([tau^i]G2) for 0 <= i < k, k bounds the number of points of a batch
+// opening.
+type SRS struct {
+	G1 []*pairing.G1Point
+	G2 []*pairing.G2Point
+}
+
+// Degree returns the maximal degree of committed polynomials
+func (s *SRS) Degree() int {
+	return len(s.G1) - 1
+}
+
+// newSRS computes the powers of tau in G1 and G2
+func newSRS(c *pairing.Curve, degree, g2Powers int, tau *nt.Integer) *SRS {
+	s := &SRS{
+		G1: make([]*pairing.G1Point, degree+1),
+		G2: make([]*pairing.G2Point, g2Powers),
+	}
+	t := nt.FromInt64(1)
+	for i := range s.G1 {
+		s.G1[i] = c.G1.ScalarMul(c.G1.Generator(), t)
+		if i < g2Powers {
+			s.G2[i] = c.G2.ScalarMul(c.G2.Generator(), t)
+		}
+		t = nt.ModMul(t, tau, c.R)
+	}
+	for i := len(s.G1); i < g2Powers; i++ {
+		s.G2[i] = c.G2.ScalarMul(c.G2.Generator(), t)
+		t = nt.ModMul(t, tau, c.R)
+	}
+	return s
+}
+
+// Setup runs a trusted setup for polynomials of degree at most degree and
+// batch openings of up to g2Powers - 1 points. tau is sampled and forgotten
+// by this process which makes it only suitable for tests, real deployments
+// convert the output of a powers of tau ceremony and load it with LoadSRS.
+func Setup(c *pairing.Curve, degree, g2Powers int) (*SRS, error) {
+	if degree < 0 || g2Powers < 2 {
+		return nil, errSetup
+	}
+	tau, err := rand.Int(rand.Reader, c.R)
+	if err != nil {
+		return nil, err
+	}
+	return newSRS(c, degree, g2Powers, tau), nil
+}
+
+// KZG commits to polynomials over Fr where r is the order of the pairing
+// groups of Curve.
+type KZG struct {
+	Curve *pairing.Curve
+	SRS   *SRS
+}
+
+// New returns the commitment scheme on c with the reference string srs
+func New(c *pairing.Curve, srs *SRS) *KZG {
+	return &KZG{Curve: c, SRS: srs}
+}
+
+// commitG1 computes sum p_i [tau^i]G1 = [p(tau)]G1
+func (k *KZG) commitG1(p poly.Polynomial) (*pairing.G1Point, error) {
+	if p.Degree() > k.SRS.Degree() {
+		return nil, errDegree
+	}
+	g1 := k.Curve.G1
+	c := g1.Infinity()
+	for i := range p {
+		c = g1.Add(c, g1.ScalarMul(k.SRS.G1[i], nt.Mod(p[i], k.Curve.R)))
+	}
+	return c, nil
+}
+
+// commitG2 computes sum p_i [tau^i]G2 = [p(tau)]G2
+func (k *KZG) commitG2(p poly.Polynomial) (*pairing.G2Point, error) {
+	if p.Degree() >= len(k.SRS.G2) {
+		return nil, errDegree
+	}
+	g2 := k.Curve.G2
+	c := g2.Infinity()
+	for i := range p {
+		c = g2.Add(c, g2.ScalarMul(k.SRS.G2[i], nt.Mod(p[i], k.Curve.R)))
+	}
+	return c, nil
+}
+
+// Commit returns the commitment [p(tau)]G1
+func (k *KZG) Commit(p poly.Polynomial) (*pairing.G1Point, error) {
+	return k.commitG1(p)
+}
+
+// Open evaluates p at z and returns y = p(z) with the witness [q(tau)]G1
+// where q(X) = (p(X) - y)/(X - z)
+func (k *KZG) Open(p poly.Polynomial, z *nt.Integer) (*nt.Integer, *pairing.G1Point, error) {
+	r := k.Curve.R
+	y := p.Eval(z, r)
+	num := p.Sub(poly.NewPolynomialBigInt(y), r)
+	q, _ := num.Div(poly.NewPolynomialBigInt(nt.Mod(new(nt.Integer).Neg(z), r), nt.FromInt64(1)), r)
+	w, err := k.commitG1(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return y, w, nil
+}
+
+// Verify checks that the polynomial committed to in c evaluates to y at z
+// with e(C - [y]G1 + [z]W, G2) e(-W, [tau]G2) = 1 which is
+// e(C - [y]G1, G2) = e(W, [tau - z]G2) moved to a single pairing check.
+func (k *KZG) Verify(c *pairing.G1Point, z, y *nt.Integer, w *pairing.G1Point) bool {
+	g1 := k.Curve.G1
+	lhs := g1.Add(c, g1.Neg(g1.ScalarMul(g1.Generator(), y)))
+	lhs = g1.Add(lhs, g1.ScalarMul(w, z))
+	ok, err := k.Curve.PairingCheck(
+		[]*pairing.G1Point{lhs, g1.Neg(w)},
+		[]*pairing.G2Point{k.SRS.G2[0], k.SRS.G2[1]},
+	)
+	return err == nil && ok
+}
+
+// vanishing returns Z(X) = prod (X - z_i) and checks that the z_i are
+// distinct modulo r
+func vanishing(zs []*nt.Integer, r *nt.Integer) (poly.Polynomial, error) {
+	z := poly.NewPolynomialInts(1)
+	for i := range zs {
+		for j := 0; j < i; j++ {
+			if nt.Mod(nt.Sub(zs[i], zs[j]), r).Sign() == 0 {
+				return nil, errPoints
+			}
+		}
+		z = z.Mul(poly.NewPolynomialBigInt(nt.Mod(new(nt.Integer).Neg(zs[i]), r), nt.FromInt64(1)), r)
+	}
+	return z, nil
+}
+
+// interpolate returns the polynomial I of degree < n with I(z_i) = y_i
+func interpolate(zs, ys []*nt.Integer, r *nt.Integer) poly.Polynomial {
+	points := make([]poly.Point, len(zs))
+	for i := range zs {
+		points[i] = poly.NewPoint(nt.Mod(zs[i], r), nt.Mod(ys[i], r))
+	}
+	return poly.Lagrange(points, r)
+}
+
+// BatchOpen evaluates p at the points zs and returns the evaluations with a
+// single witness [q(tau)]G1 where q(X) = (p(X) - I(X))/Z(X), I interpolates
+// the evaluations and Z vanishes on zs.
+func (k *KZG) BatchOpen(p poly.Polynomial, zs []*nt.Integer) ([]*nt.Integer, *pairing.G1Point, error) {
+	r := k.Curve.R
+	if len(zs) == 0 || len(zs) >= len(k.SRS.G2) {
+		return nil, nil, errPoints
+	}
+	z, err := vanishing(zs, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	ys := make([]*nt.Integer, len(zs))
+	for i := range zs {
+		ys[i] = p.Eval(zs[i], r)
+	}
+	q, _ := p.Sub(interpolate(zs, ys, r), r).Div(z, r)
+	w, err := k.commitG1(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ys, w, nil
+}
+
+// BatchVerify checks that the polynomial committed to in c evaluates to
+// ys[i] at zs[i] with e(C - [I(tau)]G1, G2) = e(W, [Z(tau)]G2)
+func (k *KZG) BatchVerify(c *pairing.G1Point, zs, ys []*nt.Integer, w *pairing.G1Point) bool {
+	r := k.Curve.R
+	if len(zs) == 0 || len(zs) != len(ys) {
+		return false
+	}
+	z, err := vanishing(zs, r)
+	if err != nil {
+		return false
+	}
+	ci, err := k.commitG1(interpolate(zs, ys, r))
+	if err != nil {
+		return false
+	}
+	cz, err := k.commitG2(z)
+	if err != nil {
+		return false
+	}
+	g1 := k.Curve.G1
+	ok, err := k.Curve.PairingCheck(
+		[]*pairing.G1Point{g1.Add(c, g1.Neg(ci)), g1.Neg(w)},
+		[]*pairing.G2Point{k.SRS.G2[0], cz},
+	)
+	return err == nil && ok
+}
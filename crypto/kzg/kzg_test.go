@@ -0,0 +1,165 @@
+package kzg
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+func TestKZG(t *testing.T) {
+
+	c := pairing.BN254()
+	tau := nt.FromInt64(0xc0ffee)
+	srs := newSRS(c, 8, 4, tau)
+	k := New(c, srs)
+	// p(X) = 3 + 2X + X^3 + 7X^8
+	p := poly.NewPolynomialInts(3, 2, 0, 1, 0, 0, 0, 0, 7)
+
+	t.Run("TestCommit", func(t *testing.T) {
+		cm, err := k.Commit(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := c.G1.ScalarMul(c.G1.Generator(), p.Eval(tau, c.R))
+		if !c.G1.Equal(cm, want) {
+			t.Error("commitment isn't [p(tau)]G1")
+		}
+		if _, err := k.Commit(p.Mul(poly.NewPolynomialInts(0, 1), c.R)); err == nil {
+			t.Error("committing beyond the SRS degree should fail")
+		}
+	})
+
+	t.Run("TestOpen", func(t *testing.T) {
+		cm, _ := k.Commit(p)
+		z := nt.FromInt64(5)
+		y, w, err := k.Open(p, z)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if y.Cmp(p.Eval(z, c.R)) != 0 {
+			t.Error("opening doesn't evaluate p")
+		}
+		if !k.Verify(cm, z, y, w) {
+			t.Error("opening should verify")
+		}
+		if k.Verify(cm, z, nt.Add(y, nt.One), w) {
+			t.Error("opening to a wrong value shouldn't verify")
+		}
+		if k.Verify(cm, nt.FromInt64(6), y, w) {
+			t.Error("opening at another point shouldn't verify")
+		}
+		// constant polynomials have a trivial witness
+		cst := poly.NewPolynomialInts(42)
+		cc, _ := k.Commit(cst)
+		y, w, _ = k.Open(cst, z)
+		if !w.Inf || !k.Verify(cc, z, y, w) {
+			t.Error("constant polynomial opening should verify")
+		}
+	})
+
+	t.Run("TestBatchOpen", func(t *testing.T) {
+		cm, _ := k.Commit(p)
+		zs := []*nt.Integer{nt.FromInt64(1), nt.FromInt64(2), nt.FromInt64(-3)}
+		ys, w, err := k.BatchOpen(p, zs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range zs {
+			if ys[i].Cmp(p.Eval(zs[i], c.R)) != 0 {
+				t.Error("batch opening doesn't evaluate p")
+			}
+		}
+		if !k.BatchVerify(cm, zs, ys, w) {
+			t.Error("batch opening should verify")
+		}
+		ys[1] = nt.Add(ys[1], nt.One)
+		if k.BatchVerify(cm, zs, ys, w) {
+			t.Error("batch opening to a wrong value shouldn't verify")
+		}
+		// a single point batch is a regular opening
+		ys, w, _ = k.BatchOpen(p, zs[:1])
+		if !k.Verify(cm, zs[0], ys[0], w) {
+			t.Error("batch opening of one point should match Open")
+		}
+		if _, _, err := k.BatchOpen(p, []*nt.Integer{zs[0], zs[0]}); err == nil {
+			t.Error("repeated points should be rejected")
+		}
+		if _, _, err := k.BatchOpen(p, append(zs, nt.FromInt64(4))); err == nil {
+			t.Error("batches larger than the G2 powers should be rejected")
+		}
+	})
+
+	t.Run("TestSRS", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteSRS(&buf, c, srs); err != nil {
+			t.Fatal(err)
+		}
+		dir, err := ioutil.TempDir("", "kzg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "srs.txt")
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0600); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadSRS(path, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range srs.G1 {
+			if !c.G1.Equal(srs.G1[i], loaded.G1[i]) {
+				t.Fatal("G1 powers don't round trip")
+			}
+		}
+		for i := range srs.G2 {
+			if !c.G2.Equal(srs.G2[i], loaded.G2[i]) {
+				t.Fatal("G2 powers don't round trip")
+			}
+		}
+		// swapping two powers breaks the sequence
+		bad := &SRS{G1: append([]*pairing.G1Point{}, srs.G1...), G2: srs.G2}
+		bad.G1[2], bad.G1[3] = bad.G1[3], bad.G1[2]
+		if bad.Check(c) == nil {
+			t.Error("SRS with swapped powers should be rejected")
+		}
+		bad = &SRS{G1: srs.G1, G2: newSRS(c, 0, 4, nt.Add(tau, nt.One)).G2}
+		if bad.Check(c) == nil {
+			t.Error("SRS with powers of distinct secrets should be rejected")
+		}
+		if _, err := ReadSRS(bytes.NewBufferString("2\n2\nzz\n"), c); err == nil {
+			t.Error("malformed SRS should be rejected")
+		}
+	})
+
+	t.Run("TestSetup", func(t *testing.T) {
+		c := pairing.BLS12381()
+		srs, err := Setup(c, 4, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := srs.Check(c); err != nil {
+			t.Fatal(err)
+		}
+		k := New(c, srs)
+		q := poly.NewPolynomialInts(1, 2, 3, 4, 5)
+		cm, _ := k.Commit(q)
+		zs := []*nt.Integer{nt.FromInt64(10), nt.FromInt64(20)}
+		ys, w, err := k.BatchOpen(q, zs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !k.BatchVerify(cm, zs, ys, w) {
+			t.Error("batch opening should verify on BLS12-381")
+		}
+		if _, err := Setup(c, 4, 1); err == nil {
+			t.Error("setup requires [tau]G2")
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package kzg
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+)
+
+var (
+	errSRSFormat = errors.New("malformed SRS file")
+	errSRSPowers = errors.New("SRS points aren't successive powers of the same secret")
+)
+
+// An SRS file holds the number of G1 points and the number of G2 points on
+// the first two lines followed by one point per line in hexadecimal, the G1
+// points are the monomial powers tau^i G1. Points are uncompressed affine
+// coordinates written big endian, x || y for G1 and x1 || x0 || y1 || y0 for
+// G2 with each coordinate on the byte size of p. This is the format written
+// by WriteSRS, ceremony outputs with compressed points or G1 in Lagrange form
+// such as the Ethereum trusted_setup.txt have to be converted first.
+
+func encodeInts(c *pairing.Curve, xs ...*nt.Integer) string {
+	return hex.EncodeToString(c.AppendFp(nil, xs...))
+}
+
+func decodeInts(c *pairing.Curve, n int, s string) ([]*nt.Integer, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != c.FpSize()*n {
+		return nil, errSRSFormat
+	}
+	return c.ReadFp(b, n), nil
+}
+
+// WriteSRS serializes s to w
+func WriteSRS(w io.Writer, c *pairing.Curve, s *SRS) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%d\n%d\n", len(s.G1), len(s.G2))
+	for _, p := range s.G1 {
+		fmt.Fprintln(bw, encodeInts(c, p.X.Big(), p.Y.Big()))
+	}
+	for _, p := range s.G2 {
+		fmt.Fprintln(bw, encodeInts(c, p.X.C1.Big(), p.X.C0.Big(), p.Y.C1.Big(), p.Y.C0.Big()))
+	}
+	return bw.Flush()
+}
+
+// ReadSRS parses an SRS, every point is checked to be in its group and the
+// sequences to be powers of the same secret.
+func ReadSRS(r io.Reader, c *pairing.Curve) (*SRS, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 1024), 1<<20)
+	next := func() (string, error) {
+		for sc.Scan() {
+			if line := strings.TrimSpace(sc.Text()); line != "" {
+				return line, nil
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return "", err
+		}
+		return "", errSRSFormat
+	}
+	count := func() (int, error) {
+		line, err := next()
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return 0, errSRSFormat
+		}
+		return n, nil
+	}
+	n1, err := count()
+	if err != nil {
+		return nil, err
+	}
+	n2, err := count()
+	if err != nil {
+		return nil, err
+	}
+	if n1 < 1 || n2 < 2 {
+		return nil, errSetup
+	}
+	s := &SRS{G1: make([]*pairing.G1Point, n1), G2: make([]*pairing.G2Point, n2)}
+	for i := range s.G1 {
+		line, err := next()
+		if err != nil {
+			return nil, err
+		}
+		xy, err := decodeInts(c, 2, line)
+		if err != nil {
+			return nil, err
+		}
+		p, err := c.G1.NewPoint(xy[0], xy[1])
+		if err != nil {
+			return nil, err
+		}
+		if !c.G1.InSubgroup(p) {
+			return nil, errSRSFormat
+		}
+		s.G1[i] = p
+	}
+	for i := range s.G2 {
+		line, err := next()
+		if err != nil {
+			return nil, err
+		}
+		xy, err := decodeInts(c, 4, line)
+		if err != nil {
+			return nil, err
+		}
+		p, err := c.G2.NewPoint(c.Fp2.New(xy[1], xy[0]), c.Fp2.New(xy[3], xy[2]))
+		if err != nil {
+			return nil, err
+		}
+		if !c.G2.InSubgroup(p) {
+			return nil, errSRSFormat
+		}
+		s.G2[i] = p
+	}
+	if err := s.Check(c); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// LoadSRS reads an SRS written by WriteSRS from the file at path
+func LoadSRS(path string, c *pairing.Curve) (*SRS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadSRS(f, c)
+}
+
+// Check verifies that both sequences start at the generators and are powers
+// of the same tau. Instead of one pairing per power it checks a random
+// linear combination sum a_i [tau^i]G1 paired with [tau]G2 against
+// sum a_i [tau^(i+1)]G1 paired with G2, and similarly for G2 with [tau]G1.
+func (s *SRS) Check(c *pairing.Curve) error {
+	g1, g2 := c.G1, c.G2
+	if len(s.G1) < 1 || len(s.G2) < 2 {
+		return errSetup
+	}
+	if !g1.Equal(s.G1[0], g1.Generator()) || !g2.Equal(s.G2[0], g2.Generator()) {
+		return errSRSPowers
+	}
+	lo, hi := g1.Infinity(), g1.Infinity()
+	for i := 0; i+1 < len(s.G1); i++ {
+		a, err := rand.Int(rand.Reader, c.R)
+		if err != nil {
+			return err
+		}
+		lo = g1.Add(lo, g1.ScalarMul(s.G1[i], a))
+		hi = g1.Add(hi, g1.ScalarMul(s.G1[i+1], a))
+	}
+	lo2, hi2 := g2.Infinity(), g2.Infinity()
+	for i := 0; i+1 < len(s.G2); i++ {
+		a, err := rand.Int(rand.Reader, c.R)
+		if err != nil {
+			return err
+		}
+		lo2 = g2.Add(lo2, g2.ScalarMul(s.G2[i], a))
+		hi2 = g2.Add(hi2, g2.ScalarMul(s.G2[i+1], a))
+	}
+	// e(lo, [tau]G2) = e(hi, G2) and e(G1, hi2) = e([tau]G1, lo2), the second
+	// equation needs [tau]G1 and is skipped for constant polynomials
+	ps := []*pairing.G1Point{lo, g1.Neg(hi)}
+	qs := []*pairing.G2Point{s.G2[1], s.G2[0]}
+	if len(s.G1) > 1 {
+		ps = append(ps, s.G1[0], g1.Neg(s.G1[1]))
+		qs = append(qs, hi2, lo2)
+	}
+	ok, err := c.PairingCheck(ps, qs)
+	if err != nil || !ok {
+		return errSRSPowers
+	}
+	return nil
+}
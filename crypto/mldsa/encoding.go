@@ -0,0 +1,206 @@
+package mldsa
+
+import (
+	"math/bits"
+)
+
+// bitLen returns the number of bits of x
+func bitLen(x uint64) uint {
+	return uint(bits.Len64(x))
+}
+
+// packBits packs the coefficients of w on c bits each in little endian bit
+// order
+func packBits(w *poly, c uint) []byte {
+	out := make([]byte, 32*c)
+	for i, x := range w {
+		for j := uint(0); j < c; j++ {
+			k := uint(i)*c + j
+			out[k/8] |= byte(x>>j&1) << (k % 8)
+		}
+	}
+	return out
+}
+
+// unpackBits reverses packBits
+func unpackBits(b []byte, c uint) *poly {
+	var w poly
+	for i := range w {
+		var x uint64
+		for j := uint(0); j < c; j++ {
+			k := uint(i)*c + j
+			x |= uint64(b[k/8]>>(k%8)&1) << j
+		}
+		w[i] = x
+	}
+	return &w
+}
+
+// simpleBitPack encodes coefficients in [0, b], it's FIPS 204 Algorithm 16.
+func simpleBitPack(w *poly, b uint64) []byte {
+	return packBits(w, bitLen(b))
+}
+
+// bitPack encodes coefficients in [-a, b] as b - w_i, it's FIPS 204
+// Algorithm 17.
+func bitPack(w *poly, a, b uint64) []byte {
+	var v poly
+	for i, x := range w {
+		v[i] = uint64(int64(b) - centered(x))
+	}
+	return packBits(&v, bitLen(a+b))
+}
+
+// bitUnpack reverses bitPack, it's FIPS 204 Algorithm 19.
+func bitUnpack(v []byte, a, b uint64) *poly {
+	w := unpackBits(v, bitLen(a+b))
+	for i, x := range w {
+		w[i] = reduce(int64(b) - int64(x))
+	}
+	return w
+}
+
+// hintBitPack encodes the positions of the ones of h followed by the
+// running count of ones per polynomial, it's FIPS 204 Algorithm 20.
+func (p Params) hintBitPack(h [][]bool) []byte {
+	y := make([]byte, p.Omega+p.K)
+	index := 0
+	for i := range h {
+		for j, b := range h[i] {
+			if b {
+				y[index] = byte(j)
+				index++
+			}
+		}
+		y[p.Omega+i] = byte(index)
+	}
+	return y
+}
+
+// hintBitUnpack decodes a hint and rejects any encoding which isn't the
+// unique one, it's FIPS 204 Algorithm 21.
+func (p Params) hintBitUnpack(y []byte) ([][]bool, bool) {
+	h := make([][]bool, p.K)
+	index := 0
+	for i := range h {
+		h[i] = make([]bool, n)
+		end := int(y[p.Omega+i])
+		if end < index || end > p.Omega {
+			return nil, false
+		}
+		first := index
+		for ; index < end; index++ {
+			// positions must be strictly increasing
+			if index > first && y[index-1] >= y[index] {
+				return nil, false
+			}
+			h[i][y[index]] = true
+		}
+	}
+	for _, b := range y[index:p.Omega] {
+		if b != 0 {
+			return nil, false
+		}
+	}
+	return h, true
+}
+
+// t1Bits is the number of bits of the coefficients of t1
+const t1Bits = 23 - d
+
+// pkEncode returns rho || t1, it's FIPS 204 Algorithm 22.
+func (p Params) pkEncode(rho []byte, t1 []*poly) []byte {
+	pk := append([]byte{}, rho...)
+	for _, t := range t1 {
+		pk = append(pk, simpleBitPack(t, 1<<t1Bits-1)...)
+	}
+	return pk
+}
+
+// pkDecode reverses pkEncode, it's FIPS 204 Algorithm 23.
+func (p Params) pkDecode(pk []byte) ([]byte, []*poly) {
+	t1 := make([]*poly, p.K)
+	size := 32 * t1Bits
+	for i := range t1 {
+		t1[i] = unpackBits(pk[32+size*i:], t1Bits)
+	}
+	return pk[:32], t1
+}
+
+// etaBits is the size in bits of an encoded coefficient of s1 and s2
+func (p Params) etaBits() uint {
+	return bitLen(uint64(2 * p.Eta))
+}
+
+// skEncode returns rho || K || tr || s1 || s2 || t0, it's FIPS 204
+// Algorithm 24.
+func (p Params) skEncode(rho, key, tr []byte, s1, s2, t0 []*poly) []byte {
+	sk := append(append(append([]byte{}, rho...), key...), tr...)
+	eta := uint64(p.Eta)
+	for _, s := range append(append([]*poly{}, s1...), s2...) {
+		sk = append(sk, bitPack(s, eta, eta)...)
+	}
+	for _, t := range t0 {
+		sk = append(sk, bitPack(t, 1<<(d-1)-1, 1<<(d-1))...)
+	}
+	return sk
+}
+
+// skDecode reverses skEncode, it's FIPS 204 Algorithm 25.
+func (p Params) skDecode(sk []byte) (rho, key, tr []byte, s1, s2, t0 []*poly) {
+	rho, key, tr = sk[:32], sk[32:64], sk[64:128]
+	off := 128
+	eta := uint64(p.Eta)
+	size := 32 * int(p.etaBits())
+	s1, s2, t0 = make([]*poly, p.L), make([]*poly, p.K), make([]*poly, p.K)
+	for i := range s1 {
+		s1[i] = bitUnpack(sk[off:], eta, eta)
+		off += size
+	}
+	for i := range s2 {
+		s2[i] = bitUnpack(sk[off:], eta, eta)
+		off += size
+	}
+	for i := range t0 {
+		t0[i] = bitUnpack(sk[off:], 1<<(d-1)-1, 1<<(d-1))
+		off += 32 * d
+	}
+	return
+}
+
+// gamma1Bits is the size in bits of an encoded coefficient of z
+func (p Params) gamma1Bits() uint {
+	return bitLen(p.Gamma1 - 1 + p.Gamma1)
+}
+
+// sigEncode returns c~ || z || h, it's FIPS 204 Algorithm 26.
+func (p Params) sigEncode(ct []byte, z []*poly, h [][]bool) []byte {
+	sig := append([]byte{}, ct...)
+	for _, zi := range z {
+		sig = append(sig, bitPack(zi, p.Gamma1-1, p.Gamma1)...)
+	}
+	return append(sig, p.hintBitPack(h)...)
+}
+
+// sigDecode reverses sigEncode, it's FIPS 204 Algorithm 27.
+func (p Params) sigDecode(sig []byte) ([]byte, []*poly, [][]bool, bool) {
+	ct := sig[:p.Lambda/4]
+	off := p.Lambda / 4
+	size := 32 * int(p.gamma1Bits())
+	z := make([]*poly, p.L)
+	for i := range z {
+		z[i] = bitUnpack(sig[off:], p.Gamma1-1, p.Gamma1)
+		off += size
+	}
+	h, ok := p.hintBitUnpack(sig[off:])
+	return ct, z, h, ok
+}
+
+// w1Encode packs the high bits of w, it's FIPS 204 Algorithm 28.
+func (p Params) w1Encode(w1 []*poly) []byte {
+	var out []byte
+	for _, w := range w1 {
+		out = append(out, simpleBitPack(w, (q-1)/(2*p.Gamma2)-1)...)
+	}
+	return out
+}
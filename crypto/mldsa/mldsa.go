@@ -0,0 +1,322 @@
+// Package mldsa implements ML-DSA, the module lattice based signature
+// scheme standardized from Dilithium. The public key is the high part t1 of
+// t = A s1 + s2 over Z_8380417[x]/(x^256 + 1), signing follows the
+// Fiat-Shamir with aborts paradigm where a response z = y + c s1 is only
+// released once its norm and the low bits of the commitment leak nothing
+// about the secret, and hints let the verifier recover the high bits of
+// the commitment without t0.
+// ref : FIPS 204 Module-Lattice-Based Digital Signature Standard
+package mldsa
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// SeedSize is the size of the key generation seed
+	SeedSize = 32
+	// RandomSize is the size of the signing randomness
+	RandomSize = 32
+	// maxContextSize bounds the size of the context string
+	maxContextSize = 255
+)
+
+var (
+	errSeed       = errors.New("invalid seed size")
+	errPrivateKey = errors.New("invalid private key size")
+	errPublicKey  = errors.New("invalid public key size")
+	errContext    = errors.New("context string is longer than 255 bytes")
+)
+
+// Params defines an ML-DSA parameter set with the notations of FIPS 204.
+type Params struct {
+	Name string
+	// K and L are the dimensions of the matrix A
+	K, L int
+	// Eta bounds the coefficients of the secrets s1 and s2
+	Eta int
+	// Tau is the number of non zero coefficients of the challenge
+	Tau int
+	// Lambda is the collision strength of the commitment hash c~
+	Lambda int
+	// Gamma1 bounds the coefficients of the mask y
+	Gamma1 uint64
+	// Gamma2 is the low order rounding range
+	Gamma2 uint64
+	// Omega bounds the number of ones in the hint
+	Omega int
+}
+
+// Parameter sets of FIPS 204 Section 4
+var (
+	MLDSA44 = Params{Name: "ML-DSA-44", K: 4, L: 4, Eta: 2, Tau: 39, Lambda: 128, Gamma1: 1 << 17, Gamma2: (q - 1) / 88, Omega: 80}
+	MLDSA65 = Params{Name: "ML-DSA-65", K: 6, L: 5, Eta: 4, Tau: 49, Lambda: 192, Gamma1: 1 << 19, Gamma2: (q - 1) / 32, Omega: 55}
+	MLDSA87 = Params{Name: "ML-DSA-87", K: 8, L: 7, Eta: 2, Tau: 60, Lambda: 256, Gamma1: 1 << 19, Gamma2: (q - 1) / 32, Omega: 75}
+)
+
+// beta = tau eta bounds the coefficients of c s1 and c s2
+func (p Params) beta() int64 {
+	return int64(p.Tau * p.Eta)
+}
+
+// PublicKeySize returns the size of the public keys
+func (p Params) PublicKeySize() int {
+	return 32 + 32*t1Bits*p.K
+}
+
+// PrivateKeySize returns the size of the private keys
+func (p Params) PrivateKeySize() int {
+	return 128 + 32*(int(p.etaBits())*(p.K+p.L)+d*p.K)
+}
+
+// SignatureSize returns the size of the signatures
+func (p Params) SignatureSize() int {
+	return p.Lambda/4 + 32*p.L*int(p.gamma1Bits()) + p.Omega + p.K
+}
+
+// h returns SHAKE256 of the concatenated inputs truncated to size bytes
+func h(size int, in ...[]byte) []byte {
+	xof := sha3.NewShake256()
+	for _, b := range in {
+		xof.Write(b)
+	}
+	out := make([]byte, size)
+	xof.Read(out)
+	return out
+}
+
+// expandA samples the k by l matrix A in NTT form where A[r][s] is
+// sampled from rho || s || r, it's FIPS 204 Algorithm 32.
+func (p Params) expandA(rho []byte) [][]*poly {
+	a := make([][]*poly, p.K)
+	for r := range a {
+		a[r] = make([]*poly, p.L)
+		for s := range a[r] {
+			a[r][s] = rejNTTPoly(append(append([]byte{}, rho...), byte(s), byte(r)))
+		}
+	}
+	return a
+}
+
+// expandS samples the secrets s1 and s2 with coefficients in [-eta, eta],
+// it's FIPS 204 Algorithm 33.
+func (p Params) expandS(rho []byte) ([]*poly, []*poly) {
+	s := make([]*poly, p.L+p.K)
+	for r := range s {
+		s[r] = rejBoundedPoly(append(append([]byte{}, rho...), byte(r), byte(r>>8)), p.Eta)
+	}
+	return s[:p.L], s[p.L:]
+}
+
+// expandMask samples the mask y with coefficients in (-gamma1, gamma1], it's
+// FIPS 204 Algorithm 34.
+func (p Params) expandMask(rho []byte, kappa int) []*poly {
+	y := make([]*poly, p.L)
+	size := 32 * int(p.gamma1Bits())
+	for r := range y {
+		v := h(size, rho, []byte{byte(kappa + r), byte((kappa + r) >> 8)})
+		y[r] = bitUnpack(v, p.Gamma1-1, p.Gamma1)
+	}
+	return y
+}
+
+// mulMatrix returns A v for A and v in NTT form
+func mulMatrix(a [][]*poly, v []*poly) []*poly {
+	w := make([]*poly, len(a))
+	for i := range a {
+		w[i] = new(poly)
+		for j := range v {
+			w[i] = add(w[i], mulNTT(a[i][j], v[j]))
+		}
+	}
+	return w
+}
+
+// nttVector returns the NTT of every polynomial of v
+func nttVector(v []*poly) []*poly {
+	w := make([]*poly, len(v))
+	for i := range v {
+		w[i] = v[i].ntt()
+	}
+	return w
+}
+
+// GenerateKey returns a new key pair
+func (p Params) GenerateKey() (pk, sk []byte, err error) {
+	seed := make([]byte, SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, nil, err
+	}
+	return p.NewKeyFromSeed(seed)
+}
+
+// NewKeyFromSeed derives the key pair of the seed xi, the public key holds
+// rho and the high bits t1 of t = A s1 + s2 and the private key holds the
+// secrets and the low bits t0, it's FIPS 204 Algorithm 6.
+func (p Params) NewKeyFromSeed(xi []byte) (pk, sk []byte, err error) {
+	if len(xi) != SeedSize {
+		return nil, nil, errSeed
+	}
+	seeds := h(128, xi, []byte{byte(p.K), byte(p.L)})
+	rho, rhoPrime, key := seeds[:32], seeds[32:96], seeds[96:]
+	a := p.expandA(rho)
+	s1, s2 := p.expandS(rhoPrime)
+	t := mulMatrix(a, nttVector(s1))
+	t1, t0 := make([]*poly, p.K), make([]*poly, p.K)
+	for i := range t {
+		ti := add(t[i].invNTT(), s2[i])
+		t1[i], t0[i] = new(poly), new(poly)
+		for j, x := range ti {
+			r1, r0 := power2Round(x)
+			t1[i][j], t0[i][j] = r1, reduce(r0)
+		}
+	}
+	pk = p.pkEncode(rho, t1)
+	tr := h(64, pk)
+	return pk, p.skEncode(rho, key, tr, s1, s2, t0), nil
+}
+
+// formatMessage returns M' = 0 || len(ctx) || ctx || M
+func formatMessage(m, ctx []byte) ([]byte, error) {
+	if len(ctx) > maxContextSize {
+		return nil, errContext
+	}
+	return append(append([]byte{0, byte(len(ctx))}, ctx...), m...), nil
+}
+
+// Sign returns the hedged signature of m under the context string ctx, it's
+// FIPS 204 Algorithm 2.
+func (p Params) Sign(sk, m, ctx []byte) ([]byte, error) {
+	rnd := make([]byte, RandomSize)
+	if _, err := rand.Read(rnd); err != nil {
+		return nil, err
+	}
+	mp, err := formatMessage(m, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.SignInternal(sk, mp, rnd)
+}
+
+// SignDeterministic returns the deterministic signature of m under the
+// context string ctx which uses rnd = 0
+func (p Params) SignDeterministic(sk, m, ctx []byte) ([]byte, error) {
+	mp, err := formatMessage(m, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.SignInternal(sk, mp, make([]byte, RandomSize))
+}
+
+// Verify checks the signature of m under the context string ctx, it's FIPS
+// 204 Algorithm 3.
+func (p Params) Verify(pk, m, ctx, sig []byte) bool {
+	mp, err := formatMessage(m, ctx)
+	if err != nil {
+		return false
+	}
+	return p.VerifyInternal(pk, mp, sig)
+}
+
+// SignInternal signs the formatted message mp with the randomness rnd, it's
+// FIPS 204 Algorithm 7. The mask y is derived from K, rnd and the message
+// representative mu and the loop restarts with a fresh mask until the
+// response z and the hint don't leak the secrets.
+func (p Params) SignInternal(sk, mp, rnd []byte) ([]byte, error) {
+	if len(sk) != p.PrivateKeySize() {
+		return nil, errPrivateKey
+	}
+	rho, key, tr, s1, s2, t0 := p.skDecode(sk)
+	s1Hat, s2Hat, t0Hat := nttVector(s1), nttVector(s2), nttVector(t0)
+	a := p.expandA(rho)
+	mu := h(64, tr, mp)
+	rhoPrime := h(64, key, rnd, mu)
+	gamma1, gamma2 := int64(p.Gamma1), int64(p.Gamma2)
+	for kappa := 0; ; kappa += p.L {
+		y := p.expandMask(rhoPrime, kappa)
+		w := mulMatrix(a, nttVector(y))
+		w1 := make([]*poly, p.K)
+		for i := range w {
+			w[i] = w[i].invNTT()
+			w1[i] = w[i].highBits(p.Gamma2)
+		}
+		ct := h(p.Lambda/4, mu, p.w1Encode(w1))
+		cHat := sampleInBall(ct, p.Tau).ntt()
+		z := make([]*poly, p.L)
+		reject := false
+		for i := range z {
+			z[i] = add(y[i], mulNTT(cHat, s1Hat[i]).invNTT())
+			reject = reject || z[i].norm() >= gamma1-p.beta()
+		}
+		if reject {
+			continue
+		}
+		hint := make([][]bool, p.K)
+		ones := 0
+		for i := range w {
+			// r = w - c s2 whose low bits must not reveal c s2
+			r := sub(w[i], mulNTT(cHat, s2Hat[i]).invNTT())
+			ct0 := mulNTT(cHat, t0Hat[i]).invNTT()
+			if r.lowBits(p.Gamma2).norm() >= gamma2-p.beta() || ct0.norm() >= gamma2 {
+				reject = true
+				break
+			}
+			// the hint recovers the high bits of r from r + c t0
+			rt := add(r, ct0)
+			hint[i] = make([]bool, n)
+			for j := range rt {
+				r1, _ := decompose(r[j], p.Gamma2)
+				v1, _ := decompose(rt[j], p.Gamma2)
+				if r1 != v1 {
+					hint[i][j] = true
+					ones++
+				}
+			}
+		}
+		if reject || ones > p.Omega {
+			continue
+		}
+		return p.sigEncode(ct, z, hint), nil
+	}
+}
+
+// VerifyInternal checks the signature of the formatted message mp, it
+// recomputes the high bits of the commitment as UseHint(h, A z - c t1 2^d)
+// and compares the hash to c~, it's FIPS 204 Algorithm 8.
+func (p Params) VerifyInternal(pk, mp, sig []byte) bool {
+	if len(pk) != p.PublicKeySize() || len(sig) != p.SignatureSize() {
+		return false
+	}
+	rho, t1 := p.pkDecode(pk)
+	ct, z, hint, ok := p.sigDecode(sig)
+	if !ok {
+		return false
+	}
+	for i := range z {
+		if z[i].norm() >= int64(p.Gamma1)-p.beta() {
+			return false
+		}
+	}
+	a := p.expandA(rho)
+	tr := h(64, pk)
+	mu := h(64, tr, mp)
+	cHat := sampleInBall(ct, p.Tau).ntt()
+	az := mulMatrix(a, nttVector(z))
+	w1 := make([]*poly, p.K)
+	for i := range az {
+		var t poly
+		for j, x := range t1[i] {
+			t[j] = x << d % q
+		}
+		w := sub(az[i], mulNTT(cHat, t.ntt())).invNTT()
+		w1[i] = new(poly)
+		for j := range w {
+			w1[i][j] = useHint(hint[i][j], w[j], p.Gamma2)
+		}
+	}
+	return subtle.ConstantTimeCompare(ct, h(p.Lambda/4, mu, p.w1Encode(w1))) == 1
+}
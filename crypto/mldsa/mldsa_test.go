@@ -0,0 +1,185 @@
+package mldsa
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// hexBytes decodes the hex strings of the ACVP files
+type hexBytes []byte
+
+func (b *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	d, err := hex.DecodeString(s)
+	*b = d
+	return err
+}
+
+// readVectors decodes a gzipped ACVP file of the testdata directory
+func readVectors(t *testing.T, path string, v interface{}) {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var paramSets = map[string]Params{
+	"ML-DSA-44": MLDSA44,
+	"ML-DSA-65": MLDSA65,
+	"ML-DSA-87": MLDSA87,
+}
+
+// acvpTest holds the fields of both the prompts and the expected results
+type acvpTest struct {
+	TcID       int      `json:"tcId"`
+	Seed       hexBytes `json:"seed"`
+	PK         hexBytes `json:"pk"`
+	SK         hexBytes `json:"sk"`
+	Message    hexBytes `json:"message"`
+	Rnd        hexBytes `json:"rnd"`
+	Signature  hexBytes `json:"signature"`
+	TestPassed bool     `json:"testPassed"`
+}
+
+type acvpGroup struct {
+	TgID          int        `json:"tgId"`
+	ParameterSet  string     `json:"parameterSet"`
+	Deterministic bool       `json:"deterministic"`
+	PK            hexBytes   `json:"pk"`
+	Tests         []acvpTest `json:"tests"`
+}
+
+type acvpFile struct {
+	TestGroups []acvpGroup `json:"testGroups"`
+}
+
+// loadACVP returns the prompts of a NIST ACVP test vector set alongside the
+// expected results indexed by group and test ids
+func loadACVP(t *testing.T, dir string) ([]acvpGroup, map[[2]int]acvpTest) {
+	var prompt, results acvpFile
+	readVectors(t, "testdata/"+dir+"/prompt.json.gz", &prompt)
+	readVectors(t, "testdata/"+dir+"/expectedResults.json.gz", &results)
+	expected := make(map[[2]int]acvpTest)
+	for _, g := range results.TestGroups {
+		for _, tc := range g.Tests {
+			expected[[2]int{g.TgID, tc.TcID}] = tc
+		}
+	}
+	return prompt.TestGroups, expected
+}
+
+func TestKeyGenKAT(t *testing.T) {
+	groups, expected := loadACVP(t, "ML-DSA-keyGen-FIPS204")
+	for _, g := range groups {
+		p := paramSets[g.ParameterSet]
+		for _, tc := range g.Tests {
+			want := expected[[2]int{g.TgID, tc.TcID}]
+			pk, sk, err := p.NewKeyFromSeed(tc.Seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(pk, want.PK) || !bytes.Equal(sk, want.SK) {
+				t.Fatalf("%s key generation test %d doesn't match", p.Name, tc.TcID)
+			}
+		}
+	}
+}
+
+// the signing vectors use the internal interface where the message is M'
+func TestSigGenKAT(t *testing.T) {
+	groups, expected := loadACVP(t, "ML-DSA-sigGen-FIPS204")
+	for _, g := range groups {
+		p := paramSets[g.ParameterSet]
+		for _, tc := range g.Tests {
+			want := expected[[2]int{g.TgID, tc.TcID}]
+			rnd := make([]byte, RandomSize)
+			if !g.Deterministic {
+				rnd = tc.Rnd
+			}
+			sig, err := p.SignInternal(tc.SK, tc.Message, rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(sig, want.Signature) {
+				t.Fatalf("%s signing test %d doesn't match", p.Name, tc.TcID)
+			}
+		}
+	}
+}
+
+func TestSigVerKAT(t *testing.T) {
+	groups, expected := loadACVP(t, "ML-DSA-sigVer-FIPS204")
+	for _, g := range groups {
+		p := paramSets[g.ParameterSet]
+		for _, tc := range g.Tests {
+			want := expected[[2]int{g.TgID, tc.TcID}]
+			if got := p.VerifyInternal(g.PK, tc.Message, tc.Signature); got != want.TestPassed {
+				t.Fatalf("%s verification test %d returned %v expected %v", p.Name, tc.TcID, got, want.TestPassed)
+			}
+		}
+	}
+}
+
+func TestMLDSA(t *testing.T) {
+	msg := []byte("lattice signatures")
+	ctx := []byte("algebra")
+	for _, p := range []Params{MLDSA44, MLDSA65, MLDSA87} {
+		pk, sk, err := p.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pk) != p.PublicKeySize() || len(sk) != p.PrivateKeySize() {
+			t.Fatalf("%s keys have the wrong size", p.Name)
+		}
+		t.Run("TestSignVerify"+p.Name[6:], func(t *testing.T) {
+			sig, err := p.Sign(sk, msg, ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sig) != p.SignatureSize() {
+				t.Fatal("signature has the wrong size")
+			}
+			if !p.Verify(pk, msg, ctx, sig) {
+				t.Fatal("valid signature rejected")
+			}
+			if p.Verify(pk, []byte("other message"), ctx, sig) {
+				t.Error("signature accepted for another message")
+			}
+			if p.Verify(pk, msg, nil, sig) {
+				t.Error("signature accepted under another context")
+			}
+			sig[0] ^= 1
+			if p.Verify(pk, msg, ctx, sig) {
+				t.Error("modified signature accepted")
+			}
+			if p.Verify(pk, msg, ctx, sig[1:]) {
+				t.Error("truncated signature accepted")
+			}
+		})
+		t.Run("TestDeterministic"+p.Name[6:], func(t *testing.T) {
+			s1, _ := p.SignDeterministic(sk, msg, ctx)
+			s2, _ := p.SignDeterministic(sk, msg, ctx)
+			if !bytes.Equal(s1, s2) {
+				t.Error("deterministic signatures differ")
+			}
+			if _, err := p.Sign(sk, msg, make([]byte, 256)); err == nil {
+				t.Error("contexts over 255 bytes should be rejected")
+			}
+		})
+	}
+}
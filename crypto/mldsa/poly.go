@@ -0,0 +1,241 @@
+package mldsa
+
+import (
+	"golang.org/x/crypto/sha3"
+
+	"github.com/actuallyachraf/algebra/ntt"
+)
+
+const (
+	n = 256
+	q = 8380417
+	// zeta is the primitive 512-th root of unity of FIPS 204
+	zeta = 1753
+	// d is the number of bits dropped from t
+	d = 13
+)
+
+// poly is an element of R_q = Z_q[x]/(x^256 + 1) with coefficients in
+// [0, q), either in coefficient or in NTT form
+type poly [n]uint64
+
+// nttParams is the negacyclic transform of FIPS 204 Algorithms 41 and 42,
+// q = 1 mod 512 so x^256 + 1 splits completely.
+var nttParams *ntt.NTTParams64
+
+func init() {
+	p, err := ntt.GenParams64WithRoot(n, q, zeta)
+	if err != nil {
+		panic(err)
+	}
+	nttParams = p
+}
+
+// ntt returns the NTT of f
+func (f *poly) ntt() *poly {
+	g := *f
+	nttParams.NTT64(g[:])
+	return &g
+}
+
+// invNTT returns the inverse NTT of f
+func (f *poly) invNTT() *poly {
+	g := *f
+	nttParams.InvNTT64(g[:])
+	return &g
+}
+
+// mulNTT returns the pointwise product of f and g
+func mulNTT(f, g *poly) *poly {
+	var h poly
+	for i := range h {
+		h[i] = f[i] * g[i] % q
+	}
+	return &h
+}
+
+// add returns f + g
+func add(f, g *poly) *poly {
+	var h poly
+	for i := range h {
+		h[i] = (f[i] + g[i]) % q
+	}
+	return &h
+}
+
+// sub returns f - g
+func sub(f, g *poly) *poly {
+	var h poly
+	for i := range h {
+		h[i] = (f[i] + q - g[i]) % q
+	}
+	return &h
+}
+
+// centered returns the representative of x in [-(q-1)/2, (q-1)/2]
+func centered(x uint64) int64 {
+	if x > (q-1)/2 {
+		return int64(x) - q
+	}
+	return int64(x)
+}
+
+// reduce returns x mod q in [0, q) for a signed x
+func reduce(x int64) uint64 {
+	x %= q
+	if x < 0 {
+		x += q
+	}
+	return uint64(x)
+}
+
+// norm returns the infinity norm of the centered coefficients of f
+func (f *poly) norm() int64 {
+	var m int64
+	for _, x := range f {
+		c := centered(x)
+		if c < 0 {
+			c = -c
+		}
+		if c > m {
+			m = c
+		}
+	}
+	return m
+}
+
+// rejNTTPoly samples a uniform polynomial in NTT form by rejection on 23 bit
+// values of SHAKE128 output, it's FIPS 204 Algorithm 30.
+func rejNTTPoly(seed []byte) *poly {
+	xof := sha3.NewShake128()
+	xof.Write(seed)
+	var a poly
+	var b [3]byte
+	for j := 0; j < n; {
+		xof.Read(b[:])
+		z := uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2]&0x7f)<<16
+		if z < q {
+			a[j] = z
+			j++
+		}
+	}
+	return &a
+}
+
+// coeffFromHalfByte maps a nibble to [-eta, eta] or rejects it, it's FIPS
+// 204 Algorithm 15.
+func coeffFromHalfByte(b byte, eta int) (int64, bool) {
+	switch {
+	case eta == 2 && b < 15:
+		return 2 - int64(b%5), true
+	case eta == 4 && b < 9:
+		return 4 - int64(b), true
+	}
+	return 0, false
+}
+
+// rejBoundedPoly samples a polynomial with coefficients in [-eta, eta] by
+// rejection on the nibbles of SHAKE256 output, it's FIPS 204 Algorithm 31.
+func rejBoundedPoly(seed []byte, eta int) *poly {
+	xof := sha3.NewShake256()
+	xof.Write(seed)
+	var a poly
+	var b [1]byte
+	for j := 0; j < n; {
+		xof.Read(b[:])
+		if z, ok := coeffFromHalfByte(b[0]&0x0f, eta); ok {
+			a[j] = reduce(z)
+			j++
+		}
+		if z, ok := coeffFromHalfByte(b[0]>>4, eta); ok && j < n {
+			a[j] = reduce(z)
+			j++
+		}
+	}
+	return &a
+}
+
+// sampleInBall samples the challenge c with tau coefficients in {-1, 1}
+// and the others zero from the commitment hash, it's FIPS 204 Algorithm 29.
+func sampleInBall(seed []byte, tau int) *poly {
+	xof := sha3.NewShake256()
+	xof.Write(seed)
+	var s [8]byte
+	xof.Read(s[:])
+	var c poly
+	var b [1]byte
+	for i := n - tau; i < n; i++ {
+		for {
+			xof.Read(b[:])
+			if int(b[0]) <= i {
+				break
+			}
+		}
+		j := int(b[0])
+		c[i] = c[j]
+		k := i + tau - n
+		if s[k/8]>>uint(k%8)&1 == 1 {
+			c[j] = q - 1
+		} else {
+			c[j] = 1
+		}
+	}
+	return &c
+}
+
+// power2Round splits r = r1 2^d + r0 with r0 in (-2^(d-1), 2^(d-1)], it's
+// FIPS 204 Algorithm 35.
+func power2Round(r uint64) (uint64, int64) {
+	r0 := int64(r & (1<<d - 1))
+	if r0 > 1<<(d-1) {
+		r0 -= 1 << d
+	}
+	return uint64(int64(r)-r0) >> d, r0
+}
+
+// decompose splits r = r1 2 gamma2 + r0 with r0 in (-gamma2, gamma2], the
+// value q - 1 is mapped to r1 = 0 and r0 = -1 so that r1 < (q - 1)/2 gamma2,
+// it's FIPS 204 Algorithm 36.
+func decompose(r, gamma2 uint64) (uint64, int64) {
+	r0 := int64(r % (2 * gamma2))
+	if r0 > int64(gamma2) {
+		r0 -= int64(2 * gamma2)
+	}
+	if int64(r)-r0 == q-1 {
+		return 0, r0 - 1
+	}
+	return uint64(int64(r)-r0) / (2 * gamma2), r0
+}
+
+// highBits returns r1 for every coefficient of f
+func (f *poly) highBits(gamma2 uint64) *poly {
+	var h poly
+	for i, x := range f {
+		h[i], _ = decompose(x, gamma2)
+	}
+	return &h
+}
+
+// lowBits returns r0 mod q for every coefficient of f
+func (f *poly) lowBits(gamma2 uint64) *poly {
+	var l poly
+	for i, x := range f {
+		_, r0 := decompose(x, gamma2)
+		l[i] = reduce(r0)
+	}
+	return &l
+}
+
+// useHint recovers the high bits of r + z from r and the hint h, it's FIPS
+// 204 Algorithm 40.
+func useHint(h bool, r, gamma2 uint64) uint64 {
+	m := (q - 1) / (2 * gamma2)
+	r1, r0 := decompose(r, gamma2)
+	switch {
+	case h && r0 > 0:
+		return (r1 + 1) % m
+	case h:
+		return (r1 + m - 1) % m
+	}
+	return r1
+}
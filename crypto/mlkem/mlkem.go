@@ -0,0 +1,159 @@
+// Package mlkem implements ML-KEM, the module lattice based key
+// encapsulation mechanism standardized from Kyber. The IND-CPA scheme K-PKE
+// encrypts 32 bytes with Module-LWE samples over Z_3329[x]/(x^256 + 1) and
+// the Fujisaki-Okamoto transform turns it into an IND-CCA2 KEM by
+// re-encrypting on decapsulation and returning an implicit rejection key
+// when the ciphertexts differ.
+// ref : FIPS 203 Module-Lattice-Based Key-Encapsulation Mechanism Standard
+package mlkem
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// SharedKeySize is the size of the shared keys
+	SharedKeySize = 32
+	// SeedSize is the size of the seed d || z of a key pair
+	SeedSize = 64
+	// MessageSize is the size of the encapsulation randomness
+	MessageSize = 32
+)
+
+var (
+	errEncapsulationKey = errors.New("invalid encapsulation key")
+	errDecapsulationKey = errors.New("invalid decapsulation key")
+	errCiphertext       = errors.New("invalid ciphertext size")
+	errSeed             = errors.New("invalid seed size")
+)
+
+// Params defines an ML-KEM parameter set, the module rank k, the
+// parameters eta1 and eta2 of the centered binomial distributions and the
+// number of bits du and dv kept by the ciphertext compression.
+type Params struct {
+	Name       string
+	K          int
+	Eta1, Eta2 int
+	Du, Dv     uint
+}
+
+// Parameter sets of FIPS 203 Section 8
+var (
+	MLKEM512  = Params{Name: "ML-KEM-512", K: 2, Eta1: 3, Eta2: 2, Du: 10, Dv: 4}
+	MLKEM768  = Params{Name: "ML-KEM-768", K: 3, Eta1: 2, Eta2: 2, Du: 10, Dv: 4}
+	MLKEM1024 = Params{Name: "ML-KEM-1024", K: 4, Eta1: 2, Eta2: 2, Du: 11, Dv: 5}
+)
+
+// EncapsulationKeySize returns the size of the encapsulation keys
+func (p Params) EncapsulationKeySize() int {
+	return encodedPolySize*p.K + 32
+}
+
+// DecapsulationKeySize returns the size of the decapsulation keys
+func (p Params) DecapsulationKeySize() int {
+	return 2*encodedPolySize*p.K + 96
+}
+
+// CiphertextSize returns the size of the ciphertexts
+func (p Params) CiphertextSize() int {
+	return 32 * (int(p.Du)*p.K + int(p.Dv))
+}
+
+// GenerateKey returns a new key pair, the encapsulation key ek is public
+// and the decapsulation key dk is secret
+func (p Params) GenerateKey() (ek, dk []byte, err error) {
+	seed := make([]byte, SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, nil, err
+	}
+	return p.NewKeyFromSeed(seed)
+}
+
+// NewKeyFromSeed derives the key pair of the seed d || z, dk is
+// dk_PKE || ek || H(ek) || z where z is the implicit rejection secret, it's
+// FIPS 203 Algorithm 16.
+func (p Params) NewKeyFromSeed(seed []byte) (ek, dk []byte, err error) {
+	if len(seed) != SeedSize {
+		return nil, nil, errSeed
+	}
+	ek, dkPKE := p.pkeKeyGen(seed[:32])
+	h := sha3.Sum256(ek)
+	dk = append(dkPKE, ek...)
+	dk = append(dk, h[:]...)
+	dk = append(dk, seed[32:]...)
+	return ek, dk, nil
+}
+
+// checkEncapsulationKey checks the size of ek and that its coefficients
+// are reduced modulo q
+func (p Params) checkEncapsulationKey(ek []byte) error {
+	if len(ek) != p.EncapsulationKeySize() {
+		return errEncapsulationKey
+	}
+	for i := 0; i < p.K; i++ {
+		b := ek[encodedPolySize*i : encodedPolySize*(i+1)]
+		if subtle.ConstantTimeCompare(byteEncode(byteDecode(b, 12), 12), b) != 1 {
+			return errEncapsulationKey
+		}
+	}
+	return nil
+}
+
+// Encapsulate returns a fresh shared key and its encapsulation under ek
+func (p Params) Encapsulate(ek []byte) (key, c []byte, err error) {
+	m := make([]byte, MessageSize)
+	if _, err := rand.Read(m); err != nil {
+		return nil, nil, err
+	}
+	return p.EncapsulateWithMessage(ek, m)
+}
+
+// EncapsulateWithMessage derives the shared key and the ciphertext from
+// the randomness m, (K, r) = G(m || H(ek)) and c is the encryption of m with
+// the randomness r, it's FIPS 203 Algorithm 17. Reusing m breaks security,
+// it's exposed for known answer tests.
+func (p Params) EncapsulateWithMessage(ek, m []byte) (key, c []byte, err error) {
+	if err := p.checkEncapsulationKey(ek); err != nil {
+		return nil, nil, err
+	}
+	if len(m) != MessageSize {
+		return nil, nil, errSeed
+	}
+	h := sha3.Sum256(ek)
+	g := sha3.Sum512(append(append([]byte{}, m...), h[:]...))
+	c = p.pkeEncrypt(ek, m, g[32:])
+	return g[:32], c, nil
+}
+
+// Decapsulate returns the shared key encapsulated in c, when the
+// re-encryption of the decrypted message doesn't give back c the pseudo
+// random key J(z || c) is returned instead so that invalid ciphertexts are
+// indistinguishable, it's FIPS 203 Algorithm 18.
+func (p Params) Decapsulate(dk, c []byte) ([]byte, error) {
+	if len(c) != p.CiphertextSize() {
+		return nil, errCiphertext
+	}
+	if len(dk) != p.DecapsulationKeySize() {
+		return nil, errDecapsulationKey
+	}
+	k := encodedPolySize * p.K
+	dkPKE, ek, h, z := dk[:k], dk[k:2*k+32], dk[2*k+32:2*k+64], dk[2*k+64:]
+	if hh := sha3.Sum256(ek); subtle.ConstantTimeCompare(hh[:], h) != 1 {
+		return nil, errDecapsulationKey
+	}
+	m := p.pkeDecrypt(dkPKE, c)
+	g := sha3.Sum512(append(append([]byte{}, m...), h...))
+	rejected := make([]byte, SharedKeySize)
+	j := sha3.NewShake256()
+	j.Write(z)
+	j.Write(c)
+	j.Read(rejected)
+	key := g[:32]
+	cc := p.pkeEncrypt(ek, m, g[32:])
+	subtle.ConstantTimeCopy(1-subtle.ConstantTimeCompare(c, cc), key, rejected)
+	return key, nil
+}
@@ -0,0 +1,184 @@
+package mlkem
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// hexBytes decodes the hex strings of the ACVP files
+type hexBytes []byte
+
+func (b *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	d, err := hex.DecodeString(s)
+	*b = d
+	return err
+}
+
+// readVectors decodes a gzipped ACVP file of the testdata directory
+func readVectors(t *testing.T, path string, v interface{}) {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var paramSets = map[string]Params{
+	"ML-KEM-512":  MLKEM512,
+	"ML-KEM-768":  MLKEM768,
+	"ML-KEM-1024": MLKEM1024,
+}
+
+// acvpTest holds the fields of both the prompts and the expected results
+type acvpTest struct {
+	TcID int      `json:"tcId"`
+	D    hexBytes `json:"d"`
+	Z    hexBytes `json:"z"`
+	M    hexBytes `json:"m"`
+	C    hexBytes `json:"c"`
+	K    hexBytes `json:"k"`
+	EK   hexBytes `json:"ek"`
+	DK   hexBytes `json:"dk"`
+}
+
+type acvpGroup struct {
+	TgID         int        `json:"tgId"`
+	ParameterSet string     `json:"parameterSet"`
+	Function     string     `json:"function"`
+	DK           hexBytes   `json:"dk"`
+	Tests        []acvpTest `json:"tests"`
+}
+
+type acvpFile struct {
+	TestGroups []acvpGroup `json:"testGroups"`
+}
+
+// loadACVP returns the prompts of a NIST ACVP test vector set alongside the
+// expected results indexed by group and test ids
+func loadACVP(t *testing.T, dir string) ([]acvpGroup, map[[2]int]acvpTest) {
+	var prompt, results acvpFile
+	readVectors(t, "testdata/"+dir+"/prompt.json.gz", &prompt)
+	readVectors(t, "testdata/"+dir+"/expectedResults.json.gz", &results)
+	expected := make(map[[2]int]acvpTest)
+	for _, g := range results.TestGroups {
+		for _, tc := range g.Tests {
+			expected[[2]int{g.TgID, tc.TcID}] = tc
+		}
+	}
+	return prompt.TestGroups, expected
+}
+
+func TestKeyGenKAT(t *testing.T) {
+	groups, expected := loadACVP(t, "ML-KEM-keyGen-FIPS203")
+	for _, g := range groups {
+		p := paramSets[g.ParameterSet]
+		for _, tc := range g.Tests {
+			want := expected[[2]int{g.TgID, tc.TcID}]
+			ek, dk, err := p.NewKeyFromSeed(append(append([]byte{}, tc.D...), tc.Z...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ek, want.EK) || !bytes.Equal(dk, want.DK) {
+				t.Fatalf("%s key generation test %d doesn't match", p.Name, tc.TcID)
+			}
+		}
+	}
+}
+
+func TestEncapDecapKAT(t *testing.T) {
+	groups, expected := loadACVP(t, "ML-KEM-encapDecap-FIPS203")
+	for _, g := range groups {
+		p := paramSets[g.ParameterSet]
+		for _, tc := range g.Tests {
+			want := expected[[2]int{g.TgID, tc.TcID}]
+			switch g.Function {
+			case "encapsulation":
+				key, c, err := p.EncapsulateWithMessage(tc.EK, tc.M)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(c, want.C) || !bytes.Equal(key, want.K) {
+					t.Fatalf("%s encapsulation test %d doesn't match", p.Name, tc.TcID)
+				}
+			case "decapsulation":
+				// the vectors include modified ciphertexts which must
+				// return the implicit rejection key
+				key, err := p.Decapsulate(g.DK, tc.C)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(key, want.K) {
+					t.Fatalf("%s decapsulation test %d doesn't match", p.Name, tc.TcID)
+				}
+			}
+		}
+	}
+}
+
+func TestMLKEM(t *testing.T) {
+	for _, p := range []Params{MLKEM512, MLKEM768, MLKEM1024} {
+		ek, dk, err := p.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ek) != p.EncapsulationKeySize() || len(dk) != p.DecapsulationKeySize() {
+			t.Fatalf("%s keys have the wrong size", p.Name)
+		}
+		t.Run("TestRoundTrip"+p.Name[6:], func(t *testing.T) {
+			key, c, err := p.Encapsulate(ek)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(c) != p.CiphertextSize() {
+				t.Fatal("ciphertext has the wrong size")
+			}
+			got, err := p.Decapsulate(dk, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, key) {
+				t.Fatal("Decapsulate(Encapsulate(ek)) doesn't give back the shared key")
+			}
+			c[0] ^= 1
+			got, _ = p.Decapsulate(dk, c)
+			if bytes.Equal(got, key) {
+				t.Fatal("modified ciphertexts should be implicitly rejected")
+			}
+		})
+		t.Run("TestInvalidInputs"+p.Name[6:], func(t *testing.T) {
+			// a 12 bit value of at least q isn't a reduced coefficient
+			bad := append([]byte{}, ek...)
+			bad[0], bad[1] = 0xff, 0xff
+			if _, _, err := p.Encapsulate(bad); err == nil {
+				t.Error("encapsulation keys with unreduced coefficients should be rejected")
+			}
+			if _, _, err := p.Encapsulate(ek[1:]); err == nil {
+				t.Error("truncated encapsulation keys should be rejected")
+			}
+			_, c, _ := p.Encapsulate(ek)
+			if _, err := p.Decapsulate(dk, c[1:]); err == nil {
+				t.Error("truncated ciphertexts should be rejected")
+			}
+			bad = append([]byte{}, dk...)
+			bad[2*encodedPolySize*p.K+40] ^= 1
+			if _, err := p.Decapsulate(bad, c); err == nil {
+				t.Error("decapsulation keys with a wrong hash should be rejected")
+			}
+		})
+	}
+}
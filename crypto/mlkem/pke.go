@@ -0,0 +1,113 @@
+package mlkem
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// expandMatrix samples the k by k matrix A in NTT form from the seed rho
+// where A[i][j] is sampled from rho || j || i
+func (p Params) expandMatrix(rho []byte) [][]*poly {
+	a := make([][]*poly, p.K)
+	for i := range a {
+		a[i] = make([]*poly, p.K)
+		for j := range a[i] {
+			a[i][j] = sampleNTT(rho, byte(j), byte(i))
+		}
+	}
+	return a
+}
+
+// sampleVector samples k polynomials from the centered binomial distribution
+// with the PRF keyed by sigma, starting from the counter *nonce
+func (p Params) sampleVector(eta int, sigma []byte, nonce *byte) []*poly {
+	v := make([]*poly, p.K)
+	for i := range v {
+		v[i] = samplePolyCBD(eta, prf(eta, sigma, *nonce))
+		*nonce++
+	}
+	return v
+}
+
+// dot returns sum a_i b_i for vectors in NTT form
+func dot(a, b []*poly) *poly {
+	acc := new(poly)
+	for i := range a {
+		acc = add(acc, mulNTT(a[i], b[i]))
+	}
+	return acc
+}
+
+// pkeKeyGen derives the K-PKE keys from the seed d, ek is the encoding of
+// t = A s + e in NTT form followed by rho and dk is the encoding of s in NTT
+// form, it's FIPS 203 Algorithm 13.
+func (p Params) pkeKeyGen(d []byte) (ek, dk []byte) {
+	g := sha3.Sum512(append(append([]byte{}, d...), byte(p.K)))
+	rho, sigma := g[:32], g[32:]
+	a := p.expandMatrix(rho)
+	var nonce byte
+	s := p.sampleVector(p.Eta1, sigma, &nonce)
+	e := p.sampleVector(p.Eta1, sigma, &nonce)
+	for i := range s {
+		s[i].ntt()
+		e[i].ntt()
+	}
+	for i := 0; i < p.K; i++ {
+		t := add(dot(a[i], s), e[i])
+		ek = append(ek, byteEncode(t, 12)...)
+		dk = append(dk, byteEncode(s[i], 12)...)
+	}
+	ek = append(ek, rho...)
+	return ek, dk
+}
+
+// pkeEncrypt encrypts the 32 bytes message m with the randomness r, it
+// returns the compressed u = A^T y + e1 and v = t^T y + e2 + round(q/2) m,
+// it's FIPS 203 Algorithm 14.
+func (p Params) pkeEncrypt(ek, m, r []byte) []byte {
+	t := make([]*poly, p.K)
+	for i := range t {
+		t[i] = byteDecode(ek[encodedPolySize*i:], 12)
+	}
+	a := p.expandMatrix(ek[encodedPolySize*p.K:])
+	var nonce byte
+	y := p.sampleVector(p.Eta1, r, &nonce)
+	e1 := p.sampleVector(p.Eta2, r, &nonce)
+	e2 := samplePolyCBD(p.Eta2, prf(p.Eta2, r, nonce))
+	for i := range y {
+		y[i].ntt()
+	}
+	var c []byte
+	for i := 0; i < p.K; i++ {
+		// column i of A
+		col := make([]*poly, p.K)
+		for j := range col {
+			col[j] = a[j][i]
+		}
+		u := dot(col, y)
+		u.invNTT()
+		u = add(u, e1[i])
+		c = append(c, byteEncode(compressPoly(u, p.Du), p.Du)...)
+	}
+	v := dot(t, y)
+	v.invNTT()
+	mu := decompressPoly(byteDecode(m, 1), 1)
+	v = add(add(v, e2), mu)
+	return append(c, byteEncode(compressPoly(v, p.Dv), p.Dv)...)
+}
+
+// pkeDecrypt recovers the message as the compression on one bit of
+// v - s^T u, it's FIPS 203 Algorithm 15.
+func (p Params) pkeDecrypt(dk, c []byte) []byte {
+	uSize := 32 * int(p.Du)
+	u := make([]*poly, p.K)
+	s := make([]*poly, p.K)
+	for i := range u {
+		u[i] = decompressPoly(byteDecode(c[uSize*i:], p.Du), p.Du)
+		u[i].ntt()
+		s[i] = byteDecode(dk[encodedPolySize*i:], 12)
+	}
+	v := decompressPoly(byteDecode(c[uSize*p.K:], p.Dv), p.Dv)
+	su := dot(s, u)
+	su.invNTT()
+	return byteEncode(compressPoly(sub(v, su), 1), 1)
+}
@@ -0,0 +1,227 @@
+package mlkem
+
+import (
+	"golang.org/x/crypto/sha3"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/ntt"
+)
+
+const (
+	n = 256
+	q = 3329
+	// zeta is the primitive 256-th root of unity of FIPS 203
+	zeta = 17
+	// encodedPolySize is the size of a polynomial encoded on 12 bits
+	encodedPolySize = 32 * 12
+)
+
+// poly is an element of R_q = Z_q[x]/(x^256 + 1) with coefficients in
+// [0, q), either in coefficient or in NTT form
+type poly [n]uint64
+
+// halfNTT is the negacyclic transform of length 128 for zeta, the NTT of
+// FIPS 203 only splits x^256 + 1 into the 128 quadratics x^2 - zeta^(2i+1)
+// since q - 1 = 2^8 13 and writing f = f_e(x^2) + x f_o(x^2) the residue of f
+// modulo x^2 - g is f_e(g) + x f_o(g), so it's the transform of length 128
+// of the even and the odd coefficients.
+var halfNTT *ntt.NTTParams64
+
+// gammas[i] = zeta^(2 brv7(i) + 1) is the root of the i-th quadratic
+var gammas [n / 2]uint64
+
+func init() {
+	p, err := ntt.GenParams64WithRoot(n/2, q, zeta)
+	if err != nil {
+		panic(err)
+	}
+	halfNTT = p
+	for i := range gammas {
+		e := 2*bitRev7(i) + 1
+		gammas[i] = nt.ModExp(nt.FromInt64(zeta), nt.FromInt64(int64(e)), nt.FromInt64(q)).Uint64()
+	}
+}
+
+// bitRev7 reverses the 7 low bits of i
+func bitRev7(i int) int {
+	r := 0
+	for b := 0; b < 7; b++ {
+		r |= (i >> uint(b) & 1) << uint(6-b)
+	}
+	return r
+}
+
+// split returns the even and the odd coefficients of f
+func (f *poly) split() ([]uint64, []uint64) {
+	even, odd := make([]uint64, n/2), make([]uint64, n/2)
+	for i := range even {
+		even[i], odd[i] = f[2*i], f[2*i+1]
+	}
+	return even, odd
+}
+
+// merge interleaves the even and the odd coefficients into f
+func (f *poly) merge(even, odd []uint64) {
+	for i := range even {
+		f[2*i], f[2*i+1] = even[i], odd[i]
+	}
+}
+
+// ntt computes in place the NTT of FIPS 203 Algorithm 9
+func (f *poly) ntt() {
+	even, odd := f.split()
+	halfNTT.NTT64(even)
+	halfNTT.NTT64(odd)
+	f.merge(even, odd)
+}
+
+// invNTT computes in place the inverse NTT of FIPS 203 Algorithm 10
+func (f *poly) invNTT() {
+	even, odd := f.split()
+	halfNTT.InvNTT64(even)
+	halfNTT.InvNTT64(odd)
+	f.merge(even, odd)
+}
+
+// mulNTT returns the product of f and g in NTT form following FIPS 203
+// Algorithm 11, the pairs (f_2i, f_2i+1) are multiplied modulo x^2 - gamma_i
+func mulNTT(f, g *poly) *poly {
+	var h poly
+	for i := 0; i < n/2; i++ {
+		a0, a1, b0, b1 := f[2*i], f[2*i+1], g[2*i], g[2*i+1]
+		h[2*i] = (a0*b0 + a1*b1%q*gammas[i]) % q
+		h[2*i+1] = (a0*b1 + a1*b0) % q
+	}
+	return &h
+}
+
+// add returns f + g
+func add(f, g *poly) *poly {
+	var h poly
+	for i := range h {
+		h[i] = (f[i] + g[i]) % q
+	}
+	return &h
+}
+
+// sub returns f - g
+func sub(f, g *poly) *poly {
+	var h poly
+	for i := range h {
+		h[i] = (f[i] + q - g[i]) % q
+	}
+	return &h
+}
+
+// sampleNTT samples a uniform polynomial in NTT form from the 34 bytes
+// seed rho || j || i by rejection on 12 bit values of SHAKE128 output, it's
+// FIPS 203 Algorithm 7.
+func sampleNTT(rho []byte, j, i byte) *poly {
+	xof := sha3.NewShake128()
+	xof.Write(rho)
+	xof.Write([]byte{j, i})
+	var a poly
+	var c [3]byte
+	for k := 0; k < n; {
+		xof.Read(c[:])
+		d1 := uint64(c[0]) | uint64(c[1]&0x0f)<<8
+		d2 := uint64(c[1]>>4) | uint64(c[2])<<4
+		if d1 < q {
+			a[k] = d1
+			k++
+		}
+		if d2 < q && k < n {
+			a[k] = d2
+			k++
+		}
+	}
+	return &a
+}
+
+// prf returns SHAKE256(s || b) of 64 eta bytes
+func prf(eta int, s []byte, b byte) []byte {
+	out := make([]byte, 64*eta)
+	h := sha3.NewShake256()
+	h.Write(s)
+	h.Write([]byte{b})
+	h.Read(out)
+	return out
+}
+
+// samplePolyCBD samples from the centered binomial distribution of
+// parameter eta, every coefficient is the difference of the sums of two
+// groups of eta bits of the 64 eta input bytes, it's FIPS 203 Algorithm 8.
+func samplePolyCBD(eta int, b []byte) *poly {
+	bit := func(k int) uint64 {
+		return uint64(b[k/8] >> uint(k%8) & 1)
+	}
+	var f poly
+	for i := range f {
+		var x, y uint64
+		for j := 0; j < eta; j++ {
+			x += bit(2*i*eta + j)
+			y += bit(2*i*eta + eta + j)
+		}
+		f[i] = (x + q - y) % q
+	}
+	return &f
+}
+
+// compress returns round(2^d x / q) mod 2^d, q is odd so there are no ties
+func compress(x uint64, d uint) uint64 {
+	return ((x<<d + q/2) / q) & (1<<d - 1)
+}
+
+// decompress returns round(q y / 2^d) with ties rounded up
+func decompress(y uint64, d uint) uint64 {
+	return (q*y + 1<<(d-1)) >> d
+}
+
+// byteEncode packs the coefficients of f on d bits each in little endian
+// bit order, it's FIPS 203 Algorithm 5.
+func byteEncode(f *poly, d uint) []byte {
+	out := make([]byte, 32*d)
+	for i, x := range f {
+		for j := uint(0); j < d; j++ {
+			k := uint(i)*d + j
+			out[k/8] |= byte(x>>j&1) << (k % 8)
+		}
+	}
+	return out
+}
+
+// byteDecode unpacks coefficients of d bits, 12 bit values are reduced
+// modulo q, it's FIPS 203 Algorithm 6.
+func byteDecode(b []byte, d uint) *poly {
+	var f poly
+	for i := range f {
+		var x uint64
+		for j := uint(0); j < d; j++ {
+			k := uint(i)*d + j
+			x |= uint64(b[k/8]>>(k%8)&1) << j
+		}
+		if d == 12 {
+			x %= q
+		}
+		f[i] = x
+	}
+	return &f
+}
+
+// compressPoly applies compress to every coefficient
+func compressPoly(f *poly, d uint) *poly {
+	var g poly
+	for i := range f {
+		g[i] = compress(f[i], d)
+	}
+	return &g
+}
+
+// decompressPoly applies decompress to every coefficient
+func decompressPoly(f *poly, d uint) *poly {
+	var g poly
+	for i := range f {
+		g[i] = decompress(f[i], d)
+	}
+	return &g
+}
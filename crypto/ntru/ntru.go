@@ -0,0 +1,222 @@
+// Package ntru implements the NTRU public key encryption scheme over the
+// convolution ring R = Z[x]/(x^N - 1) with p = 3 and a power of two q. The
+// private key is a ternary f invertible modulo 3 and modulo q, the public
+// key is h = 3 g f^-1 mod q and a ternary message m is encrypted as
+// c = r h + m mod q, decryption lifts f c to the centered representative of
+// 3 g r + f m and multiplies it by f^-1 modulo 3.
+// NTRU-HRSS encrypts the lift of m, a multiple of x - 1 congruent to m
+// modulo 3 and Phi_N = (x^N - 1)/(x - 1), so messages are recovered modulo
+// Phi_N which is why they have degree at most N - 2.
+// ref : NTRU: A Ring-Based Public Key Cryptosystem (Hoffstein, Pipher, Silverman)
+// ref : NTRU Algorithm Specifications and Supporting Documentation (NIST round 3)
+package ntru
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var (
+	errMessage    = errors.New("message isn't a ternary polynomial of the parameter set")
+	errCiphertext = errors.New("ciphertext doesn't match the parameters")
+)
+
+// PublicKey is h = 3 g f^-1 in Z_q[x]/(x^N - 1)
+type PublicKey struct {
+	H poly.Polynomial
+}
+
+// PrivateKey holds f and its inverse fp in Z_3[x]/(x^N - 1)
+type PrivateKey struct {
+	PublicKey
+	F, Fp poly.Polynomial
+}
+
+// modulus returns q as an integer
+func (p Params) modulus() *nt.Integer {
+	return nt.FromInt64(p.Q)
+}
+
+// ternary returns a polynomial of degree at most N - 2 with coefficients
+// drawn uniformly from {-1, 0, 1}
+func (p Params) ternary() (poly.Polynomial, error) {
+	c := make([]*nt.Integer, p.N-1)
+	three := big.NewInt(3)
+	for i := range c {
+		x, err := rand.Int(rand.Reader, three)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = x.Sub(x, nt.One)
+	}
+	return poly.NewPolynomialBigInt(c...), nil
+}
+
+// ternaryPlus returns a ternary polynomial v whose correlation
+// sum v_i v_(i+1) is non negative, when it's negative the signs of the odd
+// coefficients are flipped which negates every term of the sum.
+func (p Params) ternaryPlus() (poly.Polynomial, error) {
+	v, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	corr := new(nt.Integer)
+	for i := 0; i+1 < len(v); i++ {
+		corr.Add(corr, nt.Mul(v[i], v[i+1]))
+	}
+	if corr.Sign() < 0 {
+		for i := 1; i < len(v); i += 2 {
+			v[i].Neg(v[i])
+		}
+	}
+	return v, nil
+}
+
+// fixedWeight returns a polynomial of degree at most N - 2 with Weight/2
+// coefficients equal to 1, Weight/2 equal to -1 and the others zero, the
+// positions are a uniform shuffle of the coefficient vector.
+func (p Params) fixedWeight() (poly.Polynomial, error) {
+	c := make([]*nt.Integer, p.N-1)
+	for i := range c {
+		switch {
+		case i < p.Weight/2:
+			c[i] = nt.FromInt64(1)
+		case i < p.Weight/2*2:
+			c[i] = nt.FromInt64(-1)
+		default:
+			c[i] = nt.FromInt64(0)
+		}
+	}
+	for i := len(c) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		c[i], c[j.Int64()] = c[j.Int64()], c[i]
+	}
+	return poly.NewPolynomialBigInt(c...), nil
+}
+
+// SampleMessage returns a random message of the parameter set, a fixed
+// weight ternary polynomial for HPS and a ternary one for HRSS
+func (p Params) SampleMessage() (poly.Polynomial, error) {
+	if p.hrss() {
+		return p.ternary()
+	}
+	return p.fixedWeight()
+}
+
+// sampleFG returns the secrets f and g, for HPS f is ternary and g has
+// fixed weight, for HRSS f and v are ternary with a non negative
+// correlation and g = (x - 1) v.
+func (p Params) sampleFG() (f, g poly.Polynomial, err error) {
+	if !p.hrss() {
+		if f, err = p.ternary(); err != nil {
+			return nil, nil, err
+		}
+		g, err = p.fixedWeight()
+		return f, g, err
+	}
+	if f, err = p.ternaryPlus(); err != nil {
+		return nil, nil, err
+	}
+	v, err := p.ternaryPlus()
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, v.Mul(poly.NewPolynomialInts(-1, 1), nil), nil
+}
+
+// GenerateKey returns a new private key, f is sampled until it's invertible
+// modulo 3 and modulo q.
+func (p Params) GenerateKey() (*PrivateKey, error) {
+	q := p.modulus()
+	for {
+		f, g, err := p.sampleFG()
+		if err != nil {
+			return nil, err
+		}
+		fp, err := InvertModPrime(f, p.N, nt.FromInt64(3))
+		if err != nil {
+			continue
+		}
+		fq, err := InvertModPowerOfTwo(f, p.N, q)
+		if err != nil {
+			continue
+		}
+		h := convolve(poly.NewPolynomialInts(3), convolve(g, fq, p.N, q), p.N, q)
+		return &PrivateKey{PublicKey: PublicKey{H: h}, F: f, Fp: fp}, nil
+	}
+}
+
+// checkMessage checks that m has degree at most N - 2 and ternary
+// coefficients, at most Weight of them non zero for HPS
+func (p Params) checkMessage(m poly.Polynomial) error {
+	if len(m) > p.N-1 {
+		return errMessage
+	}
+	weight := 0
+	for _, x := range m {
+		if x.CmpAbs(nt.One) > 0 {
+			return errMessage
+		}
+		if x.Sign() != 0 {
+			weight++
+		}
+	}
+	if !p.hrss() && weight > p.Weight {
+		return errMessage
+	}
+	return nil
+}
+
+// lift returns the polynomial encrypted for m, m itself for HPS and
+// (x - 1) u for HRSS where u = m/(x - 1) in Z_3[x]/(Phi_N) has centered
+// coefficients, x - 1 is invertible there since Phi_N(1) = N isn't a
+// multiple of 3.
+func (p Params) lift(m poly.Polynomial) (poly.Polynomial, error) {
+	if !p.hrss() {
+		return m, nil
+	}
+	three := nt.FromInt64(3)
+	phi1 := poly.NewPolynomialInts(-1, 1)
+	inv, err := invert(phi1, phiN(p.N), three)
+	if err != nil {
+		return nil, err
+	}
+	u := centerLift(m.Clone(0).Mul(inv, three).Mod(phiN(p.N), three), three)
+	return u.Mul(phi1, nil), nil
+}
+
+// Encrypt returns c = r h + lift(m) mod q for a fresh ternary r
+func (p Params) Encrypt(pk *PublicKey, m poly.Polynomial) (poly.Polynomial, error) {
+	if err := p.checkMessage(m); err != nil {
+		return nil, err
+	}
+	lm, err := p.lift(m)
+	if err != nil {
+		return nil, err
+	}
+	r, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	q := p.modulus()
+	return convolve(r, pk.H, p.N, q).Add(lm, q), nil
+}
+
+// Decrypt recovers the ternary message of c, a = f c mod q is lifted to
+// (-q/2, q/2] where it equals 3 g r + f lift(m) over the integers so
+// fp a = lift(m) = m modulo 3 and Phi_N.
+func (p Params) Decrypt(sk *PrivateKey, c poly.Polynomial) (poly.Polynomial, error) {
+	if len(c) > p.N {
+		return nil, errCiphertext
+	}
+	a := centerLift(convolve(sk.F, c, p.N, p.modulus()), p.modulus())
+	three := nt.FromInt64(3)
+	return centerLift(modPhiN(convolve(sk.Fp, a, p.N, three), p.N, three), three), nil
+}
@@ -0,0 +1,134 @@
+package ntru
+
+import (
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+// equal compares polynomials by their trimmed coefficients
+func equal(a, b poly.Polynomial) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNTRU(t *testing.T) {
+	t.Run("TestInverse", func(t *testing.T) {
+		// x^N - 1 has the factor x - 1 so f must not vanish at 1
+		f := poly.NewPolynomialInts(1, 1, 0, 0, -1, 0, 1, -1, 0, 1, 0, 0, -1)
+		N := 13
+		for _, m := range []int64{2, 3, 7} {
+			fm, err := InvertModPrime(f, N, nt.FromInt64(m))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := convolve(f, fm, N, nt.FromInt64(m)); !equal(got, poly.NewPolynomialInts(1)) {
+				t.Errorf("f f^-1 = %v modulo %d", got, m)
+			}
+		}
+		for _, q := range []int64{2, 4, 2048, 1 << 20} {
+			fq, err := InvertModPowerOfTwo(f, N, nt.FromInt64(q))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := convolve(f, fq, N, nt.FromInt64(q)); !equal(got, poly.NewPolynomialInts(1)) {
+				t.Errorf("f f^-1 = %v modulo %d", got, q)
+			}
+		}
+		if _, err := InvertModPowerOfTwo(f, N, nt.FromInt64(12)); err == nil {
+			t.Error("moduli that aren't powers of two should be rejected")
+		}
+		// 1 + x + ... + x^(N-1) divides x^N - 1
+		ones := make([]int, N)
+		for i := range ones {
+			ones[i] = 1
+		}
+		if _, err := InvertModPrime(poly.NewPolynomialInts(ones...), N, nt.FromInt64(3)); err == nil {
+			t.Error("zero divisors shouldn't be invertible")
+		}
+	})
+	t.Run("TestEncryptDecrypt", func(t *testing.T) {
+		for _, p := range []Params{HPS2048509, HPS2048677, HPS4096821, HRSS701} {
+			sk, err := p.GenerateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := convolve(sk.F, sk.Fp, p.N, nt.FromInt64(3)); !equal(got, poly.NewPolynomialInts(1)) {
+				t.Fatalf("f fp != 1 for %s", p.Name)
+			}
+			other, _ := p.GenerateKey()
+			for i := 0; i < 3; i++ {
+				m, err := p.SampleMessage()
+				if err != nil {
+					t.Fatal(err)
+				}
+				c, err := p.Encrypt(&sk.PublicKey, m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := p.Decrypt(sk, c)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !equal(got, m) {
+					t.Fatalf("Decrypt(Encrypt(m)) != m for %s", p.Name)
+				}
+				if got, _ := p.Decrypt(other, c); equal(got, m) {
+					t.Errorf("decryption with another key shouldn't recover m for %s", p.Name)
+				}
+			}
+			if _, err := p.Encrypt(&sk.PublicKey, poly.NewPolynomialInts(0, 2)); err == nil {
+				t.Error("non ternary messages should be rejected")
+			}
+			long := make([]int, p.N)
+			long[p.N-1] = 1
+			if _, err := p.Encrypt(&sk.PublicKey, poly.NewPolynomialInts(long...)); err == nil {
+				t.Error("messages of degree N - 1 should be rejected")
+			}
+		}
+	})
+	t.Run("TestHRSS", func(t *testing.T) {
+		p := HRSS701
+		three := nt.FromInt64(3)
+		for i := 0; i < 8; i++ {
+			v, _ := p.ternaryPlus()
+			corr := 0
+			for j := 0; j+1 < len(v); j++ {
+				corr += int(v[j].Int64() * v[j+1].Int64())
+			}
+			if corr < 0 {
+				t.Fatal("ternaryPlus has a negative correlation", corr)
+			}
+			// the lift is a multiple of x - 1 congruent to m modulo 3 and Phi_N
+			m, _ := p.SampleMessage()
+			lm, err := p.lift(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lm.Eval(nt.One, nil).Sign() != 0 {
+				t.Error("lift(m) isn't a multiple of x - 1")
+			}
+			if got := centerLift(modPhiN(lm, p.N, three), three); !equal(got, m) {
+				t.Error("lift(m) != m modulo 3 and Phi_N")
+			}
+		}
+		// h and lift(m) vanish at 1 so c(1) = 0 mod q
+		sk, err := p.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, _ := p.SampleMessage()
+		c, _ := p.Encrypt(&sk.PublicKey, m)
+		if c.Eval(nt.One, p.modulus()).Sign() != 0 {
+			t.Error("c(1) != 0 mod q")
+		}
+	})
+}
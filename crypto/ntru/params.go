@@ -0,0 +1,32 @@
+package ntru
+
+// Params defines the ring R = Z[x]/(x^N - 1), the power of two modulus q
+// and the shape of the ternary polynomials which have degree at most N - 2.
+// When Weight is non zero the parameters follow NTRU-HPS, g and the
+// messages have Weight/2 coefficients equal to 1 and Weight/2 equal to -1.
+// Otherwise they follow NTRU-HRSS where g = (x - 1) v and messages are
+// lifted to multiples of x - 1.
+type Params struct {
+	Name   string
+	N      int
+	Q      int64
+	Weight int
+}
+
+// Parameter sets of the NTRU submission to the NIST standardization,
+// decryption never fails since the coefficients of 3 g r + f m stay below
+// q/2. For HPS with Weight = q/8 - 2 they are bounded by 4 Weight. For
+// HRSS f and v have a non negative correlation so that |(x - 1) f| and
+// |(x - 1) v| are at most sqrt(2(N - 1)), which bounds the coefficients by
+// 4 sqrt(2) (N - 1) < q/2.
+var (
+	HPS2048509 = Params{Name: "ntruhps2048509", N: 509, Q: 2048, Weight: 2048/8 - 2}
+	HPS2048677 = Params{Name: "ntruhps2048677", N: 677, Q: 2048, Weight: 2048/8 - 2}
+	HPS4096821 = Params{Name: "ntruhps4096821", N: 821, Q: 4096, Weight: 4096/8 - 2}
+	HRSS701    = Params{Name: "ntruhrss701", N: 701, Q: 8192}
+)
+
+// hrss returns true for the NTRU-HRSS parameter sets
+func (p Params) hrss() bool {
+	return p.Weight == 0
+}
@@ -0,0 +1,118 @@
+package ntru
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var (
+	errNotInvertible = errors.New("polynomial isn't invertible modulo x^N - 1")
+	errPowerOfTwo    = errors.New("modulus must be a power of two")
+)
+
+// reduceRing returns a modulo x^N - 1 and m, the coefficient of x^i is
+// added to the coefficient of x^(i mod N)
+func reduceRing(a poly.Polynomial, N int, m *nt.Integer) poly.Polynomial {
+	c := make([]*nt.Integer, N)
+	for i := range c {
+		c[i] = nt.FromInt64(0)
+	}
+	for i, x := range a {
+		c[i%N].Add(c[i%N], x)
+	}
+	for i := range c {
+		c[i].Mod(c[i], m)
+	}
+	return poly.NewPolynomialBigInt(c...)
+}
+
+// convolve returns a b in Z_m[x]/(x^N - 1), the operands are left unchanged
+func convolve(a, b poly.Polynomial, N int, m *nt.Integer) poly.Polynomial {
+	return reduceRing(a.Clone(0).Mul(b.Clone(0), m), N, m)
+}
+
+// centerLift returns the representatives in (-m/2, m/2] of the
+// coefficients of a reduced modulo m
+func centerLift(a poly.Polynomial, m *nt.Integer) poly.Polynomial {
+	half := nt.Div(m, nt.FromInt64(2))
+	c := make([]*nt.Integer, len(a))
+	for i, x := range a {
+		c[i] = nt.Mod(x, m)
+		if c[i].Cmp(half) > 0 {
+			c[i].Sub(c[i], m)
+		}
+	}
+	return poly.NewPolynomialBigInt(c...)
+}
+
+// xN1 returns x^N - 1
+func xN1(N int) poly.Polynomial {
+	c := make([]*nt.Integer, N+1)
+	for i := range c {
+		c[i] = nt.FromInt64(0)
+	}
+	c[0], c[N] = nt.FromInt64(-1), nt.FromInt64(1)
+	return poly.NewPolynomialBigInt(c...)
+}
+
+// phiN returns Phi_N = 1 + x + ... + x^(N-1) = (x^N - 1)/(x - 1)
+func phiN(N int) poly.Polynomial {
+	c := make([]int, N)
+	for i := range c {
+		c[i] = 1
+	}
+	return poly.NewPolynomialInts(c...)
+}
+
+// modPhiN returns a modulo Phi_N and m, since x^(N-1) = -(1 + ... + x^(N-2))
+// the coefficient of x^(N-1) is subtracted from the others.
+func modPhiN(a poly.Polynomial, N int, m *nt.Integer) poly.Polynomial {
+	c := reduceRing(a, N, m)
+	if len(c) < N {
+		return c
+	}
+	for i := range c[:N-1] {
+		c[i] = nt.ModSub(c[i], c[N-1], m)
+	}
+	return poly.NewPolynomialBigInt(c[:N-1]...)
+}
+
+// invert returns the inverse of f modulo the polynomial m and the prime p,
+// the extended Euclidean algorithm gives d = s m + t f and f is invertible
+// when the gcd d is a non zero constant, its inverse is t/d.
+func invert(f, m poly.Polynomial, p *nt.Integer) (poly.Polynomial, error) {
+	d, _, t := m.XGCD(f, p)
+	if d.Degree() != 0 || d[0].Sign() == 0 {
+		return nil, errNotInvertible
+	}
+	dInv := nt.ModInv(nt.Mod(d[0], p), p)
+	return t.Mul(poly.NewPolynomialBigInt(dInv), p).Mod(m, p), nil
+}
+
+// InvertModPrime returns the inverse of f in Z_p[x]/(x^N - 1) for a prime p
+func InvertModPrime(f poly.Polynomial, N int, p *nt.Integer) (poly.Polynomial, error) {
+	return invert(reduceRing(f, N, p), xN1(N), p)
+}
+
+// InvertModPowerOfTwo returns the inverse of f in Z_q[x]/(x^N - 1) for q a
+// power of two, the inverse modulo 2 is lifted by Newton iteration
+// b = b (2 - f b) which doubles the number of correct bits since
+// f b = 1 mod 2^j implies 1 - f b (2 - f b) = (1 - f b)^2 = 0 mod 2^2j.
+func InvertModPowerOfTwo(f poly.Polynomial, N int, q *nt.Integer) (poly.Polynomial, error) {
+	k := q.BitLen() - 1
+	if q.Sign() <= 0 || nt.Sub(q, nt.One).BitLen() != k {
+		return nil, errPowerOfTwo
+	}
+	b, err := InvertModPrime(f, N, nt.FromInt64(2))
+	if err != nil {
+		return nil, err
+	}
+	two := poly.NewPolynomialInts(2)
+	for j := 1; j < k; j <<= 1 {
+		fb := convolve(f, b, N, q)
+		b = convolve(b, two.Sub(fb, q), N, q)
+	}
+	return b, nil
+}
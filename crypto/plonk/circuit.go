@@ -0,0 +1,106 @@
+package plonk
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+var (
+	errVariable = errors.New("gate refers to an unknown variable")
+	errWitness  = errors.New("witness doesn't satisfy the circuit")
+)
+
+// Gate is the arithmetic constraint
+// QL a + QR b + QO c + QM ab + QC = 0
+// where a, b and c are the values of the variables wired to its left, right
+// and output wires. Nil selectors are zero.
+type Gate struct {
+	A, B, C            int
+	QL, QR, QO, QM, QC *nt.Integer
+}
+
+// Circuit is a list of gates over Fr, the variables 0 to NumPublic - 1 are
+// the public inputs. Copy constraints are implicit, two wires are equal
+// whenever they refer to the same variable.
+type Circuit struct {
+	R         *nt.Integer
+	NumPublic int
+	NumVars   int
+	Gates     []Gate
+}
+
+// NewCircuit returns a circuit over Fr with numPublic public inputs
+func NewCircuit(r *nt.Integer, numPublic int) *Circuit {
+	return &Circuit{R: r, NumPublic: numPublic, NumVars: numPublic}
+}
+
+// NewVariable allocates a private variable and returns its index
+func (cs *Circuit) NewVariable() int {
+	cs.NumVars++
+	return cs.NumVars - 1
+}
+
+// AddGate appends the gate g to the circuit
+func (cs *Circuit) AddGate(g Gate) error {
+	for _, v := range []int{g.A, g.B, g.C} {
+		if v < 0 || v >= cs.NumVars {
+			return errVariable
+		}
+	}
+	cs.Gates = append(cs.Gates, g)
+	return nil
+}
+
+// Add constrains c = a + b
+func (cs *Circuit) Add(a, b, c int) error {
+	return cs.AddGate(Gate{A: a, B: b, C: c, QL: nt.FromInt64(1), QR: nt.FromInt64(1), QO: nt.FromInt64(-1)})
+}
+
+// Mul constrains c = ab
+func (cs *Circuit) Mul(a, b, c int) error {
+	return cs.AddGate(Gate{A: a, B: b, C: c, QM: nt.FromInt64(1), QO: nt.FromInt64(-1)})
+}
+
+// AddConstant constrains c = a + k
+func (cs *Circuit) AddConstant(a int, k *nt.Integer, c int) error {
+	return cs.AddGate(Gate{A: a, B: a, C: c, QL: nt.FromInt64(1), QO: nt.FromInt64(-1), QC: k})
+}
+
+// rows returns the gates of the arithmetization, one public input gate
+// QL a = x_i per public input followed by the circuit gates
+func (cs *Circuit) rows() []Gate {
+	rows := make([]Gate, 0, cs.NumPublic+len(cs.Gates))
+	for i := 0; i < cs.NumPublic; i++ {
+		rows = append(rows, Gate{A: i, B: i, C: i, QL: nt.FromInt64(1)})
+	}
+	return append(rows, cs.Gates...)
+}
+
+// selector returns the value of a possibly nil selector
+func selector(q *nt.Integer, r *nt.Integer) *nt.Integer {
+	if q == nil {
+		return nt.FromInt64(0)
+	}
+	return nt.Mod(q, r)
+}
+
+// IsSatisfied checks every gate on the assignment w of all the variables
+func (cs *Circuit) IsSatisfied(w []*nt.Integer) bool {
+	if len(w) != cs.NumVars {
+		return false
+	}
+	r := cs.R
+	for _, g := range cs.Gates {
+		a, b, c := w[g.A], w[g.B], w[g.C]
+		s := nt.ModMul(selector(g.QL, r), a, r)
+		s = nt.ModAdd(s, nt.ModMul(selector(g.QR, r), b, r), r)
+		s = nt.ModAdd(s, nt.ModMul(selector(g.QO, r), c, r), r)
+		s = nt.ModAdd(s, nt.ModMul(selector(g.QM, r), nt.ModMul(a, b, r), r), r)
+		s = nt.ModAdd(s, selector(g.QC, r), r)
+		if s.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
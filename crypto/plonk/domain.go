@@ -0,0 +1,128 @@
+package plonk
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var errDomain = errors.New("Fr has no multiplicative subgroup of the requested size")
+
+// domain is the multiplicative subgroup H = <omega> of order n of Fr*, a
+// power of two dividing r - 1. Polynomials of degree < n are moved between
+// coefficients and evaluations on H or on a coset gH with the discrete
+// Fourier transform of poly.
+type domain struct {
+	n               int
+	r               *nt.Integer
+	f               ff.FiniteField
+	dft             *poly.DFT
+	omega, omegaInv *nt.Integer
+	// g is a quadratic non residue, its powers are never in a 2-adic subgroup
+	// which makes gH, g^2H disjoint from H
+	g, gInv *nt.Integer
+}
+
+// newDomain returns the subgroup of order n of Fr*
+func newDomain(n int, r *nt.Integer) (*domain, error) {
+	if n < 1 || n&(n-1) != 0 {
+		return nil, errDomain
+	}
+	f, err := ff.NewFiniteField(r)
+	if err != nil {
+		return nil, err
+	}
+	dft, err := poly.NewDFT(f, n)
+	if err != nil {
+		return nil, errDomain
+	}
+	g := nt.FromInt64(2)
+	for nt.Jacobi(g, r) != -1 {
+		g = nt.Add(g, nt.One)
+	}
+	omega := dft.Root().Big()
+	return &domain{
+		n:        n,
+		r:        r,
+		f:        f,
+		dft:      dft,
+		omega:    omega,
+		omegaInv: nt.ModInv(omega, r),
+		g:        g,
+		gInv:     nt.ModInv(g, r),
+	}, nil
+}
+
+// element returns omega^i
+func (d *domain) element(i int) *nt.Integer {
+	return nt.ModExp(d.omega, nt.FromInt64(int64(i)), d.r)
+}
+
+// bigs returns the values of field elements
+func bigs(xs []ff.FieldElement) []*nt.Integer {
+	v := make([]*nt.Integer, len(xs))
+	for i, x := range xs {
+		v[i] = x.Big()
+	}
+	return v
+}
+
+// scale multiplies a[i] by k^i
+func (d *domain) scale(a []*nt.Integer, k *nt.Integer) {
+	t := nt.FromInt64(1)
+	for i := range a {
+		a[i] = nt.ModMul(a[i], t, d.r)
+		t = nt.ModMul(t, k, d.r)
+	}
+}
+
+// evaluate returns p(omega^i) for 0 <= i < n, deg p < n
+func (d *domain) evaluate(p poly.Polynomial) []*nt.Integer {
+	return bigs(d.dft.Evaluate(p))
+}
+
+// interpolate returns the polynomial of degree < n with p(omega^i) = ys[i],
+// missing values are zero so the transform always has the right length.
+func (d *domain) interpolate(ys []*nt.Integer) poly.Polynomial {
+	values := make([]ff.FieldElement, d.n)
+	for i := range values {
+		if i < len(ys) {
+			values[i] = d.f.NewFieldElement(ys[i])
+		} else {
+			values[i] = d.f.Zero()
+		}
+	}
+	p, _ := d.dft.Interpolate(values)
+	return p
+}
+
+// cosetEvaluate returns p(g omega^i) for 0 <= i < n, deg p < n
+func (d *domain) cosetEvaluate(p poly.Polynomial) []*nt.Integer {
+	a := p.Clone(0)
+	d.scale(a, d.g)
+	return d.evaluate(a)
+}
+
+// cosetInterpolate returns the polynomial of degree < n with
+// p(g omega^i) = ys[i]
+func (d *domain) cosetInterpolate(ys []*nt.Integer) poly.Polynomial {
+	p := d.interpolate(ys)
+	d.scale(p, d.gInv)
+	return poly.NewPolynomialBigInt(p...)
+}
+
+// vanishing returns Z_H(x) = x^n - 1
+func (d *domain) vanishing(x *nt.Integer) *nt.Integer {
+	return nt.ModSub(nt.ModExp(x, nt.FromInt64(int64(d.n)), d.r), nt.One, d.r)
+}
+
+// lagrange returns L_i(x) = omega^i (x^n - 1)/(n (x - omega^i)) the i-th
+// Lagrange polynomial of H at x outside of H
+func (d *domain) lagrange(i int, x *nt.Integer) *nt.Integer {
+	wi := d.element(i)
+	num := nt.ModMul(wi, d.vanishing(x), d.r)
+	den := nt.ModMul(nt.FromInt64(int64(d.n)), nt.ModSub(x, wi, d.r), d.r)
+	return nt.ModMul(num, nt.ModInv(den, d.r), d.r)
+}
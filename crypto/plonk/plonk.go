@@ -0,0 +1,539 @@
+// Package plonk implements the PLONK universal zk-SNARK, circuits of
+// arithmetic gates are compiled against a KZG reference string that doesn't
+// depend on the circuit so a single powers of tau ceremony serves every
+// circuit up to its size. Gates are encoded by selector polynomials over a
+// multiplicative subgroup H, wiring by a permutation argument and the prover
+// shows that the combined constraint polynomial is divisible by the vanishing
+// polynomial of H.
+// ref : PLONK: Permutations over Lagrange-bases for Oecumenical Noninteractive
+// arguments of Knowledge (Gabizon, Williamson, Ciobotaru)
+package plonk
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/actuallyachraf/algebra/crypto/kzg"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var (
+	errSRS          = errors.New("SRS is too small for the circuit")
+	errPublicInputs = errors.New("number of public inputs doesn't match the verifying key")
+)
+
+// transcriptLabel domain separates the Fiat-Shamir challenges
+const transcriptLabel = "plonk"
+
+// VerifyingKey holds the commitments to the preprocessed polynomials of a
+// circuit of N rows
+type VerifyingKey struct {
+	N         int
+	NumPublic int
+	// commitments to the selectors and to the permutation polynomials
+	QL, QR, QO, QM, QC *pairing.G1Point
+	S1, S2, S3         *pairing.G1Point
+	// G2 and [tau]G2 from the SRS
+	G2, TauG2 *pairing.G2Point
+}
+
+// ProvingKey holds the preprocessed polynomials of a circuit
+type ProvingKey struct {
+	Circuit *Circuit
+	SRS     *kzg.SRS
+	VK      *VerifyingKey
+
+	QL, QR, QO, QM, QC poly.Polynomial
+	S1, S2, S3         poly.Polynomial
+	// sigma[j][i] = S_j(omega^i)
+	sigma [3][]*nt.Integer
+}
+
+// Proof is a PLONK proof, the commitments to the wire polynomials a, b and
+// c, the permutation accumulator z and the quotient t split in three, the
+// opening witnesses at zeta and zeta omega, and the evaluations at zeta.
+type Proof struct {
+	A, B, C, Z        *pairing.G1Point
+	TLo, TMid, THi    *pairing.G1Point
+	WZeta, WZetaOmega *pairing.G1Point
+
+	EvalA, EvalB, EvalC *nt.Integer
+	EvalS1, EvalS2      *nt.Integer
+	EvalZOmega          *nt.Integer
+}
+
+// shifts returns the coset representatives 1, k1 = g and k2 = g^2 of the
+// three wire columns
+func (d *domain) shifts() [3]*nt.Integer {
+	return [3]*nt.Integer{nt.FromInt64(1), d.g, nt.ModMul(d.g, d.g, d.r)}
+}
+
+// rowCount returns the size of the subgroup holding the rows of cs
+func rowCount(cs *Circuit) int {
+	n := 1
+	for n < cs.NumPublic+len(cs.Gates) {
+		n <<= 1
+	}
+	return n
+}
+
+// Setup preprocesses the circuit cs against the reference string srs which
+// must hold powers up to N + 5 in G1 and [tau]G2.
+func Setup(c *pairing.Curve, srs *kzg.SRS, cs *Circuit) (*ProvingKey, *VerifyingKey, error) {
+	r := c.R
+	rows := cs.rows()
+	n := rowCount(cs)
+	if srs.Degree() < n+5 || len(srs.G2) < 2 {
+		return nil, nil, errSRS
+	}
+	d, err := newDomain(n, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 8n is needed for the quotient
+	if _, err := newDomain(8*n, r); err != nil {
+		return nil, nil, err
+	}
+	var qs [5][]*nt.Integer
+	for j := range qs {
+		qs[j] = make([]*nt.Integer, n)
+	}
+	for i := 0; i < n; i++ {
+		g := Gate{}
+		if i < len(rows) {
+			g = rows[i]
+		}
+		for j, q := range []*nt.Integer{g.QL, g.QR, g.QO, g.QM, g.QC} {
+			qs[j][i] = selector(q, r)
+		}
+	}
+	pk := &ProvingKey{
+		Circuit: cs,
+		SRS:     srs,
+		QL:      d.interpolate(qs[0]),
+		QR:      d.interpolate(qs[1]),
+		QO:      d.interpolate(qs[2]),
+		QM:      d.interpolate(qs[3]),
+		QC:      d.interpolate(qs[4]),
+	}
+	// the wire at column j and row i is at position jn + i and labeled
+	// k_j omega^i, sigma sends every position to the next one wired to the
+	// same variable and padding rows to themselves
+	k := d.shifts()
+	label := func(pos int) *nt.Integer {
+		return nt.ModMul(k[pos/n], d.element(pos%n), r)
+	}
+	perm := make([]int, 3*n)
+	for pos := range perm {
+		perm[pos] = pos
+	}
+	last := make(map[int]int)
+	first := make(map[int]int)
+	for i, g := range rows {
+		for j, v := range []int{g.A, g.B, g.C} {
+			pos := j*n + i
+			if prev, ok := last[v]; ok {
+				perm[prev] = pos
+			} else {
+				first[v] = pos
+			}
+			last[v] = pos
+		}
+	}
+	for v, pos := range last {
+		perm[pos] = first[v]
+	}
+	for j := range pk.sigma {
+		pk.sigma[j] = make([]*nt.Integer, n)
+		for i := range pk.sigma[j] {
+			pk.sigma[j][i] = label(perm[j*n+i])
+		}
+	}
+	pk.S1 = d.interpolate(pk.sigma[0])
+	pk.S2 = d.interpolate(pk.sigma[1])
+	pk.S3 = d.interpolate(pk.sigma[2])
+
+	kz := kzg.New(c, srs)
+	vk := &VerifyingKey{N: n, NumPublic: cs.NumPublic, G2: srs.G2[0], TauG2: srs.G2[1]}
+	for _, e := range []struct {
+		dst **pairing.G1Point
+		p   poly.Polynomial
+	}{
+		{&vk.QL, pk.QL}, {&vk.QR, pk.QR}, {&vk.QO, pk.QO}, {&vk.QM, pk.QM}, {&vk.QC, pk.QC},
+		{&vk.S1, pk.S1}, {&vk.S2, pk.S2}, {&vk.S3, pk.S3},
+	} {
+		if *e.dst, err = kz.Commit(e.p); err != nil {
+			return nil, nil, err
+		}
+	}
+	pk.VK = vk
+	return pk, vk, nil
+}
+
+// newTranscript starts the transcript with the verifying key and the public
+// inputs
+func (vk *VerifyingKey) newTranscript(c *pairing.Curve, x []*nt.Integer) *transcript {
+	t := newTranscript(c, transcriptLabel)
+	t.appendScalar("n", nt.FromInt64(int64(vk.N)))
+	for _, p := range []*pairing.G1Point{vk.QL, vk.QR, vk.QO, vk.QM, vk.QC, vk.S1, vk.S2, vk.S3} {
+		t.appendPoint("vk", p)
+	}
+	for _, xi := range x {
+		t.appendScalar("x", xi)
+	}
+	return t
+}
+
+// scalarMul returns k p(X)
+func scalarMul(p poly.Polynomial, k, r *nt.Integer) poly.Polynomial {
+	return p.Mul(poly.NewPolynomialBigInt(nt.Mod(k, r)), r)
+}
+
+// constant returns p(X) + k
+func constant(p poly.Polynomial, k, r *nt.Integer) poly.Polynomial {
+	return p.Add(poly.NewPolynomialBigInt(nt.Mod(k, r)), r)
+}
+
+// blind returns p(X) + (b_0 + b_1 X + ...) Z_H(X) which leaves the values
+// of p on H unchanged
+func blind(p poly.Polynomial, n int, r *nt.Integer, bs ...*nt.Integer) poly.Polynomial {
+	zh := make(poly.Polynomial, n+1)
+	for i := range zh {
+		zh[i] = nt.FromInt64(0)
+	}
+	zh[0], zh[n] = nt.Sub(r, nt.One), nt.FromInt64(1)
+	return p.Add(poly.NewPolynomialBigInt(bs...).Mul(zh, r), r)
+}
+
+// chunk returns the polynomial of coefficients p[lo:hi]
+func chunk(p poly.Polynomial, lo, hi int) poly.Polynomial {
+	if hi > len(p) {
+		hi = len(p)
+	}
+	if lo >= hi {
+		return poly.NewPolynomialInts(0)
+	}
+	return poly.NewPolynomialBigInt(p[lo:hi].Clone(0)...)
+}
+
+// randomScalars samples k elements of Fr
+func randomScalars(k int, r *nt.Integer) ([]*nt.Integer, error) {
+	bs := make([]*nt.Integer, k)
+	for i := range bs {
+		b, err := rand.Int(rand.Reader, r)
+		if err != nil {
+			return nil, err
+		}
+		bs[i] = b
+	}
+	return bs, nil
+}
+
+// Prove computes a proof that the assignment w of the variables satisfies
+// the circuit of pk, the public inputs are the first variables of w.
+func Prove(c *pairing.Curve, pk *ProvingKey, w []*nt.Integer) (*Proof, error) {
+	cs := pk.Circuit
+	if !cs.IsSatisfied(w) {
+		return nil, errWitness
+	}
+	n := pk.VK.N
+	var wires [3][]*nt.Integer
+	for j := range wires {
+		wires[j] = make([]*nt.Integer, n)
+		for i := range wires[j] {
+			wires[j][i] = nt.FromInt64(0)
+		}
+	}
+	for i, g := range cs.rows() {
+		wires[0][i], wires[1][i], wires[2][i] = nt.Mod(w[g.A], c.R), nt.Mod(w[g.B], c.R), nt.Mod(w[g.C], c.R)
+	}
+	return prove(c, pk, w[:cs.NumPublic], wires)
+}
+
+// prove runs the prover on the values of the wires of every row, it fails
+// when the quotient isn't a polynomial i.e. when a gate or a copy constraint
+// doesn't hold.
+func prove(c *pairing.Curve, pk *ProvingKey, x []*nt.Integer, wires [3][]*nt.Integer) (*Proof, error) {
+	r := c.R
+	n := pk.VK.N
+	d, err := newDomain(n, r)
+	if err != nil {
+		return nil, err
+	}
+	d8, err := newDomain(8*n, r)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := randomScalars(9, r)
+	if err != nil {
+		return nil, err
+	}
+	kz := kzg.New(c, pk.SRS)
+	add := func(x, y *nt.Integer) *nt.Integer { return nt.ModAdd(x, y, r) }
+	sub := func(x, y *nt.Integer) *nt.Integer { return nt.ModSub(x, y, r) }
+	mul := func(x, y *nt.Integer) *nt.Integer { return nt.ModMul(x, y, r) }
+	t := pk.VK.newTranscript(c, x)
+	proof := &Proof{}
+
+	// round 1 : commit to the wire polynomials
+	a := blind(d.interpolate(wires[0]), n, r, bs[1], bs[0])
+	b := blind(d.interpolate(wires[1]), n, r, bs[3], bs[2])
+	cp := blind(d.interpolate(wires[2]), n, r, bs[5], bs[4])
+	for _, e := range []struct {
+		dst **pairing.G1Point
+		p   poly.Polynomial
+	}{{&proof.A, a}, {&proof.B, b}, {&proof.C, cp}} {
+		if *e.dst, err = kz.Commit(e.p); err != nil {
+			return nil, err
+		}
+		t.appendPoint("wire", *e.dst)
+	}
+	beta := t.challenge("beta")
+	gamma := t.challenge("gamma")
+
+	// round 2 : commit to the permutation accumulator
+	// z(omega^0) = 1 and z(omega^(i+1)) = z(omega^i) prod_j
+	// (w_j + beta k_j omega^i + gamma)/(w_j + beta sigma_j(omega^i) + gamma)
+	k := d.shifts()
+	zv := make([]*nt.Integer, n)
+	zv[0] = nt.FromInt64(1)
+	for i := 0; i+1 < n; i++ {
+		num, den := nt.FromInt64(1), nt.FromInt64(1)
+		wi := d.element(i)
+		for j := range wires {
+			num = mul(num, add(add(wires[j][i], mul(beta, mul(k[j], wi))), gamma))
+			den = mul(den, add(add(wires[j][i], mul(beta, pk.sigma[j][i])), gamma))
+		}
+		zv[i+1] = mul(zv[i], mul(num, nt.ModInv(den, r)))
+	}
+	z := blind(d.interpolate(zv), n, r, bs[8], bs[7], bs[6])
+	if proof.Z, err = kz.Commit(z); err != nil {
+		return nil, err
+	}
+	t.appendPoint("z", proof.Z)
+	alpha := t.challenge("alpha")
+
+	// round 3 : commit to the quotient t = num/Z_H evaluated on the coset
+	// g H' of the subgroup H' of order 8n which bounds deg num = 4n + 5
+	piv := make([]*nt.Integer, n)
+	for i := range piv {
+		piv[i] = nt.FromInt64(0)
+		if i < len(x) {
+			piv[i] = nt.Mod(new(nt.Integer).Neg(x[i]), r)
+		}
+	}
+	pi := d.interpolate(piv)
+	l0v := make([]*nt.Integer, n)
+	for i := range l0v {
+		l0v[i] = nt.FromInt64(0)
+	}
+	l0v[0] = nt.FromInt64(1)
+	l0 := d.interpolate(l0v)
+	zOmega := z.Clone(0)
+	d.scale(zOmega, d.omega)
+
+	ev := func(p poly.Polynomial) []*nt.Integer { return d8.cosetEvaluate(p) }
+	ea, eb, ec, ez, ezw := ev(a), ev(b), ev(cp), ev(z), ev(zOmega)
+	eql, eqr, eqo, eqm, eqc := ev(pk.QL), ev(pk.QR), ev(pk.QO), ev(pk.QM), ev(pk.QC)
+	es1, es2, es3 := ev(pk.S1), ev(pk.S2), ev(pk.S3)
+	epi, el0 := ev(pi), ev(l0)
+	// Z_H(g omega'^i) = g^n omega'^(in) - 1 has period 8
+	var zhInv [8]*nt.Integer
+	for i := range zhInv {
+		zhInv[i] = nt.ModInv(d.vanishing(nt.ModMul(d8.g, d8.element(i), r)), r)
+	}
+	alpha2 := mul(alpha, alpha)
+	tv := make([]*nt.Integer, 8*n)
+	xi := d8.g
+	for i := range tv {
+		gate := mul(mul(ea[i], eb[i]), eqm[i])
+		gate = add(gate, mul(ea[i], eql[i]))
+		gate = add(gate, mul(eb[i], eqr[i]))
+		gate = add(gate, mul(ec[i], eqo[i]))
+		gate = add(gate, add(epi[i], eqc[i]))
+		bx := mul(beta, xi)
+		p1 := add(add(ea[i], bx), gamma)
+		p1 = mul(p1, add(add(eb[i], mul(bx, k[1])), gamma))
+		p1 = mul(p1, add(add(ec[i], mul(bx, k[2])), gamma))
+		p1 = mul(p1, ez[i])
+		p2 := add(add(ea[i], mul(beta, es1[i])), gamma)
+		p2 = mul(p2, add(add(eb[i], mul(beta, es2[i])), gamma))
+		p2 = mul(p2, add(add(ec[i], mul(beta, es3[i])), gamma))
+		p2 = mul(p2, ezw[i])
+		l := mul(sub(ez[i], nt.One), el0[i])
+		num := add(gate, add(mul(alpha, sub(p1, p2)), mul(alpha2, l)))
+		tv[i] = mul(num, zhInv[i%8])
+		xi = mul(xi, d8.omega)
+	}
+	tq := d8.cosetInterpolate(tv)
+	if tq.Degree() > 3*n+5 {
+		return nil, errWitness
+	}
+	tLo, tMid, tHi := chunk(tq, 0, n), chunk(tq, n, 2*n), chunk(tq, 2*n, len(tq))
+	for _, e := range []struct {
+		dst **pairing.G1Point
+		p   poly.Polynomial
+	}{{&proof.TLo, tLo}, {&proof.TMid, tMid}, {&proof.THi, tHi}} {
+		if *e.dst, err = kz.Commit(e.p); err != nil {
+			return nil, err
+		}
+		t.appendPoint("t", *e.dst)
+	}
+	zeta := t.challenge("zeta")
+
+	// round 4 : evaluations at zeta
+	zetaOmega := mul(zeta, d.omega)
+	proof.EvalA, proof.EvalB, proof.EvalC = a.Eval(zeta, r), b.Eval(zeta, r), cp.Eval(zeta, r)
+	proof.EvalS1, proof.EvalS2 = pk.S1.Eval(zeta, r), pk.S2.Eval(zeta, r)
+	proof.EvalZOmega = z.Eval(zetaOmega, r)
+	proof.absorbEvals(t)
+	v := t.challenge("v")
+
+	// round 5 : the linearization polynomial vanishes at zeta, open it with
+	// the other polynomials at zeta and z at zeta omega
+	ab, bb, cb := proof.EvalA, proof.EvalB, proof.EvalC
+	s1b, s2b, zwb := proof.EvalS1, proof.EvalS2, proof.EvalZOmega
+	l0z := d.lagrange(0, zeta)
+	zhz := d.vanishing(zeta)
+	lin := scalarMul(pk.QM, mul(ab, bb), r)
+	lin = lin.Add(scalarMul(pk.QL, ab, r), r)
+	lin = lin.Add(scalarMul(pk.QR, bb, r), r)
+	lin = lin.Add(scalarMul(pk.QO, cb, r), r)
+	lin = lin.Add(pk.QC, r)
+	lin = constant(lin, pi.Eval(zeta, r), r)
+	bz := mul(beta, zeta)
+	perm := mul(alpha, add(add(ab, bz), gamma))
+	perm = mul(perm, add(add(bb, mul(bz, k[1])), gamma))
+	perm = mul(perm, add(add(cb, mul(bz, k[2])), gamma))
+	lin = lin.Add(scalarMul(z, add(perm, mul(alpha2, l0z)), r), r)
+	sp := mul(alpha, mul(add(add(ab, mul(beta, s1b)), gamma), add(add(bb, mul(beta, s2b)), gamma)))
+	sp = mul(sp, zwb)
+	lin = lin.Sub(scalarMul(pk.S3, mul(sp, beta), r), r)
+	lin = constant(lin, new(nt.Integer).Neg(add(mul(sp, add(cb, gamma)), mul(alpha2, l0z))), r)
+	zn := nt.ModExp(zeta, nt.FromInt64(int64(n)), r)
+	tz := tLo.Add(scalarMul(tMid, zn, r), r).Add(scalarMul(tHi, mul(zn, zn), r), r)
+	lin = lin.Sub(scalarMul(tz, zhz, r), r)
+
+	open := lin
+	vpow := nt.FromInt64(1)
+	for _, e := range []struct {
+		p poly.Polynomial
+		y *nt.Integer
+	}{{a, ab}, {b, bb}, {cp, cb}, {pk.S1, s1b}, {pk.S2, s2b}} {
+		vpow = mul(vpow, v)
+		open = open.Add(scalarMul(constant(e.p, new(nt.Integer).Neg(e.y), r), vpow, r), r)
+	}
+	if _, proof.WZeta, err = kz.Open(open, zeta); err != nil {
+		return nil, err
+	}
+	if _, proof.WZetaOmega, err = kz.Open(z, zetaOmega); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// absorbEvals appends the evaluations at zeta to the transcript
+func (p *Proof) absorbEvals(t *transcript) {
+	for _, e := range []*nt.Integer{p.EvalA, p.EvalB, p.EvalC, p.EvalS1, p.EvalS2, p.EvalZOmega} {
+		t.appendScalar("eval", e)
+	}
+}
+
+// Verify checks a proof against the public inputs x, the verifier rebuilds
+// the commitment [F] to the batched polynomial opened at zeta and the value
+// E it opens to, and checks both openings with a single pairing equation
+// e(W + u W', [tau]G2) = e(zeta W + u zeta omega W' + F - [E]G1, G2).
+func Verify(c *pairing.Curve, vk *VerifyingKey, x []*nt.Integer, proof *Proof) (bool, error) {
+	if len(x) != vk.NumPublic {
+		return false, errPublicInputs
+	}
+	r := c.R
+	n := vk.N
+	d, err := newDomain(n, r)
+	if err != nil {
+		return false, err
+	}
+	add := func(x, y *nt.Integer) *nt.Integer { return nt.ModAdd(x, y, r) }
+	sub := func(x, y *nt.Integer) *nt.Integer { return nt.ModSub(x, y, r) }
+	mul := func(x, y *nt.Integer) *nt.Integer { return nt.ModMul(x, y, r) }
+
+	t := vk.newTranscript(c, x)
+	for _, p := range []*pairing.G1Point{proof.A, proof.B, proof.C} {
+		t.appendPoint("wire", p)
+	}
+	beta := t.challenge("beta")
+	gamma := t.challenge("gamma")
+	t.appendPoint("z", proof.Z)
+	alpha := t.challenge("alpha")
+	for _, p := range []*pairing.G1Point{proof.TLo, proof.TMid, proof.THi} {
+		t.appendPoint("t", p)
+	}
+	zeta := t.challenge("zeta")
+	proof.absorbEvals(t)
+	v := t.challenge("v")
+	t.appendPoint("w", proof.WZeta)
+	t.appendPoint("w", proof.WZetaOmega)
+	u := t.challenge("u")
+
+	zhz := d.vanishing(zeta)
+	if zhz.Sign() == 0 {
+		return false, nil
+	}
+	l0z := d.lagrange(0, zeta)
+	piz := nt.FromInt64(0)
+	for i := range x {
+		piz = sub(piz, mul(x[i], d.lagrange(i, zeta)))
+	}
+	ab, bb, cb := nt.Mod(proof.EvalA, r), nt.Mod(proof.EvalB, r), nt.Mod(proof.EvalC, r)
+	s1b, s2b, zwb := nt.Mod(proof.EvalS1, r), nt.Mod(proof.EvalS2, r), nt.Mod(proof.EvalZOmega, r)
+	alpha2 := mul(alpha, alpha)
+	k := d.shifts()
+
+	// constant part of the linearization polynomial
+	sp := mul(alpha, mul(add(add(ab, mul(beta, s1b)), gamma), add(add(bb, mul(beta, s2b)), gamma)))
+	sp = mul(sp, zwb)
+	r0 := sub(sub(piz, mul(l0z, alpha2)), mul(sp, add(cb, gamma)))
+
+	g1 := c.G1
+	smul := func(p *pairing.G1Point, s *nt.Integer) *pairing.G1Point {
+		return g1.ScalarMul(p, nt.Mod(s, r))
+	}
+	bz := mul(beta, zeta)
+	perm := mul(alpha, add(add(ab, bz), gamma))
+	perm = mul(perm, add(add(bb, mul(bz, k[1])), gamma))
+	perm = mul(perm, add(add(cb, mul(bz, k[2])), gamma))
+	dc := smul(vk.QM, mul(ab, bb))
+	dc = g1.Add(dc, smul(vk.QL, ab))
+	dc = g1.Add(dc, smul(vk.QR, bb))
+	dc = g1.Add(dc, smul(vk.QO, cb))
+	dc = g1.Add(dc, vk.QC)
+	dc = g1.Add(dc, smul(proof.Z, add(add(perm, mul(alpha2, l0z)), u)))
+	dc = g1.Add(dc, g1.Neg(smul(vk.S3, mul(sp, beta))))
+	zn := nt.ModExp(zeta, nt.FromInt64(int64(n)), r)
+	tc := g1.Add(proof.TLo, smul(proof.TMid, zn))
+	tc = g1.Add(tc, smul(proof.THi, mul(zn, zn)))
+	dc = g1.Add(dc, g1.Neg(smul(tc, zhz)))
+
+	f := dc
+	e := sub(mul(u, zwb), r0)
+	vpow := nt.FromInt64(1)
+	for _, o := range []struct {
+		p *pairing.G1Point
+		y *nt.Integer
+	}{{proof.A, ab}, {proof.B, bb}, {proof.C, cb}, {vk.S1, s1b}, {vk.S2, s2b}} {
+		vpow = mul(vpow, v)
+		f = g1.Add(f, smul(o.p, vpow))
+		e = add(e, mul(vpow, o.y))
+	}
+	zetaOmega := mul(zeta, d.omega)
+	lhs := g1.Add(proof.WZeta, smul(proof.WZetaOmega, u))
+	rhs := g1.Add(smul(proof.WZeta, zeta), smul(proof.WZetaOmega, mul(u, zetaOmega)))
+	rhs = g1.Add(rhs, f)
+	rhs = g1.Add(rhs, g1.Neg(smul(g1.Generator(), e)))
+	return c.PairingCheck(
+		[]*pairing.G1Point{lhs, g1.Neg(rhs)},
+		[]*pairing.G2Point{vk.TauG2, vk.G2},
+	)
+}
@@ -0,0 +1,193 @@
+package plonk
+
+import (
+	"testing"
+
+	"github.com/actuallyachraf/algebra/crypto/kzg"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+func ints(xs ...int64) []*nt.Integer {
+	out := make([]*nt.Integer, len(xs))
+	for i := range xs {
+		out[i] = nt.FromInt64(xs[i])
+	}
+	return out
+}
+
+// cubic returns the circuit x^3 + x + 5 = out with the variables
+// (out, x, x^2, x^3, x^3 + x)
+func cubic(r *nt.Integer) *Circuit {
+	cs := NewCircuit(r, 1)
+	x, x2, x3, s := cs.NewVariable(), cs.NewVariable(), cs.NewVariable(), cs.NewVariable()
+	cs.Mul(x, x, x2)
+	cs.Mul(x2, x, x3)
+	cs.Add(x3, x, s)
+	cs.AddConstant(s, nt.FromInt64(5), 0)
+	return cs
+}
+
+func TestDomain(t *testing.T) {
+	r := pairing.BN254().R
+	d, err := newDomain(8, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.element(8).Cmp(nt.One) != 0 || d.element(4).Cmp(nt.One) == 0 {
+		t.Fatal("omega should have order 8")
+	}
+	p := poly.NewPolynomialInts(3, 1, 4, 1, 5, 9, 2, 6)
+	ys := d.evaluate(p)
+	cys := d.cosetEvaluate(p)
+	for i := range ys {
+		if ys[i].Cmp(p.Eval(d.element(i), r)) != 0 {
+			t.Fatal("DFT doesn't evaluate on the subgroup")
+		}
+		if cys[i].Cmp(p.Eval(nt.ModMul(d.g, d.element(i), r), r)) != 0 {
+			t.Fatal("DFT doesn't evaluate on the coset")
+		}
+	}
+	ip, cip := d.interpolate(ys), d.cosetInterpolate(cys)
+	if ip.Compare(&p) != 0 || cip.Compare(&p) != 0 {
+		t.Error("inverse DFT doesn't interpolate")
+	}
+	if _, err := newDomain(6, r); err == nil {
+		t.Error("subgroups of size other than a power of two should be rejected")
+	}
+	if _, err := newDomain(1<<29, r); err == nil {
+		t.Error("BN254 has no subgroup of order 2^29")
+	}
+}
+
+func TestPLONK(t *testing.T) {
+
+	c := pairing.BN254()
+	srs, err := kzg.Setup(c, 32, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := cubic(c.R)
+	w := ints(35, 3, 9, 27, 30)
+	pk, vk, err := Setup(c, srs, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("TestCircuit", func(t *testing.T) {
+		if !cs.IsSatisfied(w) {
+			t.Error("witness should satisfy the circuit")
+		}
+		if cs.IsSatisfied(ints(35, 3, 9, 27, 31)) {
+			t.Error("wrong witness shouldn't satisfy the circuit")
+		}
+		if err := cs.Mul(0, 5, 1); err == nil {
+			t.Error("unknown variables should be rejected")
+		}
+		// sigma permutes the labels k_j omega^i of the wires
+		d, _ := newDomain(vk.N, c.R)
+		k := d.shifts()
+		labels := make(map[string]int)
+		for j := range pk.sigma {
+			for i := range pk.sigma[j] {
+				labels[nt.ModMul(k[j], d.element(i), c.R).String()]++
+				labels[pk.sigma[j][i].String()]--
+			}
+		}
+		for _, count := range labels {
+			if count != 0 {
+				t.Fatal("sigma isn't a permutation of the wires")
+			}
+		}
+	})
+
+	t.Run("TestProve", func(t *testing.T) {
+		proof, err := Prove(c, pk, w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := Verify(c, vk, ints(35), proof)
+		if err != nil || !ok {
+			t.Fatal("proof should verify")
+		}
+		if ok, _ := Verify(c, vk, ints(36), proof); ok {
+			t.Error("proof shouldn't verify for another output")
+		}
+		if _, err := Verify(c, vk, ints(35, 1), proof); err == nil {
+			t.Error("extra public inputs should be rejected")
+		}
+		tampered := *proof
+		tampered.EvalA = nt.Add(proof.EvalA, nt.One)
+		if ok, _ := Verify(c, vk, ints(35), &tampered); ok {
+			t.Error("proof with a wrong evaluation shouldn't verify")
+		}
+		tampered = *proof
+		tampered.Z = c.G1.Add(proof.Z, c.G1.Generator())
+		if ok, _ := Verify(c, vk, ints(35), &tampered); ok {
+			t.Error("proof with a wrong accumulator shouldn't verify")
+		}
+		if _, err := Prove(c, pk, ints(35, 3, 9, 27, 31)); err == nil {
+			t.Error("proving a wrong witness should fail")
+		}
+	})
+
+	t.Run("TestCopyConstraints", func(t *testing.T) {
+		// x^2 = y and y + x = out
+		cs := NewCircuit(c.R, 1)
+		x, y := cs.NewVariable(), cs.NewVariable()
+		cs.Mul(x, x, y)
+		cs.Add(y, x, 0)
+		pk, vk, err := Setup(c, srs, cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := Prove(c, pk, ints(12, 3, 9))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, _ := Verify(c, vk, ints(12), proof); !ok {
+			t.Fatal("proof should verify")
+		}
+		// every gate holds on the rows (12, 12, 12), (3, 3, 9) and
+		// (10, 2, 12) but x and y take different values in each gate
+		wires := [3][]*nt.Integer{ints(12, 3, 10, 0), ints(12, 3, 2, 0), ints(12, 9, 12, 0)}
+		if _, err := prove(c, pk, ints(12), wires); err == nil {
+			t.Error("broken copy constraints should fail to prove")
+		}
+		// same for a gate that doesn't hold
+		wires = [3][]*nt.Integer{ints(12, 3, 9, 0), ints(12, 3, 2, 0), ints(12, 9, 12, 0)}
+		if _, err := prove(c, pk, ints(12), wires); err == nil {
+			t.Error("broken gate should fail to prove")
+		}
+	})
+
+	t.Run("TestUniversalSRS", func(t *testing.T) {
+		// a second circuit x * y = z with two public inputs uses the same SRS
+		cs := NewCircuit(c.R, 2)
+		z := cs.NewVariable()
+		cs.Mul(0, 1, z)
+		cs.AddGate(Gate{A: z, B: z, C: z, QL: nt.FromInt64(1), QC: nt.FromInt64(-42)})
+		pk, vk, err := Setup(c, srs, cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cs.IsSatisfied(ints(6, 7, 42)) {
+			t.Fatal("witness should satisfy the circuit")
+		}
+		proof, err := Prove(c, pk, ints(6, 7, 42))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, _ := Verify(c, vk, ints(6, 7), proof); !ok {
+			t.Error("proof should verify")
+		}
+		if ok, _ := Verify(c, vk, ints(7, 6), proof); ok {
+			t.Error("proof shouldn't verify for swapped inputs")
+		}
+		small, _ := kzg.Setup(c, 8, 2)
+		if _, _, err := Setup(c, small, cubic(c.R)); err == nil {
+			t.Error("SRS too small for the circuit should be rejected")
+		}
+	})
+}
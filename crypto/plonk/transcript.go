@@ -0,0 +1,50 @@
+package plonk
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/pairing"
+)
+
+// transcript derives the verifier challenges with the Fiat-Shamir transform,
+// every message of the prover is absorbed into a running SHA-256 state and a
+// challenge is a 512 bit digest of the state reduced modulo r which is in
+// turn absorbed.
+type transcript struct {
+	c *pairing.Curve
+	h hash.Hash
+}
+
+func newTranscript(c *pairing.Curve, label string) *transcript {
+	t := &transcript{c: c, h: sha256.New()}
+	t.h.Write([]byte(label))
+	return t
+}
+
+func (t *transcript) appendScalar(label string, x *nt.Integer) {
+	t.h.Write([]byte(label))
+	t.h.Write(nt.Mod(x, t.c.R).FillBytes(make([]byte, (t.c.R.BitLen()+7)/8)))
+}
+
+// appendPoint absorbs the uncompressed point p, infinity is all zeros
+func (t *transcript) appendPoint(label string, p *pairing.G1Point) {
+	t.h.Write([]byte(label))
+	if p.Inf {
+		t.h.Write(make([]byte, 2*t.c.FpSize()))
+		return
+	}
+	t.h.Write(t.c.AppendFp(nil, p.X.Big(), p.Y.Big()))
+}
+
+func (t *transcript) challenge(label string) *nt.Integer {
+	t.h.Write([]byte(label))
+	state := t.h.Sum(nil)
+	lo := sha256.Sum256(append(append([]byte{}, state...), 0))
+	hi := sha256.Sum256(append(append([]byte{}, state...), 1))
+	x := new(nt.Integer).SetBytes(append(lo[:], hi[:]...))
+	x = nt.Mod(x, t.c.R)
+	t.appendScalar(label, x)
+	return x
+}
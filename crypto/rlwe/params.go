@@ -0,0 +1,48 @@
+package rlwe
+
+import (
+	"math"
+)
+
+// Params defines the ring R_q = Z_q[x]/(x^n + 1) and the parameter sigma of
+// the discrete gaussian error distribution, q must be a prime with
+// q = 1 mod 2n so that products are computed with the NTT.
+type Params struct {
+	N     int
+	Q     uint64
+	Sigma float64
+}
+
+// Parameter sets in the spirit of Lindner and Peikert, the modulus of each
+// set is the smallest NTT friendly prime used in the literature for its
+// dimension.
+var (
+	// LPR256 decrypts wrongly with probability about 2^-129
+	LPR256 = Params{N: 256, Q: 7681, Sigma: 2.5}
+	// LPR512 decrypts wrongly with probability about 2^-103
+	LPR512 = Params{N: 512, Q: 12289, Sigma: 2.8}
+	// LPR1024 decrypts wrongly with probability about 2^-79
+	LPR1024 = Params{N: 1024, Q: 12289, Sigma: 2.5}
+)
+
+// noiseStdDev returns the standard deviation of a coefficient of the
+// decryption noise e r + e2 - e1 s, which sums 2n products of independent
+// gaussians and one gaussian so its variance is 2n sigma^4 + sigma^2.
+func (p Params) noiseStdDev() float64 {
+	s2 := p.Sigma * p.Sigma
+	return math.Sqrt(2*float64(p.N)*s2*s2 + s2)
+}
+
+// coefficientFailure returns the probability that a coefficient of the
+// noise exceeds q/4 in absolute value, which flips the decrypted bit, the
+// noise is approximated by a gaussian by the central limit theorem.
+func (p Params) coefficientFailure() float64 {
+	return math.Erfc(float64(p.Q) / 4 / (math.Sqrt2 * p.noiseStdDev()))
+}
+
+// FailureProbability estimates the probability that decryption of a
+// message fails, it's the union bound over the n coefficients of the
+// gaussian approximation of the noise.
+func (p Params) FailureProbability() float64 {
+	return math.Min(1, float64(p.N)*p.coefficientFailure())
+}
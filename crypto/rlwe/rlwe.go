@@ -0,0 +1,163 @@
+// Package rlwe implements the Ring-LWE public key encryption scheme of
+// Lyubashevsky, Peikert and Regev over R_q = Z_q[x]/(x^n + 1). A public key
+// is a Ring-LWE sample (a, b = a s + e) and a message of n bits is encoded
+// in the high bit of every coefficient, errors are drawn from the discrete
+// gaussian and products are computed with the word sized NTT.
+// ref : On Ideal Lattices and Learning with Errors Over Rings (Lyubashevsky, Peikert, Regev)
+// ref : Better Key Sizes (and Attacks) for LWE-Based Encryption (Lindner, Peikert)
+package rlwe
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/actuallyachraf/algebra/gauss"
+	"github.com/actuallyachraf/algebra/ntt"
+)
+
+var (
+	errMessage    = errors.New("message must hold exactly n bits")
+	errCiphertext = errors.New("ciphertext doesn't match the parameters")
+)
+
+// Scheme holds the parameters and the NTT tables of the ring
+type Scheme struct {
+	Params
+	ntt *ntt.NTTParams64
+}
+
+// PublicKey is the Ring-LWE sample (a, b = a s + e) stored in NTT form
+type PublicKey struct {
+	A, B []uint64
+}
+
+// PrivateKey is the secret s stored in NTT form
+type PrivateKey struct {
+	S []uint64
+}
+
+// Ciphertext is the pair (u, v) = (a r + e1, b r + e2 + q/2 m) in
+// coefficient form
+type Ciphertext struct {
+	U, V []uint64
+}
+
+// New returns the scheme over the given parameters
+func New(p Params) (*Scheme, error) {
+	t, err := ntt.GenParams64(int64(p.N), p.Q)
+	if err != nil {
+		return nil, err
+	}
+	return &Scheme{Params: p, ntt: t}, nil
+}
+
+// mulMod returns a b mod q
+func mulMod(a, b, q uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, q)
+}
+
+// uniform returns a polynomial with uniform coefficients in [0, q)
+func (s *Scheme) uniform() ([]uint64, error) {
+	q := new(big.Int).SetUint64(s.Q)
+	a := make([]uint64, s.N)
+	for i := range a {
+		x, err := rand.Int(rand.Reader, q)
+		if err != nil {
+			return nil, err
+		}
+		a[i] = x.Uint64()
+	}
+	return a, nil
+}
+
+// gaussian returns a polynomial with coefficients drawn from the discrete
+// gaussian and reduced in [0, q)
+func (s *Scheme) gaussian() []uint64 {
+	q := int64(s.Q)
+	e := make([]uint64, s.N)
+	for i := range e {
+		e[i] = uint64((gauss.Sample(s.Sigma)%q + q) % q)
+	}
+	return e
+}
+
+// ntts returns the NTT of a copy of a
+func (s *Scheme) ntts(a []uint64) []uint64 {
+	b := append([]uint64{}, a...)
+	s.ntt.NTT64(b)
+	return b
+}
+
+// mul returns the pointwise product of a and b
+func (s *Scheme) mul(a, b []uint64) []uint64 {
+	c := make([]uint64, s.N)
+	for i := range c {
+		c[i] = mulMod(a[i], b[i], s.Q)
+	}
+	return c
+}
+
+// add returns a + b
+func (s *Scheme) add(a, b []uint64) []uint64 {
+	c := make([]uint64, s.N)
+	for i := range c {
+		c[i] = (a[i] + b[i]) % s.Q
+	}
+	return c
+}
+
+// GenerateKey samples s, e from the error distribution and a uniformly and
+// returns the keys (a, b = a s + e) and s
+func (s *Scheme) GenerateKey() (*PublicKey, *PrivateKey, error) {
+	a, err := s.uniform()
+	if err != nil {
+		return nil, nil, err
+	}
+	// a uniform polynomial stays uniform in NTT form
+	sk := s.ntts(s.gaussian())
+	e := s.ntts(s.gaussian())
+	b := s.add(s.mul(a, sk), e)
+	return &PublicKey{A: a, B: b}, &PrivateKey{S: sk}, nil
+}
+
+// Encrypt encrypts a message of n bits, bit i of m is bit i%8 of byte i/8
+func (s *Scheme) Encrypt(pk *PublicKey, m []byte) (*Ciphertext, error) {
+	if len(m)*8 != s.N {
+		return nil, errMessage
+	}
+	r := s.ntts(s.gaussian())
+	e1, e2 := s.gaussian(), s.gaussian()
+	u := s.mul(pk.A, r)
+	s.ntt.InvNTT64(u)
+	v := s.mul(pk.B, r)
+	s.ntt.InvNTT64(v)
+	half := s.Q / 2
+	for i := range v {
+		if (m[i/8]>>uint(i%8))&1 == 1 {
+			v[i] = (v[i] + half) % s.Q
+		}
+	}
+	return &Ciphertext{U: s.add(u, e1), V: s.add(v, e2)}, nil
+}
+
+// Decrypt computes v - u s = e r + e2 - e1 s + q/2 m and decodes every
+// coefficient to the closest of 0 and q/2
+func (s *Scheme) Decrypt(sk *PrivateKey, c *Ciphertext) ([]byte, error) {
+	if len(c.U) != s.N || len(c.V) != s.N {
+		return nil, errCiphertext
+	}
+	us := s.mul(s.ntts(c.U), sk.S)
+	s.ntt.InvNTT64(us)
+	m := make([]byte, s.N/8)
+	for i := range us {
+		x := (c.V[i] + s.Q - us[i]%s.Q) % s.Q
+		// x is closer to q/2 than to 0 when it lies in (q/4, 3q/4)
+		if x > s.Q/4 && x < 3*s.Q/4 {
+			m[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return m, nil
+}
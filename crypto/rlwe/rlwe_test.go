@@ -0,0 +1,69 @@
+package rlwe
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math"
+	"testing"
+)
+
+func TestRLWE(t *testing.T) {
+	t.Run("TestEncryptDecrypt", func(t *testing.T) {
+		for _, p := range []Params{LPR256, LPR512, LPR1024} {
+			s, err := New(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pk, sk, err := s.GenerateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 5; i++ {
+				m := make([]byte, p.N/8)
+				rand.Read(m)
+				c, err := s.Encrypt(pk, m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := s.Decrypt(sk, c)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, m) {
+					t.Fatalf("Decrypt(Encrypt(m)) != m for n = %d", p.N)
+				}
+			}
+			if _, err := s.Encrypt(pk, make([]byte, p.N/8+1)); err == nil {
+				t.Error("messages of the wrong size should be rejected")
+			}
+		}
+	})
+	t.Run("TestFailureProbability", func(t *testing.T) {
+		for _, p := range []Params{LPR256, LPR512, LPR1024} {
+			if math.Log2(p.FailureProbability()) > -64 {
+				t.Errorf("parameters for n = %d fail with probability %v", p.N, p.FailureProbability())
+			}
+		}
+		// a wide error distribution fails often enough to be measured, the
+		// empirical rate per coefficient should match the estimate
+		p := Params{N: 256, Q: 7681, Sigma: 6}
+		s, _ := New(p)
+		pk, sk, _ := s.GenerateKey()
+		m := make([]byte, p.N/8)
+		errs, total := 0, 0
+		for i := 0; i < 20; i++ {
+			c, _ := s.Encrypt(pk, m)
+			got, _ := s.Decrypt(sk, c)
+			for _, b := range got {
+				for ; b != 0; b &= b - 1 {
+					errs++
+				}
+			}
+			total += p.N
+		}
+		rate := float64(errs) / float64(total)
+		if est := p.coefficientFailure(); rate < est/2 || rate > 2*est {
+			t.Errorf("measured failure rate %v per coefficient doesn't match the estimate %v", rate, est)
+		}
+	})
+}
@@ -0,0 +1,398 @@
+package ec
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/bf"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// BinaryCurve represents an ordinary (non-supersingular) elliptic curve over GF(2^m)
+// (E): y^2 + xy = x^3 + ax^2 + b with b != 0
+// When a is 0 or 1 and b = 1 the curve is an anomalous binary curve, better known
+// as a Koblitz curve, and the Frobenius map tau(x,y) = (x^2,y^2) can replace
+// doublings in scalar multiplication.
+// ref : Guide To Elliptic Curve Cryptography sections 3.1.2, 3.2.3 and 3.4
+type BinaryCurve struct {
+	A bf.Element
+	B bf.Element
+	F *bf.Field
+}
+
+// BinaryPoint represents an affine point on a binary curve, since b != 0
+// (0,0) is never on the curve and stands for the point at infinity.
+type BinaryPoint struct {
+	X bf.Element
+	Y bf.Element
+}
+
+// LDPoint represents a point in López-Dahab projective coordinates (X:Y:Z)
+// which corresponds to the affine point (X/Z, Y/Z^2), the point at infinity
+// is (1:0:0).
+type LDPoint struct {
+	X bf.Element
+	Y bf.Element
+	Z bf.Element
+}
+
+// NewBinaryCurve creates an instance of a binary elliptic curve
+func NewBinaryCurve(a, b bf.Element, f *bf.Field) *BinaryCurve {
+	return &BinaryCurve{
+		A: a,
+		B: b,
+		F: f,
+	}
+}
+
+// Infinity returns the point at infinity
+func (c *BinaryCurve) Infinity() *BinaryPoint {
+	return &BinaryPoint{X: c.F.Zero(), Y: c.F.Zero()}
+}
+
+// IsInfinity returns true if p is the point at infinity
+func (c *BinaryCurve) IsInfinity(p *BinaryPoint) bool {
+	return c.F.IsZero(p.X) && c.F.IsZero(p.Y)
+}
+
+// Equal checks if two points are equal
+func (c *BinaryCurve) Equal(p, q *BinaryPoint) bool {
+	return c.F.Equal(p.X, q.X) && c.F.Equal(p.Y, q.Y)
+}
+
+// IsOnCurve checks if a given point is on the curve
+func (c *BinaryCurve) IsOnCurve(p *BinaryPoint) bool {
+	f := c.F
+	// y^2 + xy
+	lhs := f.Add(f.Square(p.Y), f.Mul(p.X, p.Y))
+	// x^3 + ax^2 + b
+	x2 := f.Square(p.X)
+	rhs := f.Add(f.Add(f.Mul(x2, p.X), f.Mul(c.A, x2)), c.B)
+	return f.Equal(lhs, rhs)
+}
+
+// IsKoblitz returns true when a is 0 or 1 and b = 1
+func (c *BinaryCurve) IsKoblitz() bool {
+	f := c.F
+	return (f.IsZero(c.A) || f.IsOne(c.A)) && f.IsOne(c.B)
+}
+
+// Neg returns -(x,y) = (x, x+y)
+func (c *BinaryCurve) Neg(p *BinaryPoint) *BinaryPoint {
+	if c.IsInfinity(p) {
+		return p
+	}
+	return &BinaryPoint{X: c.F.Copy(p.X), Y: c.F.Add(p.X, p.Y)}
+}
+
+// Add computes the sum of two points using the affine formulas :
+// P + Q : lambda = (y1+y2)/(x1+x2), x3 = lambda^2 + lambda + x1 + x2 + a
+// y3 = lambda(x1+x3) + x3 + y1
+func (c *BinaryCurve) Add(p, q *BinaryPoint) *BinaryPoint {
+	f := c.F
+	if c.IsInfinity(p) {
+		return q
+	}
+	if c.IsInfinity(q) {
+		return p
+	}
+	if f.Equal(p.X, q.X) {
+		if f.Equal(p.Y, q.Y) {
+			return c.Double(p)
+		}
+		// q = -p
+		return c.Infinity()
+	}
+	lambda, _ := f.Div(f.Add(p.Y, q.Y), f.Add(p.X, q.X))
+	x3 := f.Add(f.Add(f.Add(f.Add(f.Square(lambda), lambda), p.X), q.X), c.A)
+	y3 := f.Add(f.Add(f.Mul(lambda, f.Add(p.X, x3)), x3), p.Y)
+	return &BinaryPoint{X: x3, Y: y3}
+}
+
+// Double computes 2P using the affine formulas :
+// lambda = x1 + y1/x1, x3 = lambda^2 + lambda + a, y3 = x1^2 + lambda x3 + x3
+func (c *BinaryCurve) Double(p *BinaryPoint) *BinaryPoint {
+	f := c.F
+	// points with x = 0 have order 2
+	if c.IsInfinity(p) || f.IsZero(p.X) {
+		return c.Infinity()
+	}
+	t, _ := f.Div(p.Y, p.X)
+	lambda := f.Add(p.X, t)
+	x3 := f.Add(f.Add(f.Square(lambda), lambda), c.A)
+	y3 := f.Add(f.Add(f.Square(p.X), f.Mul(lambda, x3)), x3)
+	return &BinaryPoint{X: x3, Y: y3}
+}
+
+// ScalarMulAffine computes kP using affine double and add, negative scalars
+// multiply the opposite point.
+func (c *BinaryCurve) ScalarMulAffine(p *BinaryPoint, k *nt.Integer) *BinaryPoint {
+	if k.Sign() < 0 {
+		return c.ScalarMulAffine(c.Neg(p), new(nt.Integer).Neg(k))
+	}
+	q := c.Infinity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		q = c.Double(q)
+		if k.Bit(i) == 1 {
+			q = c.Add(q, p)
+		}
+	}
+	return q
+}
+
+// ScalarMul computes kP using double and add in López-Dahab coordinates
+// which trades the inversion of every affine step for a few multiplications.
+func (c *BinaryCurve) ScalarMul(p *BinaryPoint, k *nt.Integer) *BinaryPoint {
+	if k.Sign() < 0 {
+		return c.ScalarMul(c.Neg(p), new(nt.Integer).Neg(k))
+	}
+	q := c.ToLD(c.Infinity())
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		q = c.DoubleLD(q)
+		if k.Bit(i) == 1 {
+			q = c.AddMixedLD(q, p)
+		}
+	}
+	return c.FromLD(q)
+}
+
+// ToLD maps an affine point to López-Dahab coordinates (x:y:1)
+func (c *BinaryCurve) ToLD(p *BinaryPoint) *LDPoint {
+	f := c.F
+	if c.IsInfinity(p) {
+		return &LDPoint{X: f.One(), Y: f.Zero(), Z: f.Zero()}
+	}
+	return &LDPoint{X: f.Copy(p.X), Y: f.Copy(p.Y), Z: f.One()}
+}
+
+// FromLD maps a López-Dahab point back to affine coordinates (X/Z, Y/Z^2)
+func (c *BinaryCurve) FromLD(p *LDPoint) *BinaryPoint {
+	f := c.F
+	if f.IsZero(p.Z) {
+		return c.Infinity()
+	}
+	zInv, _ := f.Inv(p.Z)
+	return &BinaryPoint{X: f.Mul(p.X, zInv), Y: f.Mul(p.Y, f.Square(zInv))}
+}
+
+// DoubleLD computes 2P in López-Dahab coordinates :
+// Z3 = X1^2 Z1^2, X3 = X1^4 + b Z1^4, Y3 = b Z1^4 Z3 + X3 (a Z3 + Y1^2 + b Z1^4)
+func (c *BinaryCurve) DoubleLD(p *LDPoint) *LDPoint {
+	f := c.F
+	if f.IsZero(p.Z) || f.IsZero(p.X) {
+		return &LDPoint{X: f.One(), Y: f.Zero(), Z: f.Zero()}
+	}
+	x2 := f.Square(p.X)
+	z2 := f.Square(p.Z)
+	bz4 := f.Mul(c.B, f.Square(z2))
+	z3 := f.Mul(x2, z2)
+	x3 := f.Add(f.Square(x2), bz4)
+	y3 := f.Add(f.Mul(bz4, z3), f.Mul(x3, f.Add(f.Add(f.Mul(c.A, z3), f.Square(p.Y)), bz4)))
+	return &LDPoint{X: x3, Y: y3, Z: z3}
+}
+
+// AddMixedLD computes P + Q where P is in López-Dahab coordinates and Q is
+// affine (Guide To ECC algorithm 3.25 generalized to any a).
+func (c *BinaryCurve) AddMixedLD(p *LDPoint, q *BinaryPoint) *LDPoint {
+	f := c.F
+	if c.IsInfinity(q) {
+		return p
+	}
+	if f.IsZero(p.Z) {
+		return c.ToLD(q)
+	}
+	t1 := f.Mul(p.Z, q.X)
+	t2 := f.Square(p.Z)
+	x3 := f.Add(p.X, t1)
+	t1 = f.Mul(p.Z, x3)
+	t3 := f.Mul(t2, q.Y)
+	y3 := f.Add(p.Y, t3)
+	if f.IsZero(x3) {
+		if f.IsZero(y3) {
+			return c.DoubleLD(c.ToLD(q))
+		}
+		return &LDPoint{X: f.One(), Y: f.Zero(), Z: f.Zero()}
+	}
+	z3 := f.Square(t1)
+	t3 = f.Mul(t1, y3)
+	t1 = f.Add(t1, f.Mul(c.A, t2))
+	t2 = f.Square(x3)
+	x3 = f.Mul(t2, t1)
+	t2 = f.Square(y3)
+	x3 = f.Add(f.Add(x3, t2), t3)
+	t2 = f.Add(f.Mul(q.X, z3), x3)
+	t1 = f.Square(z3)
+	t3 = f.Add(t3, z3)
+	y3 = f.Mul(t3, t2)
+	t2 = f.Add(q.X, q.Y)
+	t3 = f.Mul(t1, t2)
+	y3 = f.Add(y3, t3)
+	return &LDPoint{X: x3, Y: y3, Z: z3}
+}
+
+// Tau computes the Frobenius endomorphism tau(x,y) = (x^2,y^2) which
+// satisfies tau^2 - mu*tau + 2 = 0 on Koblitz curves.
+func (c *BinaryCurve) Tau(p *BinaryPoint) *BinaryPoint {
+	if c.IsInfinity(p) {
+		return p
+	}
+	return &BinaryPoint{X: c.F.Square(p.X), Y: c.F.Square(p.Y)}
+}
+
+// mu returns (-1)^(1-a)
+func (c *BinaryCurve) mu() int64 {
+	if c.F.IsOne(c.A) {
+		return 1
+	}
+	return -1
+}
+
+// ScalarMulTNAF computes kP on a Koblitz curve using the tau-adic NAF of k
+// reduced modulo delta = (tau^m - 1)/(tau - 1) (Guide To ECC algorithm 3.70),
+// P must be in the main subgroup where delta acts as zero.
+func (c *BinaryCurve) ScalarMulTNAF(p *BinaryPoint, k *nt.Integer) (*BinaryPoint, error) {
+	if !c.IsKoblitz() {
+		return nil, errors.New("tau-adic expansions require a Koblitz curve")
+	}
+	mu := c.mu()
+	r0, r1 := reduceModDelta(k, c.F.Degree(), mu)
+	digits := TNAF(r0, r1, mu)
+
+	negP := c.Neg(p)
+	q := c.Infinity()
+	for i := len(digits) - 1; i >= 0; i-- {
+		q = c.Tau(q)
+		switch digits[i] {
+		case 1:
+			q = c.Add(q, p)
+		case -1:
+			q = c.Add(q, negP)
+		}
+	}
+	return q, nil
+}
+
+// TNAF computes the tau-adic non adjacent form of r0 + r1*tau (Guide To ECC
+// algorithm 3.61), digits are in {-1,0,1} least significant first.
+func TNAF(r0, r1 *nt.Integer, mu int64) []int8 {
+	a := new(big.Int).Set(r0)
+	b := new(big.Int).Set(r1)
+	four := nt.FromInt64(4)
+	var digits []int8
+	for a.Sign() != 0 || b.Sign() != 0 {
+		var u int64
+		if a.Bit(0) == 1 {
+			// u = 2 - ((r0 - 2r1) mod 4)
+			t := nt.Mod(nt.Sub(a, nt.Mul(nt.FromInt64(2), b)), four)
+			u = 2 - t.Int64()
+			a.Sub(a, nt.FromInt64(u))
+		}
+		digits = append(digits, int8(u))
+		// (r0, r1) = (r1 + mu*r0/2, -r0/2)
+		half := new(big.Int).Rsh(a, 1)
+		if a.Sign() < 0 {
+			half = new(big.Int).Quo(a, nt.FromInt64(2))
+		}
+		a = nt.Add(b, nt.Mul(nt.FromInt64(mu), half))
+		b = new(big.Int).Neg(half)
+	}
+	return digits
+}
+
+// zTau elements of the ring Z[tau] as a + b*tau
+type zTau struct {
+	a *nt.Integer
+	b *nt.Integer
+}
+
+// mul computes (a + b tau)(c + d tau) = (ac - 2bd) + (ad + bc + mu bd) tau
+func (x zTau) mul(y zTau, mu int64) zTau {
+	bd := nt.Mul(x.b, y.b)
+	return zTau{
+		a: nt.Sub(nt.Mul(x.a, y.a), nt.Mul(nt.FromInt64(2), bd)),
+		b: nt.Add(nt.Add(nt.Mul(x.a, y.b), nt.Mul(x.b, y.a)), nt.Mul(nt.FromInt64(mu), bd)),
+	}
+}
+
+// conj returns the conjugate (a + mu b) - b tau
+func (x zTau) conj(mu int64) zTau {
+	return zTau{a: nt.Add(x.a, nt.Mul(nt.FromInt64(mu), x.b)), b: new(big.Int).Neg(x.b)}
+}
+
+// norm returns N(a + b tau) = a^2 + mu ab + 2b^2
+func (x zTau) norm(mu int64) *nt.Integer {
+	n := nt.Add(nt.Mul(x.a, x.a), nt.Mul(nt.FromInt64(mu), nt.Mul(x.a, x.b)))
+	return nt.Add(n, nt.Mul(nt.FromInt64(2), nt.Mul(x.b, x.b)))
+}
+
+// delta returns (tau^m - 1)/(tau - 1) in Z[tau]
+func delta(m int, mu int64) zTau {
+	t := zTau{a: nt.FromInt64(1), b: nt.FromInt64(0)}
+	tau := zTau{a: nt.FromInt64(0), b: nt.FromInt64(1)}
+	for i := 0; i < m; i++ {
+		t = t.mul(tau, mu)
+	}
+	t.a = nt.Sub(t.a, nt.One)
+	// exact division by d = tau - 1 : x/d = x*conj(d)/N(d)
+	d := zTau{a: nt.FromInt64(-1), b: nt.FromInt64(1)}
+	q := t.mul(d.conj(mu), mu)
+	n := d.norm(mu)
+	return zTau{a: new(big.Int).Quo(q.a, n), b: new(big.Int).Quo(q.b, n)}
+}
+
+// reduceModDelta returns r = k mod delta, the quotient k/delta is rounded
+// to a nearby element of Z[tau] (Guide To ECC algorithms 3.62 and 3.63).
+func reduceModDelta(k *nt.Integer, m int, mu int64) (*nt.Integer, *nt.Integer) {
+	d := delta(m, mu)
+	n := d.norm(mu)
+	dc := d.conj(mu)
+	// lambda = k*conj(delta)/N(delta)
+	l0 := new(big.Rat).SetFrac(nt.Mul(k, dc.a), n)
+	l1 := new(big.Rat).SetFrac(nt.Mul(k, dc.b), n)
+	q := roundZTau(l0, l1, mu)
+	qd := q.mul(d, mu)
+	return nt.Sub(k, qd.a), new(big.Int).Neg(qd.b)
+}
+
+// roundZTau rounds lambda0 + lambda1*tau to a nearby element of Z[tau]
+// (Guide To ECC algorithm 3.63).
+func roundZTau(l0, l1 *big.Rat, mu int64) zTau {
+	round := func(x *big.Rat) *nt.Integer {
+		// floor(x + 1/2)
+		t := new(big.Rat).Add(x, big.NewRat(1, 2))
+		f := new(big.Int).Div(t.Num(), t.Denom())
+		return f
+	}
+	rat := func(n int64) *big.Rat { return big.NewRat(n, 1) }
+	f0, f1 := round(l0), round(l1)
+	e0 := new(big.Rat).Sub(l0, new(big.Rat).SetInt(f0))
+	e1 := new(big.Rat).Sub(l1, new(big.Rat).SetInt(f1))
+	muE1 := new(big.Rat).Mul(rat(mu), e1)
+
+	var h0, h1 int64
+	// eta = 2 eta0 + mu eta1
+	eta := new(big.Rat).Add(new(big.Rat).Mul(rat(2), e0), muE1)
+	// eta0 - 3 mu eta1 and eta0 + 4 mu eta1
+	minus3 := new(big.Rat).Sub(e0, new(big.Rat).Mul(rat(3), muE1))
+	plus4 := new(big.Rat).Add(e0, new(big.Rat).Mul(rat(4), muE1))
+
+	if eta.Cmp(rat(1)) >= 0 {
+		if minus3.Cmp(rat(-1)) < 0 {
+			h1 = mu
+		} else {
+			h0 = 1
+		}
+	} else if plus4.Cmp(rat(2)) >= 0 {
+		h1 = mu
+	}
+	if eta.Cmp(rat(-1)) < 0 {
+		if minus3.Cmp(rat(1)) >= 0 {
+			h1 = -mu
+		} else {
+			h0 = -1
+		}
+	} else if plus4.Cmp(rat(-2)) < 0 {
+		h1 = -mu
+	}
+	return zTau{a: nt.Add(f0, nt.FromInt64(h0)), b: nt.Add(f1, nt.FromInt64(h1))}
+}
@@ -0,0 +1,168 @@
+package ec
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/actuallyachraf/algebra/bf"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// BinaryDomainParams represents the public parameters of a binary curve group :
+// the curve over GF(2^m), a base point G of prime order N and the cofactor H.
+type BinaryDomainParams struct {
+	Name  string
+	Curve *BinaryCurve
+	G     *BinaryPoint
+	N     *nt.Integer
+	H     *nt.Integer
+}
+
+// binaryCurveSpec holds the hex encoded parameters from SEC 2 and FIPS 186-4 D.1.3
+type binaryCurveSpec struct {
+	name   string
+	secg   string
+	exps   []int
+	a, b   string
+	gx, gy string
+	n      string
+	h      int64
+}
+
+var binaryCurveSpecs = []binaryCurveSpec{
+	{
+		name: "K-163", secg: "sect163k1", exps: bf.NISTPoly163,
+		a:  "1",
+		b:  "1",
+		gx: "02FE13C0537BBC11ACAA07D793DE4E6D5E5C94EEE8",
+		gy: "0289070FB05D38FF58321F2E800536D538CCDAA3D9",
+		n:  "04000000000000000000020108A2E0CC0D99F8A5EF",
+		h:  2,
+	},
+	{
+		name: "B-163", secg: "sect163r2", exps: bf.NISTPoly163,
+		a:  "1",
+		b:  "020A601907B8C953CA1481EB10512F78744A3205FD",
+		gx: "03F0EBA16286A2D57EA0991168D4994637E8343E36",
+		gy: "00D51FBC6C71A0094FA2CDD545B11C5C0C797324F1",
+		n:  "040000000000000000000292FE77E70C12A4234C33",
+		h:  2,
+	},
+	{
+		name: "K-233", secg: "sect233k1", exps: bf.NISTPoly233,
+		a:  "0",
+		b:  "1",
+		gx: "017232BA853A7E731AF129F22FF4149563A419C26BF50A4C9D6EEFAD6126",
+		gy: "01DB537DECE819B7F70F555A67C427A8CD9BF18AEB9B56E0C11056FAE6A3",
+		n:  "8000000000000000000000000000069D5BB915BCD46EFB1AD5F173ABDF",
+		h:  4,
+	},
+	{
+		name: "B-233", secg: "sect233r1", exps: bf.NISTPoly233,
+		a:  "1",
+		b:  "0066647EDE6C332C7F8C0923BB58213B333B20E9CE4281FE115F7D8F90AD",
+		gx: "00FAC9DFCBAC8313BB2139F1BB755FEF65BC391F8B36F8F8EB7371FD558B",
+		gy: "01006A08A41903350678E58528BEBF8A0BEFF867A7CA36716F7E01F81052",
+		n:  "01000000000000000000000000000013E974E72F8A6922031D2603CFE0D7",
+		h:  2,
+	},
+	{
+		name: "K-283", secg: "sect283k1", exps: bf.NISTPoly283,
+		a:  "0",
+		b:  "1",
+		gx: "0503213F78CA44883F1A3B8162F188E553CD265F23C1567A16876913B0C2AC2458492836",
+		gy: "01CCDA380F1C9E318D90F95D07E5426FE87E45C0E8184698E45962364E34116177DD2259",
+		n:  "01FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE9AE2ED07577265DFF7F94451E061E163C61",
+		h:  4,
+	},
+	{
+		name: "B-283", secg: "sect283r1", exps: bf.NISTPoly283,
+		a:  "1",
+		b:  "027B680AC8B8596DA5A4AF8A19A0303FCA97FD7645309FA2A581485AF6263E313B79A2F5",
+		gx: "05F939258DB7DD90E1934F8C70B0DFEC2EED25B8557EAC9C80E2E198F8CDBECD86B12053",
+		gy: "03676854FE24141CB98FE6D4B20D02B4516FF702350EDDB0826779C813F0DF45BE8112F4",
+		n:  "03FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEF90399660FC938A90165B042A7CEFADB307",
+		h:  2,
+	},
+	{
+		name: "K-409", secg: "sect409k1", exps: bf.NISTPoly409,
+		a:  "0",
+		b:  "1",
+		gx: "0060F05F658F49C1AD3AB1890F7184210EFD0987E307C84C27ACCFB8F9F67CC2C460189EB5AAAA62EE222EB1B35540CFE9023746",
+		gy: "01E369050B7C4E42ACBA1DACBF04299C3460782F918EA427E6325165E9EA10E3DA5F6C42E9C55215AA9CA27A5863EC48D8E0286B",
+		n:  "7FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE5F83B2D4EA20400EC4557D5ED3E3E7CA5B4B5C83B8E01E5FCF",
+		h:  4,
+	},
+	{
+		name: "B-409", secg: "sect409r1", exps: bf.NISTPoly409,
+		a:  "1",
+		b:  "0021A5C2C8EE9FEB5C4B9A753B7B476B7FD6422EF1F3DD674761FA99D6AC27C8A9A197B272822F6CD57A55AA4F50AE317B13545F",
+		gx: "015D4860D088DDB3496B0C6064756260441CDE4AF1771D4DB01FFE5B34E59703DC255A868A1180515603AEAB60794E54BB7996A7",
+		gy: "0061B1CFAB6BE5F32BBFA78324ED106A7636B9C5A7BD198D0158AA4F5488D08F38514F1FDF4B4F40D2181B3681C364BA0273C706",
+		n:  "010000000000000000000000000000000000000000000000000001E2AAD6A612F33307BE5FA47C3C9E052F838164CD37D9A21173",
+		h:  2,
+	},
+	{
+		name: "K-571", secg: "sect571k1", exps: bf.NISTPoly571,
+		a:  "0",
+		b:  "1",
+		gx: "026EB7A859923FBC82189631F8103FE4AC9CA2970012D5D46024804801841CA44370958493B205E647DA304DB4CEB08CBBD1BA39494776FB988B47174DCA88C7E2945283A01C8972",
+		gy: "0349DC807F4FBF374F4AEADE3BCA95314DD58CEC9F307A54FFC61EFC006D8A2C9D4979C0AC44AEA74FBEBBB9F772AEDCB620B01A7BA7AF1B320430C8591984F601CD4C143EF1C7A3",
+		n:  "020000000000000000000000000000000000000000000000000000000000000000000000131850E1F19A63E4B391A8DB917F4138B630D84BE5D639381E91DEB45CFE778F637C1001",
+		h:  4,
+	},
+	{
+		name: "B-571", secg: "sect571r1", exps: bf.NISTPoly571,
+		a:  "1",
+		b:  "02F40E7E2221F295DE297117B7F3D62F5C6A97FFCB8CEFF1CD6BA8CE4A9A18AD84FFABBD8EFA59332BE7AD6756A66E294AFD185A78FF12AA520E4DE739BACA0C7FFEFF7F2955727A",
+		gx: "0303001D34B856296C16C0D40D3CD7750A93D1D2955FA80AA5F40FC8DB7B2ABDBDE53950F4C0D293CDD711A35B67FB1499AE60038614F1394ABFA3B4C850D927E1E7769C8EEC2D19",
+		gy: "037BF27342DA639B6DCCFFFEB73D69D78C6C27A6009CBBCA1980F8533921E8A684423E43BAB08A576291AF8F461BB2A8B3531D2F0485C19B16E2F1516E23DD3C1A4827AF1B8AC15B",
+		n:  "03FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE661CE18FF55987308059B186823851EC7DD9CA1161DE93D5174D66E8382E9BB2FE84E47",
+		h:  2,
+	},
+}
+
+// NamedBinaryCurve returns the parameters of a standard binary curve given
+// its NIST name (K-233, B-283...) or SEC 2 name (sect233k1, sect283r1...).
+func NamedBinaryCurve(name string) (*BinaryDomainParams, error) {
+	for _, spec := range binaryCurveSpecs {
+		if strings.EqualFold(name, spec.name) || strings.EqualFold(name, spec.secg) {
+			return spec.params()
+		}
+	}
+	return nil, errors.New("unknown binary curve " + name)
+}
+
+// BinaryCurveNames returns the NIST names of the available binary curves
+func BinaryCurveNames() []string {
+	names := make([]string, len(binaryCurveSpecs))
+	for i, spec := range binaryCurveSpecs {
+		names[i] = spec.name
+	}
+	return names
+}
+
+func (spec binaryCurveSpec) params() (*BinaryDomainParams, error) {
+	field, err := bf.NewField(spec.exps...)
+	if err != nil {
+		return nil, err
+	}
+	var elems [4]bf.Element
+	for i, s := range []string{spec.a, spec.b, spec.gx, spec.gy} {
+		elems[i], err = field.NewElementFromHex(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	n, ok := new(nt.Integer).SetString(spec.n, 16)
+	if !ok {
+		return nil, errors.New("invalid subgroup order")
+	}
+	return &BinaryDomainParams{
+		Name:  spec.name,
+		Curve: NewBinaryCurve(elems[0], elems[1], field),
+		G:     &BinaryPoint{X: elems[2], Y: elems[3]},
+		N:     n,
+		H:     nt.FromInt64(spec.h),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package ec
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+func TestBinaryCurve(t *testing.T) {
+
+	t.Run("TestNamedCurves", func(t *testing.T) {
+		for _, name := range BinaryCurveNames() {
+			params, err := NamedBinaryCurve(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := params.Curve
+			if !c.IsOnCurve(params.G) {
+				t.Error("generator of", name, "isn't on the curve")
+			}
+			if !c.IsInfinity(c.ScalarMul(params.G, params.N)) {
+				t.Error("nG != O for", name)
+			}
+			if c.IsKoblitz() != (name[0] == 'K') {
+				t.Error("IsKoblitz failed for", name)
+			}
+		}
+		if _, err := NamedBinaryCurve("sect233k1"); err != nil {
+			t.Error("SEC 2 names should be accepted")
+		}
+	})
+
+	t.Run("TestKnownAnswer", func(t *testing.T) {
+		// private and public keys generated with OpenSSL
+		testCases := []struct {
+			name string
+			k    string
+			pub  string
+		}{
+			{"K-163", "02355c365812d59949e0c221bd711d3e1bee68c67b",
+				"051e060644f254787d2fe00d2500678a017e83601103be36b2bfb2f5b56333faae13aa08e1e560f70bd9"},
+			{"K-233", "7c6255a05e17cec4897ba1ae86fa2b69d1b4706cde0d00d29537b91189",
+				"0191923b2aaf167a11a3628a81386cc5f8166fc84042c44f6863557ffd2e0162eb6b4de9c1e02a198e38509192c5a077e42dbfef0946e754ba2e3c33"},
+			{"B-283", "02069e43795570dde4451a8e78a5a8562e717a0c0dc6ddac2a9eac17a6acfb9bdf89f533",
+				"0736e44e460dbfe739c65d58ee2a50e1376b30ab1053c0197bd8f2d137a0adc8bad2ec0c054a7b93cd6006c4aafa39dff677d692524816ae3a7acc4c85a888ba2efb0a6325948c01"},
+		}
+		for _, tc := range testCases {
+			params, _ := NamedBinaryCurve(tc.name)
+			c := params.Curve
+			k, _ := new(nt.Integer).SetString(tc.k, 16)
+			x, _ := c.F.NewElementFromHex(tc.pub[:len(tc.pub)/2])
+			y, _ := c.F.NewElementFromHex(tc.pub[len(tc.pub)/2:])
+			expected := &BinaryPoint{X: x, Y: y}
+
+			if actual := c.ScalarMul(params.G, k); !c.Equal(actual, expected) {
+				t.Error("López-Dahab scalar multiplication failed for", tc.name)
+			}
+			if actual := c.ScalarMulAffine(params.G, k); !c.Equal(actual, expected) {
+				t.Error("affine scalar multiplication failed for", tc.name)
+			}
+			if c.IsKoblitz() {
+				actual, err := c.ScalarMulTNAF(params.G, k)
+				if err != nil || !c.Equal(actual, expected) {
+					t.Error("tau-adic scalar multiplication failed for", tc.name)
+				}
+			}
+		}
+	})
+
+	t.Run("TestGroupLaw", func(t *testing.T) {
+		params, _ := NamedBinaryCurve("B-163")
+		c := params.Curve
+		P := c.ScalarMul(params.G, nt.FromInt64(12345))
+		Q := c.ScalarMul(params.G, nt.FromInt64(6789))
+		if !c.IsOnCurve(P) || !c.IsOnCurve(Q) {
+			t.Fatal("points aren't on the curve")
+		}
+		if !c.Equal(c.Add(P, Q), c.ScalarMul(params.G, nt.FromInt64(12345+6789))) {
+			t.Error("kG + lG != (k+l)G")
+		}
+		if !c.IsInfinity(c.Add(P, c.Neg(P))) {
+			t.Error("P + (-P) != O")
+		}
+		k := nt.FromInt64(-12345)
+		if !c.Equal(c.ScalarMul(params.G, k), c.Neg(P)) || !c.Equal(c.ScalarMulAffine(params.G, k), c.Neg(P)) {
+			t.Error("(-k)G != -(kG)")
+		}
+		koblitz, _ := NamedBinaryCurve("K-163")
+		kc := koblitz.Curve
+		expected := kc.Neg(kc.ScalarMul(koblitz.G, nt.FromInt64(12345)))
+		if R, _ := kc.ScalarMulTNAF(koblitz.G, k); !kc.Equal(R, expected) {
+			t.Error("tau-adic (-k)G != -(kG)")
+		}
+		if !c.Equal(c.Double(P), c.FromLD(c.DoubleLD(c.ToLD(P)))) {
+			t.Error("affine and López-Dahab doubling disagree")
+		}
+		if !c.Equal(c.Add(P, Q), c.FromLD(c.AddMixedLD(c.ToLD(P), Q))) {
+			t.Error("affine and López-Dahab addition disagree")
+		}
+	})
+
+	t.Run("TestTNAF", func(t *testing.T) {
+		for _, name := range []string{"K-163", "K-233", "K-283"} {
+			params, _ := NamedBinaryCurve(name)
+			c := params.Curve
+			mu := c.mu()
+			k, _ := rand.Int(rand.Reader, params.N)
+
+			r0, r1 := reduceModDelta(k, c.F.Degree(), mu)
+			digits := TNAF(r0, r1, mu)
+			// the reduced expansion is about as long as the field degree
+			if len(digits) > c.F.Degree()+4 {
+				t.Error("tau-adic NAF is too long", len(digits))
+			}
+			// no two consecutive non zero digits and sum u_i tau^i = r0 + r1 tau
+			acc := zTau{a: nt.FromInt64(0), b: nt.FromInt64(0)}
+			tau := zTau{a: nt.FromInt64(0), b: nt.FromInt64(1)}
+			for i := len(digits) - 1; i >= 0; i-- {
+				if i > 0 && digits[i] != 0 && digits[i-1] != 0 {
+					t.Error("adjacent non zero digits")
+				}
+				acc = acc.mul(tau, mu)
+				acc.a = nt.Add(acc.a, nt.FromInt64(int64(digits[i])))
+			}
+			if acc.a.Cmp(r0) != 0 || acc.b.Cmp(r1) != 0 {
+				t.Error("tau-adic NAF doesn't represent its input")
+			}
+			expected := c.ScalarMul(params.G, k)
+			actual, _ := c.ScalarMulTNAF(params.G, k)
+			if !c.Equal(actual, expected) {
+				t.Error("ScalarMulTNAF disagrees with ScalarMul on", name)
+			}
+		}
+		params, _ := NamedBinaryCurve("B-233")
+		if _, err := params.Curve.ScalarMulTNAF(params.G, nt.One); err == nil {
+			t.Error("tau-adic multiplication on a random curve should fail")
+		}
+	})
+}
@@ -15,7 +15,7 @@ import (
 // There are two separate cases :
 // (E) is defined over a field K with characteristic different than 2 and 3
 // (E) is defined over a field K with characteristic 2 or 3
-// We treat the first case for now .
+// Curve treats the first case, WeierstrassCurve handles the general equation.
 // The change of variable used is :
 // Phi : (X,Y) -> ((x-3a1^2-12a2)/36,((y-3a1x)/216)-((a1^3 + 4a1a2 - 12a3)/24)
 // Applying Phi to (E) gives us the simplified Weirstreass equation :
@@ -0,0 +1,264 @@
+package ec
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+const (
+	// movDegreeBound is the largest embedding degree we check for the
+	// MOV/Frey-Ruck reduction, SEC 1 recommends 100.
+	movDegreeBound = 100
+	// twistSecurityBits is the minimal security level in bits the quadratic
+	// twist must offer against Pollard's rho, rho costs about sqrt(q) so the
+	// largest prime factor of the twist order must be twice as large.
+	twistSecurityBits = 100
+)
+
+// DomainParams represents the public parameters of an elliptic curve group
+// as they are usually distributed (SEC 1 section 3.1.1) :
+// the curve (E) over Fp, a base point G of prime order N and the cofactor H
+// such that #E(Fp) = H*N.
+// H can be left nil in which case it's derived from Hasse's theorem.
+type DomainParams struct {
+	Curve *Curve
+	G     *Point
+	N     *nt.Integer
+	H     *nt.Integer
+}
+
+// Check identifies a single validation routine.
+type Check int
+
+// The checks run by Validate in the order they are run.
+const (
+	CheckDiscriminant Check = iota
+	CheckFieldPrime
+	CheckOrderPrime
+	CheckGeneratorOnCurve
+	CheckGeneratorOrder
+	CheckCofactor
+	CheckEmbeddingDegree
+	CheckAnomalous
+	CheckTwist
+)
+
+// String implements stringer
+func (c Check) String() string {
+	switch c {
+	case CheckDiscriminant:
+		return "discriminant"
+	case CheckFieldPrime:
+		return "field-prime"
+	case CheckOrderPrime:
+		return "order-prime"
+	case CheckGeneratorOnCurve:
+		return "generator-on-curve"
+	case CheckGeneratorOrder:
+		return "generator-order"
+	case CheckCofactor:
+		return "cofactor"
+	case CheckEmbeddingDegree:
+		return "embedding-degree"
+	case CheckAnomalous:
+		return "anomalous"
+	case CheckTwist:
+		return "twist-security"
+	}
+	return "unknown"
+}
+
+// CheckResult is the outcome of a single check, Reason explains a failure.
+type CheckResult struct {
+	Check  Check
+	Passed bool
+	Reason string
+}
+
+// ValidationReport collects the outcome of every check run on the parameters.
+// Some values computed along the way are kept since they're useful on their own.
+type ValidationReport struct {
+	Results []CheckResult
+	// Order is the number of points on the curve #E(Fp) = H*N
+	Order *nt.Integer
+	// EmbeddingDegree is the smallest k such that N | p^k - 1 or 0
+	// when k is larger than the checked bound.
+	EmbeddingDegree int
+	// TwistOrder is the number of points on the quadratic twist 2p+2-#E(Fp)
+	TwistOrder *nt.Integer
+}
+
+// OK returns true when every check passed.
+func (r *ValidationReport) OK() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the checks that didn't pass.
+func (r *ValidationReport) Failed() []CheckResult {
+	var failed []CheckResult
+	for _, res := range r.Results {
+		if !res.Passed {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Passed returns whether a given check passed, checks that weren't run
+// are considered failed.
+func (r *ValidationReport) Passed(c Check) bool {
+	for _, res := range r.Results {
+		if res.Check == c {
+			return res.Passed
+		}
+	}
+	return false
+}
+
+// String implements stringer
+func (r *ValidationReport) String() string {
+	s := ""
+	for _, res := range r.Results {
+		if res.Passed {
+			s += fmt.Sprintf("%s: ok\n", res.Check)
+		} else {
+			s += fmt.Sprintf("%s: failed (%s)\n", res.Check, res.Reason)
+		}
+	}
+	return s
+}
+
+func (r *ValidationReport) add(c Check, passed bool, reason string) {
+	if passed {
+		reason = ""
+	}
+	r.Results = append(r.Results, CheckResult{Check: c, Passed: passed, Reason: reason})
+}
+
+// Discriminant returns the discriminant of the curve -16(4a^3+27b^2) mod p
+// the curve is singular (not an elliptic curve) when it's zero.
+func (c *Curve) Discriminant() *nt.Integer {
+	field := c.F
+	aCubed := c.A.Exp(nt.FromInt64(3))
+	bSquared := c.B.Square()
+	d := field.Add(field.Mul(field.NewFieldElementFromInt64(4), aCubed), field.Mul(field.NewFieldElementFromInt64(27), bSquared))
+	d = field.Mul(field.NewFieldElementFromInt64(-16), d)
+	return d.Big()
+}
+
+// IsSingular returns true if the curve has a singular point i.e 4a^3+27b^2 = 0
+func (c *Curve) IsSingular() bool {
+	return c.Discriminant().Sign() == 0
+}
+
+// Validate runs the standard security checks on user supplied domain parameters
+// (SEC 1 section 3.1.1.2.1 and https://safecurves.cr.yp.to).
+// Every check is run and recorded even when a previous one failed, checks
+// that depend on a failed one are reported as failed with the reason.
+func Validate(params *DomainParams) *ValidationReport {
+
+	report := new(ValidationReport)
+
+	if params == nil || params.Curve == nil || params.G == nil || params.N == nil {
+		for c := CheckDiscriminant; c <= CheckTwist; c++ {
+			report.add(c, false, "missing domain parameters")
+		}
+		return report
+	}
+
+	curve := params.Curve
+	p := curve.F.Modulus()
+	n := params.N
+
+	// (E) must be non singular
+	report.add(CheckDiscriminant, !curve.IsSingular(), "4a^3+27b^2 = 0 mod p")
+
+	// the short Weirstrass form only holds for char(K) > 3
+	fieldPrime := nt.IsPrime(p) && p.Cmp(nt.FromInt64(3)) > 0
+	report.add(CheckFieldPrime, fieldPrime, "p must be a prime greater than 3")
+
+	orderPrime := n.Sign() > 0 && nt.IsPrime(n)
+	report.add(CheckOrderPrime, orderPrime, "n isn't prime")
+
+	onCurve := !params.G.Equal(Inf) && curve.IsOnCurve(params.G)
+	report.add(CheckGeneratorOnCurve, onCurve, "G isn't a point of (E)")
+
+	// when n is prime nG = O and G != O implies ord(G) = n
+	if onCurve && orderPrime {
+		report.add(CheckGeneratorOrder, curve.ScalarMul(params.G, n).Equal(Inf), "nG != O")
+	} else {
+		report.add(CheckGeneratorOrder, false, "requires G on curve and n prime")
+	}
+
+	h, reason := cofactor(p, n, params.H)
+	report.add(CheckCofactor, reason == "", reason)
+	if reason != "" {
+		report.add(CheckEmbeddingDegree, false, "requires a valid cofactor")
+		report.add(CheckAnomalous, false, "requires a valid cofactor")
+		report.add(CheckTwist, false, "requires a valid cofactor")
+		return report
+	}
+	report.Order = nt.Mul(h, n)
+
+	// MOV/Frey-Ruck : the pairing maps <G> to a subgroup of F(p^k)*
+	// where k is the smallest integer s.t n | p^k - 1.
+	report.EmbeddingDegree = embeddingDegree(p, n, movDegreeBound)
+	report.add(CheckEmbeddingDegree, report.EmbeddingDegree == 0, fmt.Sprintf("embedding degree %d <= %d", report.EmbeddingDegree, movDegreeBound))
+
+	// Smart/Satoh-Araki/Semaev : anomalous curves #E(Fp) = p have a
+	// discrete log solvable in linear time.
+	report.add(CheckAnomalous, report.Order.Cmp(p) != 0 && n.Cmp(p) != 0, "#E(Fp) = p")
+
+	// The twist E' has #E'(Fp) = 2(p+1) - #E(Fp) points, implementations that
+	// don't validate input points (x-only ladders) leak through it.
+	report.TwistOrder = nt.Sub(nt.Mul(nt.FromInt64(2), nt.Add(p, nt.One)), report.Order)
+	factors := nt.PrimeFactors(report.TwistOrder)
+	q := nt.One
+	if len(factors) > 0 {
+		q = factors[len(factors)-1]
+	}
+	report.add(CheckTwist, q.BitLen() >= 2*twistSecurityBits, fmt.Sprintf("largest prime factor of the twist order has %d bits", q.BitLen()))
+
+	return report
+}
+
+// cofactor checks (or computes when h is nil) the cofactor h using Hasse's
+// theorem |p + 1 - hn| <= 2 sqrt(p), on failure it returns a reason.
+func cofactor(p, n, h *nt.Integer) (*nt.Integer, string) {
+
+	if n.Sign() <= 0 {
+		return nil, "n must be positive"
+	}
+	if h == nil {
+		// h = round((p+1)/n)
+		h = nt.Div(nt.Add(nt.Add(p, nt.One), nt.Div(n, nt.FromInt64(2))), n)
+	}
+	if h.Sign() <= 0 {
+		return nil, "h must be positive"
+	}
+	// (p + 1 - hn)^2 <= 4p
+	t := nt.Sub(nt.Add(p, nt.One), nt.Mul(h, n))
+	if nt.Mul(t, t).Cmp(nt.Mul(nt.FromInt64(4), p)) > 0 {
+		return nil, "hn is outside the Hasse interval"
+	}
+	// h is only uniquely determined by n when n > 4 sqrt(p)
+	if nt.Mul(n, n).Cmp(nt.Mul(nt.FromInt64(16), p)) <= 0 {
+		return nil, "n <= 4 sqrt(p) the cofactor isn't unique"
+	}
+	return h, ""
+}
+
+// embeddingDegree returns the smallest k <= bound s.t p^k = 1 mod n or 0.
+func embeddingDegree(p, n *nt.Integer, bound int) int {
+	t := nt.Mod(p, n)
+	acc := new(big.Int).Set(t)
+	for k := 1; k <= bound; k++ {
+		if acc.Cmp(nt.One) == 0 {
+			return k
+		}
+		acc = nt.ModMul(acc, t, n)
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package ec
+
+import (
+	"testing"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+func TestValidate(t *testing.T) {
+
+	t.Run("TestSecp256k1", func(t *testing.T) {
+		p, _ := new(nt.Integer).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+		n, _ := new(nt.Integer).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+		gx, _ := new(nt.Integer).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+		gy, _ := new(nt.Integer).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+
+		field, _ := ff.NewFiniteField(p)
+		curve := NewEllipticCurve(field.Zero(), field.NewFieldElementFromInt64(7), field)
+		params := &DomainParams{Curve: curve, G: &Point{X: gx, Y: gy}, N: n, H: nt.FromInt64(1)}
+
+		report := Validate(params)
+		for _, c := range []Check{CheckDiscriminant, CheckFieldPrime, CheckOrderPrime, CheckGeneratorOnCurve,
+			CheckGeneratorOrder, CheckCofactor, CheckEmbeddingDegree, CheckAnomalous, CheckTwist} {
+			if !report.Passed(c) {
+				t.Error("secp256k1 failed check", c, "\n", report)
+			}
+		}
+		if report.Order.Cmp(n) != 0 {
+			t.Error("wrong group order expected", n, "got", report.Order)
+		}
+		// the cofactor can be recovered from Hasse's theorem
+		params.H = nil
+		if !Validate(params).Passed(CheckCofactor) {
+			t.Error("failed to derive the cofactor of secp256k1")
+		}
+		// a wrong generator is detected
+		params.G = &Point{X: gx, Y: nt.Add(gy, nt.One)}
+		report = Validate(params)
+		if report.Passed(CheckGeneratorOnCurve) || report.Passed(CheckGeneratorOrder) {
+			t.Error("bad generator wasn't detected")
+		}
+	})
+
+	t.Run("TestTwistFactorization", func(t *testing.T) {
+		// y^2 = x^3 + 7 is supersingular over p = 2 mod 3 so the curve and its
+		// twist have p + 1 = 12 q n points with q = 16777259, trial division
+		// leaves q n which is split by Pollard's rho.
+		p, _ := new(nt.Integer).SetString("aecedf491731adce2232389612e2bc0423f39d3573db5aa7235a0d000c06a7b", 16)
+		n, _ := new(nt.Integer).SetString("e913ad3b26294da9e8376c1c1f5aec1694f25bed3a5e4abbadea8b1f", 16)
+		gx, _ := new(nt.Integer).SetString("15a6d97ecaf065d490db708474d05c51f847234f28715b494b4c02d6cf448a3", 16)
+		gy, _ := new(nt.Integer).SetString("5604be4512bed29fea3705c0bfa54a412d7bcee3ed5cc8d250b16242a6a559", 16)
+
+		field, _ := ff.NewFiniteField(p)
+		curve := NewEllipticCurve(field.Zero(), field.NewFieldElementFromInt64(7), field)
+		report := Validate(&DomainParams{Curve: curve, G: &Point{X: gx, Y: gy}, N: n})
+		if report.TwistOrder.Cmp(nt.Add(p, nt.One)) != 0 {
+			t.Error("wrong twist order expected", nt.Add(p, nt.One), "got", report.TwistOrder)
+		}
+		if !report.Passed(CheckTwist) {
+			t.Error("twist check failed\n", report)
+		}
+		// the embedding degree of supersingular curves is 2
+		if report.Passed(CheckEmbeddingDegree) || report.EmbeddingDegree != 2 {
+			t.Error("wrong embedding degree expected 2 got", report.EmbeddingDegree)
+		}
+	})
+
+	t.Run("TestSingular", func(t *testing.T) {
+		field, _ := ff.NewFiniteField(nt.FromInt64(29))
+		// y^2 = x^3 - 3x + 2 = (x-1)^2(x+2)
+		curve := NewEllipticCurve(field.NewFieldElementFromInt64(-3), field.NewFieldElementFromInt64(2), field)
+		if !curve.IsSingular() {
+			t.Error("failed to detect singular curve")
+		}
+		report := Validate(&DomainParams{Curve: curve, G: &Point{X: nt.FromInt64(2), Y: nt.FromInt64(2)}, N: nt.FromInt64(29)})
+		if report.Passed(CheckDiscriminant) || report.OK() {
+			t.Error("singular curve passed validation")
+		}
+	})
+
+	t.Run("TestToyCurve", func(t *testing.T) {
+		// E(F29) : y^2 = x^3 + 4x + 20 has 37 points
+		field, _ := ff.NewFiniteField(nt.FromInt64(29))
+		curve := NewEllipticCurve(field.NewFieldElementFromInt64(4), field.NewFieldElementFromInt64(20), field)
+		report := Validate(&DomainParams{Curve: curve, G: &Point{X: nt.FromInt64(1), Y: nt.FromInt64(5)}, N: nt.FromInt64(37)})
+
+		for _, c := range []Check{CheckDiscriminant, CheckFieldPrime, CheckOrderPrime, CheckGeneratorOnCurve,
+			CheckGeneratorOrder, CheckCofactor, CheckAnomalous} {
+			if !report.Passed(c) {
+				t.Error("toy curve failed check", c, "\n", report)
+			}
+		}
+		// small curves are always vulnerable to MOV and their twist is small
+		if report.Passed(CheckEmbeddingDegree) || report.EmbeddingDegree == 0 {
+			t.Error("MOV check should fail on toy curves")
+		}
+		if report.Passed(CheckTwist) || report.TwistOrder.Cmp(nt.FromInt64(23)) != 0 {
+			t.Error("wrong twist order expected 23 got", report.TwistOrder)
+		}
+		// a wrong order is detected
+		report = Validate(&DomainParams{Curve: curve, G: &Point{X: nt.FromInt64(1), Y: nt.FromInt64(5)}, N: nt.FromInt64(31)})
+		if report.Passed(CheckGeneratorOrder) || report.OK() {
+			t.Error("wrong order passed validation")
+		}
+	})
+
+	t.Run("TestAnomalous", func(t *testing.T) {
+		// E(F43) : y^2 = x^3 + x + 14 has exactly 43 points
+		field, _ := ff.NewFiniteField(nt.FromInt64(43))
+		curve := NewEllipticCurve(field.NewFieldElementFromInt64(1), field.NewFieldElementFromInt64(14), field)
+		report := Validate(&DomainParams{Curve: curve, G: &Point{X: nt.FromInt64(0), Y: nt.FromInt64(10)}, N: nt.FromInt64(43)})
+		if !report.Passed(CheckGeneratorOrder) {
+			t.Error("generator order check failed", report)
+		}
+		if report.Passed(CheckAnomalous) {
+			t.Error("failed to detect anomalous curve")
+		}
+	})
+}
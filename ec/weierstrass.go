@@ -0,0 +1,416 @@
+package ec
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// Form identifies the shape of a Weierstrass equation, the group law has
+// simpler formulas for each of the normal forms.
+type Form int
+
+// Normal forms of the Weierstrass equation, see Guide To ECC section 3.1.
+const (
+	// FormGeneral : y^2 + a1xy + a3y = x^3 + a2x^2 + a4x + a6
+	FormGeneral Form = iota
+	// FormChar2NonSupersingular : y^2 + xy = x^3 + a2x^2 + a6 (j != 0)
+	FormChar2NonSupersingular
+	// FormChar2Supersingular : y^2 + a3y = x^3 + a4x + a6 (j = 0)
+	FormChar2Supersingular
+	// FormChar3NonSupersingular : y^2 = x^3 + a2x^2 + a6 (j != 0)
+	FormChar3NonSupersingular
+	// FormChar3Supersingular : y^2 = x^3 + a4x + a6 (j = 0)
+	FormChar3Supersingular
+)
+
+// WeierstrassCurve represents an elliptic curve by the general Weierstrass equation :
+// (E): y^2 + a1xy + a3y = x^3 + a2x^2 + a4x + a6
+// Unlike Curve it's defined for any prime characteristic, including 2 and 3.
+// It's built on ff.FiniteField which only implements prime fields so the char
+// 2 and 3 addition laws only ever run over GF(2) and GF(3), binary curves over
+// GF(2^m) are handled by BinaryCurve. The usual quantities attached to (E)
+// are (Silverman III.1) :
+// b2 = a1^2 + 4a2
+// b4 = 2a4 + a1a3
+// b6 = a3^2 + 4a6
+// b8 = a1^2a6 + 4a2a6 - a1a3a4 + a2a3^2 - a4^2
+// c4 = b2^2 - 24b4
+// c6 = -b2^3 + 36b2b4 - 216b6
+// Δ = -b2^2b8 - 8b4^3 - 27b6^2 + 9b2b4b6
+// j = c4^3/Δ
+//
+// Since (0,0) lies on (E) whenever a6 = 0 the point at infinity can't be
+// the sentinel Inf, it's represented by the nil point instead.
+type WeierstrassCurve struct {
+	A1 ff.FieldElement
+	A2 ff.FieldElement
+	A3 ff.FieldElement
+	A4 ff.FieldElement
+	A6 ff.FieldElement
+	F  ff.FiniteField
+}
+
+// NewWeierstrassCurve creates an instance of an elliptic curve in general Weierstrass form
+func NewWeierstrassCurve(a1, a2, a3, a4, a6 ff.FieldElement, f ff.FiniteField) *WeierstrassCurve {
+	return &WeierstrassCurve{
+		A1: a1,
+		A2: a2,
+		A3: a3,
+		A4: a4,
+		A6: a6,
+		F:  f,
+	}
+}
+
+// Infinity returns the point at infinity
+func (c *WeierstrassCurve) Infinity() *Point {
+	return nil
+}
+
+// IsInfinity returns true if p is the point at infinity
+func (c *WeierstrassCurve) IsInfinity(p *Point) bool {
+	return p == nil
+}
+
+// small returns the integer n as an element of the field
+func (c *WeierstrassCurve) small(n int64) ff.FieldElement {
+	return c.F.NewFieldElementFromInt64(n)
+}
+
+// B2 returns b2 = a1^2 + 4a2
+func (c *WeierstrassCurve) B2() ff.FieldElement {
+	f := c.F
+	return f.Add(c.A1.Square(), f.Mul(c.small(4), c.A2))
+}
+
+// B4 returns b4 = 2a4 + a1a3
+func (c *WeierstrassCurve) B4() ff.FieldElement {
+	f := c.F
+	return f.Add(c.A4.Double(), f.Mul(c.A1, c.A3))
+}
+
+// B6 returns b6 = a3^2 + 4a6
+func (c *WeierstrassCurve) B6() ff.FieldElement {
+	f := c.F
+	return f.Add(c.A3.Square(), f.Mul(c.small(4), c.A6))
+}
+
+// B8 returns b8 = a1^2a6 + 4a2a6 - a1a3a4 + a2a3^2 - a4^2
+func (c *WeierstrassCurve) B8() ff.FieldElement {
+	f := c.F
+	r := f.Mul(c.A1.Square(), c.A6)
+	r = f.Add(r, f.Mul(c.small(4), f.Mul(c.A2, c.A6)))
+	r = f.Sub(r, f.Mul(c.A1, f.Mul(c.A3, c.A4)))
+	r = f.Add(r, f.Mul(c.A2, c.A3.Square()))
+	return f.Sub(r, c.A4.Square())
+}
+
+// C4 returns c4 = b2^2 - 24b4
+func (c *WeierstrassCurve) C4() ff.FieldElement {
+	f := c.F
+	return f.Sub(c.B2().Square(), f.Mul(c.small(24), c.B4()))
+}
+
+// C6 returns c6 = -b2^3 + 36b2b4 - 216b6
+func (c *WeierstrassCurve) C6() ff.FieldElement {
+	f := c.F
+	b2, b4, b6 := c.B2(), c.B4(), c.B6()
+	r := f.Mul(b2.Square(), b2).Neg()
+	r = f.Add(r, f.Mul(c.small(36), f.Mul(b2, b4)))
+	return f.Sub(r, f.Mul(c.small(216), b6))
+}
+
+// Discriminant returns Δ = -b2^2b8 - 8b4^3 - 27b6^2 + 9b2b4b6
+func (c *WeierstrassCurve) Discriminant() ff.FieldElement {
+	f := c.F
+	b2, b4, b6, b8 := c.B2(), c.B4(), c.B6(), c.B8()
+	r := f.Mul(b2.Square(), b8).Neg()
+	r = f.Sub(r, f.Mul(c.small(8), f.Mul(b4.Square(), b4)))
+	r = f.Sub(r, f.Mul(c.small(27), b6.Square()))
+	return f.Add(r, f.Mul(c.small(9), f.Mul(b2, f.Mul(b4, b6))))
+}
+
+// IsSingular returns true if Δ = 0
+func (c *WeierstrassCurve) IsSingular() bool {
+	return c.Discriminant().IsZero()
+}
+
+// J returns the j-invariant j = c4^3/Δ, two curves over the algebraic closure
+// are isomorphic if and only if they have the same j-invariant.
+func (c *WeierstrassCurve) J() (ff.FieldElement, error) {
+	if c.IsSingular() {
+		return c.F.Zero(), errors.New("j-invariant of a singular curve")
+	}
+	c4 := c.C4()
+	return c.F.Div(c.F.Mul(c4.Square(), c4), c.Discriminant()), nil
+}
+
+// Form returns the normal form of the equation, curves in char 2 and 3 whose
+// coefficients match a normal form use the specialized addition laws.
+func (c *WeierstrassCurve) Form() Form {
+	zero := func(e ff.FieldElement) bool { return e.IsZero() }
+	one := func(e ff.FieldElement) bool { return e.Equal(c.F.One()) }
+
+	// compare the whole characteristic, large primes may agree with 2 or 3
+	// on their low 64 bits
+	char := c.F.Char()
+	switch {
+	case char.Cmp(nt.FromInt64(2)) == 0:
+		if one(c.A1) && zero(c.A3) && zero(c.A4) {
+			return FormChar2NonSupersingular
+		}
+		if zero(c.A1) && zero(c.A2) && !zero(c.A3) {
+			return FormChar2Supersingular
+		}
+	case char.Cmp(nt.FromInt64(3)) == 0:
+		if zero(c.A1) && zero(c.A3) && zero(c.A4) && !zero(c.A2) {
+			return FormChar3NonSupersingular
+		}
+		if zero(c.A1) && zero(c.A3) && zero(c.A2) {
+			return FormChar3Supersingular
+		}
+	}
+	return FormGeneral
+}
+
+// ToShort returns the isomorphic curve in short Weirstrass form when char(K) != 2,3.
+// Completing the square y -> y + (a1x + a3)/2 then the cube x -> x + b2/12 yields
+// (E)s : y^2 = x^3 - c4/48 x - c6/864
+func (c *WeierstrassCurve) ToShort() (*Curve, error) {
+	if err := c.checkShortChar(); err != nil {
+		return nil, err
+	}
+	f := c.F
+	a := f.Div(c.C4(), c.small(48)).Neg()
+	b := f.Div(c.C6(), c.small(864)).Neg()
+	return NewEllipticCurve(a, b, f), nil
+}
+
+// MapToShort applies the isomorphism (x,y) -> (x + b2/12, y + (a1x + a3)/2)
+// sending a point on (E) to its image on the short Weirstrass curve, the
+// point at infinity is sent to Inf which stands for it on Curve.
+func (c *WeierstrassCurve) MapToShort(p *Point) (*Point, error) {
+	if err := c.checkShortChar(); err != nil {
+		return nil, err
+	}
+	if c.IsInfinity(p) {
+		return Inf, nil
+	}
+	f := c.F
+	x := f.NewFieldElement(p.X)
+	y := f.NewFieldElement(p.Y)
+	u := f.Add(x, f.Div(c.B2(), c.small(12)))
+	v := f.Add(y, f.Div(f.Add(f.Mul(c.A1, x), c.A3), c.small(2)))
+	return &Point{u.Big(), v.Big()}, nil
+}
+
+// MapFromShort is the inverse of MapToShort.
+func (c *WeierstrassCurve) MapFromShort(p *Point) (*Point, error) {
+	if err := c.checkShortChar(); err != nil {
+		return nil, err
+	}
+	if p.Equal(Inf) {
+		return c.Infinity(), nil
+	}
+	f := c.F
+	u := f.NewFieldElement(p.X)
+	v := f.NewFieldElement(p.Y)
+	x := f.Sub(u, f.Div(c.B2(), c.small(12)))
+	y := f.Sub(v, f.Div(f.Add(f.Mul(c.A1, x), c.A3), c.small(2)))
+	return &Point{x.Big(), y.Big()}, nil
+}
+
+func (c *WeierstrassCurve) checkShortChar() error {
+	char := c.F.Char()
+	if char.Cmp(nt.FromInt64(2)) == 0 || char.Cmp(nt.FromInt64(3)) == 0 {
+		return errors.New("no short Weirstrass form in characteristic 2 or 3")
+	}
+	return nil
+}
+
+// IsOnCurve checks if a given point is on the curve
+func (c *WeierstrassCurve) IsOnCurve(p *Point) bool {
+	if c.IsInfinity(p) {
+		return true
+	}
+	f := c.F
+	x := f.NewFieldElement(p.X)
+	y := f.NewFieldElement(p.Y)
+
+	// y^2 + a1xy + a3y
+	lhs := f.Add(f.Add(y.Square(), f.Mul(c.A1, f.Mul(x, y))), f.Mul(c.A3, y))
+	// x^3 + a2x^2 + a4x + a6
+	rhs := f.Add(f.Add(f.Add(f.Mul(x.Square(), x), f.Mul(c.A2, x.Square())), f.Mul(c.A4, x)), c.A6)
+
+	return lhs.Equal(rhs)
+}
+
+// Neg returns -(x,y) = (x, -y - a1x - a3)
+func (c *WeierstrassCurve) Neg(p *Point) *Point {
+	if c.IsInfinity(p) {
+		return p
+	}
+	f := c.F
+	x := f.NewFieldElement(p.X)
+	y := f.NewFieldElement(p.Y)
+	return &Point{X: x.Big(), Y: f.Sub(y.Neg(), f.Add(f.Mul(c.A1, x), c.A3)).Big()}
+}
+
+// Add computes the sum of two points on the curve, the formulas depend
+// on the normal form of the curve.
+func (c *WeierstrassCurve) Add(p, q *Point) *Point {
+
+	if c.IsInfinity(p) {
+		return q
+	}
+	if c.IsInfinity(q) {
+		return p
+	}
+	if q.Equal(c.Neg(p)) {
+		return c.Infinity()
+	}
+	switch c.Form() {
+	case FormChar2NonSupersingular:
+		return c.addChar2NonSupersingular(p, q)
+	case FormChar2Supersingular:
+		return c.addChar2Supersingular(p, q)
+	case FormChar3NonSupersingular:
+		return c.addChar3NonSupersingular(p, q)
+	case FormChar3Supersingular:
+		return c.addChar3Supersingular(p, q)
+	}
+	return c.addGeneral(p, q)
+}
+
+// addGeneral implements the group law for the general equation (Silverman III.2.3)
+// p and q must be finite and p != -q
+func (c *WeierstrassCurve) addGeneral(p, q *Point) *Point {
+	f := c.F
+	x1, y1 := f.NewFieldElement(p.X), f.NewFieldElement(p.Y)
+	x2, y2 := f.NewFieldElement(q.X), f.NewFieldElement(q.Y)
+
+	var lambda, nu ff.FieldElement
+	if !x1.Equal(x2) {
+		// lambda = (y2-y1)/(x2-x1) , nu = (y1x2 - y2x1)/(x2-x1)
+		den := f.Sub(x2, x1)
+		lambda = f.Div(f.Sub(y2, y1), den)
+		nu = f.Div(f.Sub(f.Mul(y1, x2), f.Mul(y2, x1)), den)
+	} else {
+		// lambda = (3x1^2 + 2a2x1 + a4 - a1y1)/(2y1 + a1x1 + a3)
+		// nu = (-x1^3 + a4x1 + 2a6 - a3y1)/(2y1 + a1x1 + a3)
+		den := f.Add(f.Add(y1.Double(), f.Mul(c.A1, x1)), c.A3)
+		num := f.Add(f.Mul(c.small(3), x1.Square()), f.Mul(c.A2.Double(), x1))
+		num = f.Sub(f.Add(num, c.A4), f.Mul(c.A1, y1))
+		lambda = f.Div(num, den)
+		num = f.Add(f.Mul(x1.Square(), x1).Neg(), f.Mul(c.A4, x1))
+		num = f.Sub(f.Add(num, c.A6.Double()), f.Mul(c.A3, y1))
+		nu = f.Div(num, den)
+	}
+	// x3 = lambda^2 + a1lambda - a2 - x1 - x2
+	x3 := f.Sub(f.Sub(f.Sub(f.Add(lambda.Square(), f.Mul(c.A1, lambda)), c.A2), x1), x2)
+	// y3 = -(lambda + a1)x3 - nu - a3
+	y3 := f.Sub(f.Sub(f.Mul(f.Add(lambda, c.A1), x3).Neg(), nu), c.A3)
+
+	return &Point{x3.Big(), y3.Big()}
+}
+
+// addChar2NonSupersingular : y^2 + xy = x^3 + a2x^2 + a6 (Guide To ECC 3.1.2)
+// P + Q : lambda = (y1+y2)/(x1+x2), x3 = lambda^2 + lambda + x1 + x2 + a2, y3 = lambda(x1+x3) + x3 + y1
+// 2P : lambda = x1 + y1/x1, x3 = lambda^2 + lambda + a2, y3 = x1^2 + lambda x3 + x3
+func (c *WeierstrassCurve) addChar2NonSupersingular(p, q *Point) *Point {
+	f := c.F
+	x1, y1 := f.NewFieldElement(p.X), f.NewFieldElement(p.Y)
+	x2, y2 := f.NewFieldElement(q.X), f.NewFieldElement(q.Y)
+
+	if p.Equal(q) {
+		lambda := f.Add(x1, f.Div(y1, x1))
+		x3 := f.Add(f.Add(lambda.Square(), lambda), c.A2)
+		y3 := f.Add(f.Add(x1.Square(), f.Mul(lambda, x3)), x3)
+		return &Point{x3.Big(), y3.Big()}
+	}
+	lambda := f.Div(f.Add(y1, y2), f.Add(x1, x2))
+	x3 := f.Add(f.Add(f.Add(f.Add(lambda.Square(), lambda), x1), x2), c.A2)
+	y3 := f.Add(f.Add(f.Mul(lambda, f.Add(x1, x3)), x3), y1)
+	return &Point{x3.Big(), y3.Big()}
+}
+
+// addChar2Supersingular : y^2 + a3y = x^3 + a4x + a6
+// P + Q : lambda = (y1+y2)/(x1+x2), x3 = lambda^2 + x1 + x2, y3 = lambda(x1+x3) + y1 + a3
+// 2P : lambda = (x1^2+a4)/a3, x3 = lambda^2, y3 = lambda(x1+x3) + y1 + a3
+func (c *WeierstrassCurve) addChar2Supersingular(p, q *Point) *Point {
+	f := c.F
+	x1, y1 := f.NewFieldElement(p.X), f.NewFieldElement(p.Y)
+	x2, y2 := f.NewFieldElement(q.X), f.NewFieldElement(q.Y)
+
+	var lambda, x3 ff.FieldElement
+	if p.Equal(q) {
+		lambda = f.Div(f.Add(x1.Square(), c.A4), c.A3)
+		x3 = lambda.Square()
+	} else {
+		lambda = f.Div(f.Add(y1, y2), f.Add(x1, x2))
+		x3 = f.Add(f.Add(lambda.Square(), x1), x2)
+	}
+	y3 := f.Add(f.Add(f.Mul(lambda, f.Add(x1, x3)), y1), c.A3)
+	return &Point{x3.Big(), y3.Big()}
+}
+
+// addChar3NonSupersingular : y^2 = x^3 + a2x^2 + a6
+// P + Q : lambda = (y2-y1)/(x2-x1), x3 = lambda^2 - a2 - x1 - x2, y3 = lambda(x1-x3) - y1
+// 2P : lambda = a2x1/y1, x3 = lambda^2 - a2 - 2x1, y3 = lambda(x1-x3) - y1
+func (c *WeierstrassCurve) addChar3NonSupersingular(p, q *Point) *Point {
+	f := c.F
+	x1, y1 := f.NewFieldElement(p.X), f.NewFieldElement(p.Y)
+	x2, y2 := f.NewFieldElement(q.X), f.NewFieldElement(q.Y)
+
+	var lambda ff.FieldElement
+	if p.Equal(q) {
+		lambda = f.Div(f.Mul(c.A2, x1), y1)
+	} else {
+		lambda = f.Div(f.Sub(y2, y1), f.Sub(x2, x1))
+	}
+	x3 := f.Sub(f.Sub(f.Sub(lambda.Square(), c.A2), x1), x2)
+	y3 := f.Sub(f.Mul(lambda, f.Sub(x1, x3)), y1)
+	return &Point{x3.Big(), y3.Big()}
+}
+
+// addChar3Supersingular : y^2 = x^3 + a4x + a6
+// P + Q : lambda = (y2-y1)/(x2-x1), x3 = lambda^2 - x1 - x2, y3 = lambda(x1-x3) - y1
+// 2P : lambda = -a4/y1, x3 = lambda^2 + x1, y3 = lambda(x1-x3) - y1
+func (c *WeierstrassCurve) addChar3Supersingular(p, q *Point) *Point {
+	f := c.F
+	x1, y1 := f.NewFieldElement(p.X), f.NewFieldElement(p.Y)
+	x2, y2 := f.NewFieldElement(q.X), f.NewFieldElement(q.Y)
+
+	var lambda ff.FieldElement
+	if p.Equal(q) {
+		lambda = f.Div(c.A4, y1).Neg()
+	} else {
+		lambda = f.Div(f.Sub(y2, y1), f.Sub(x2, x1))
+	}
+	x3 := f.Sub(f.Sub(lambda.Square(), x1), x2)
+	y3 := f.Sub(f.Mul(lambda, f.Sub(x1, x3)), y1)
+	return &Point{x3.Big(), y3.Big()}
+}
+
+// Double computes 2P
+func (c *WeierstrassCurve) Double(p *Point) *Point {
+	return c.Add(p, p)
+}
+
+// ScalarMul computes multiplication of curve points by scalars, negative
+// scalars multiply the opposite point.
+func (c *WeierstrassCurve) ScalarMul(p *Point, s *nt.Integer) *Point {
+	if s.Sign() < 0 {
+		return c.ScalarMul(c.Neg(p), new(nt.Integer).Neg(s))
+	}
+	q := c.Infinity()
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		q = c.Double(q)
+		if s.Bit(i) == 1 {
+			q = c.Add(q, p)
+		}
+	}
+	return q
+}
@@ -0,0 +1,235 @@
+package ec
+
+import (
+	"testing"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// points enumerates the affine points of a curve over a small prime field.
+func points(c *WeierstrassCurve) []*Point {
+	var pts []*Point
+	q := c.F.Modulus().Int64()
+	for x := int64(0); x < q; x++ {
+		for y := int64(0); y < q; y++ {
+			p := &Point{nt.FromInt64(x), nt.FromInt64(y)}
+			if c.IsOnCurve(p) {
+				pts = append(pts, p)
+			}
+		}
+	}
+	return pts
+}
+
+func TestWeierstrass(t *testing.T) {
+
+	t.Run("TestInvariants", func(t *testing.T) {
+		// y^2 + y = x^3 - x^2 (the curve 11a3) has Δ = -11 and j = -4096/11
+		field, _ := ff.NewFiniteField(nt.FromInt64(101))
+		c := NewWeierstrassCurve(field.Zero(), field.NewFieldElementFromInt64(-1), field.One(), field.Zero(), field.Zero(), field)
+
+		if !c.Discriminant().Equal(field.NewFieldElementFromInt64(-11)) {
+			t.Error("wrong discriminant got", c.Discriminant())
+		}
+		j, err := c.J()
+		expected := field.Div(field.NewFieldElementFromInt64(-4096), field.NewFieldElementFromInt64(11))
+		if err != nil || !j.Equal(expected) {
+			t.Error("wrong j-invariant expected", expected, "got", j)
+		}
+		// 1728 Δ = c4^3 - c6^2
+		lhs := field.Mul(field.NewFieldElementFromInt64(1728), c.Discriminant())
+		rhs := field.Sub(field.Mul(c.C4().Square(), c.C4()), c.C6().Square())
+		if !lhs.Equal(rhs) {
+			t.Error("1728Δ != c4^3 - c6^2")
+		}
+		// 4b8 = b2b6 - b4^2
+		lhs = field.Mul(field.NewFieldElementFromInt64(4), c.B8())
+		rhs = field.Sub(field.Mul(c.B2(), c.B6()), c.B4().Square())
+		if !lhs.Equal(rhs) {
+			t.Error("4b8 != b2b6 - b4^2")
+		}
+	})
+
+	t.Run("TestInfinity", func(t *testing.T) {
+		// (0,0) is a point of order 5 on 11a3 since a6 = 0, it's distinct
+		// from the point at infinity
+		field, _ := ff.NewFiniteField(nt.FromInt64(101))
+		c := NewWeierstrassCurve(field.Zero(), field.NewFieldElementFromInt64(-1), field.One(), field.Zero(), field.Zero(), field)
+		point := func(x, y int64) *Point { return &Point{nt.FromInt64(x), nt.FromInt64(y)} }
+		P := point(0, 0)
+		if !c.IsOnCurve(P) || c.IsInfinity(P) {
+			t.Fatal("(0,0) is an affine point of 11a3")
+		}
+		if R := c.Neg(P); c.IsInfinity(R) || !R.Equal(point(0, 100)) {
+			t.Error("-(0,0) expected (0,100) got", R)
+		}
+		if R := c.Double(P); c.IsInfinity(R) || !R.Equal(point(1, 100)) {
+			t.Error("2(0,0) expected (1,100) got", R)
+		}
+		if R := c.Add(P, point(1, 0)); c.IsInfinity(R) || !R.Equal(point(0, 100)) {
+			t.Error("(0,0) + (1,0) expected (0,100) got", R)
+		}
+		if !c.Add(c.Infinity(), P).Equal(P) || !c.Add(P, c.Infinity()).Equal(P) {
+			t.Error("O isn't the identity")
+		}
+		if !c.IsInfinity(c.Add(P, c.Neg(P))) {
+			t.Error("P + (-P) != O")
+		}
+		for k := int64(1); k < 5; k++ {
+			if c.IsInfinity(c.ScalarMul(P, nt.FromInt64(k))) {
+				t.Error(k, "(0,0) shouldn't be O")
+			}
+		}
+		if !c.IsInfinity(c.ScalarMul(P, nt.FromInt64(5))) {
+			t.Error("5(0,0) != O")
+		}
+		if !c.IsOnCurve(c.Infinity()) || !c.IsInfinity(c.Neg(c.Infinity())) {
+			t.Error("O is on the curve and is its own inverse")
+		}
+		sO, _ := c.MapToShort(c.Infinity())
+		if !sO.Equal(Inf) {
+			t.Error("O should map to Inf on the short curve")
+		}
+		if O, _ := c.MapFromShort(Inf); !c.IsInfinity(O) {
+			t.Error("Inf should map back to O")
+		}
+	})
+
+	t.Run("TestShortForm", func(t *testing.T) {
+		// y^2 + xy + y = x^3 - x^2 + 3x + 5 over F101
+		field, _ := ff.NewFiniteField(nt.FromInt64(101))
+		c := NewWeierstrassCurve(field.One(), field.NewFieldElementFromInt64(-1), field.One(),
+			field.NewFieldElementFromInt64(3), field.NewFieldElementFromInt64(5), field)
+		short, err := c.ToShort()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// isomorphic curves share the j-invariant j = 1728 * 4a^3/(4a^3 + 27b^2)
+		fourACubed := field.Mul(field.NewFieldElementFromInt64(4), field.Mul(short.A.Square(), short.A))
+		jShort := field.Div(field.Mul(field.NewFieldElementFromInt64(1728), fourACubed),
+			field.Add(fourACubed, field.Mul(field.NewFieldElementFromInt64(27), short.B.Square())))
+		j, _ := c.J()
+		if !j.Equal(jShort) {
+			t.Error("j-invariant isn't preserved expected", j, "got", jShort)
+		}
+
+		pts := points(c)
+		for i := 0; i < len(pts); i += 7 {
+			for j := 0; j < len(pts); j += 11 {
+				P, Q := pts[i], pts[j]
+				sP, _ := c.MapToShort(P)
+				sQ, _ := c.MapToShort(Q)
+				if !short.IsOnCurve(sP) {
+					t.Fatal("image of", P, "isn't on the short curve")
+				}
+				back, _ := c.MapFromShort(sP)
+				if !back.Equal(P) {
+					t.Error("MapFromShort isn't the inverse of MapToShort")
+				}
+				// the isomorphism is a group morphism
+				R := c.Add(P, Q)
+				if !c.IsOnCurve(R) {
+					t.Fatal("sum isn't on the curve")
+				}
+				sR, _ := c.MapToShort(R)
+				if !short.Add(sP, sQ).Equal(sR) {
+					t.Error("phi(P+Q) != phi(P) + phi(Q) for", P, Q)
+				}
+			}
+		}
+		char3, _ := ff.NewFiniteField(nt.FromInt64(3))
+		if _, err := NewWeierstrassCurve(char3.Zero(), char3.One(), char3.Zero(), char3.Zero(), char3.One(), char3).ToShort(); err == nil {
+			t.Error("short form in characteristic 3 should fail")
+		}
+	})
+
+	t.Run("TestNegativeScalar", func(t *testing.T) {
+		// y^2 = x^3 + 2x + 3 over F97
+		field, _ := ff.NewFiniteField(nt.FromInt64(97))
+		c := NewWeierstrassCurve(field.Zero(), field.Zero(), field.Zero(), field.NewFieldElementFromInt64(2), field.NewFieldElementFromInt64(3), field)
+		for _, P := range points(c)[:8] {
+			for _, k := range []int64{1, 3, 10} {
+				expected := c.Neg(c.ScalarMul(P, nt.FromInt64(k)))
+				got := c.ScalarMul(P, nt.FromInt64(-k))
+				if c.IsInfinity(got) != c.IsInfinity(expected) || !c.IsInfinity(got) && !got.Equal(expected) {
+					t.Error("-k P != -(k P) for k =", k, "got", got, "expected", expected)
+				}
+			}
+		}
+	})
+
+	t.Run("TestLargeCharacteristic", func(t *testing.T) {
+		// p = 8 2^64 + 3 agrees with 3 on its low 64 bits
+		p, _ := new(nt.Integer).SetString("147573952589676412931", 10)
+		field, err := ff.NewFiniteField(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// y^2 = x^3 + x^2 + 1
+		c := NewWeierstrassCurve(field.Zero(), field.One(), field.Zero(), field.Zero(), field.One(), field)
+		if c.Form() != FormGeneral {
+			t.Fatal("wrong form expected", FormGeneral, "got", c.Form())
+		}
+		y, _ := new(nt.Integer).SetString("31365250574035511977", 10)
+		P := &Point{nt.FromInt64(1), y}
+		if !c.IsOnCurve(P) {
+			t.Fatal("P isn't on the curve")
+		}
+		if !c.IsOnCurve(c.Double(P)) || !c.IsOnCurve(c.ScalarMul(P, nt.FromInt64(5))) {
+			t.Error("multiples of P aren't on the curve")
+		}
+	})
+
+	t.Run("TestSmallCharacteristic", func(t *testing.T) {
+		gf2, _ := ff.NewFiniteField(nt.FromInt64(2))
+		gf3, _ := ff.NewFiniteField(nt.FromInt64(3))
+		testCases := []struct {
+			curve *WeierstrassCurve
+			form  Form
+			order int64
+		}{
+			// y^2 + xy = x^3 + 1
+			{NewWeierstrassCurve(gf2.One(), gf2.Zero(), gf2.Zero(), gf2.Zero(), gf2.One(), gf2), FormChar2NonSupersingular, 4},
+			// y^2 + y = x^3 + 1
+			{NewWeierstrassCurve(gf2.Zero(), gf2.Zero(), gf2.One(), gf2.Zero(), gf2.One(), gf2), FormChar2Supersingular, 3},
+			// y^2 = x^3 + x^2 + 1
+			{NewWeierstrassCurve(gf3.Zero(), gf3.One(), gf3.Zero(), gf3.Zero(), gf3.One(), gf3), FormChar3NonSupersingular, 6},
+			// y^2 = x^3 + 2x + 1
+			{NewWeierstrassCurve(gf3.Zero(), gf3.Zero(), gf3.Zero(), gf3.NewFieldElementFromInt64(2), gf3.One(), gf3), FormChar3Supersingular, 7},
+		}
+		for _, tc := range testCases {
+			c := tc.curve
+			if c.IsSingular() {
+				t.Fatal("curve is singular")
+			}
+			if c.Form() != tc.form {
+				t.Error("wrong form expected", tc.form, "got", c.Form())
+			}
+			j, _ := c.J()
+			supersingular := tc.form == FormChar2Supersingular || tc.form == FormChar3Supersingular
+			if j.IsZero() != supersingular {
+				t.Error("supersingular curves in char 2 and 3 have j = 0")
+			}
+			pts := points(c)
+			if int64(len(pts)+1) != tc.order {
+				t.Error("wrong number of points expected", tc.order, "got", len(pts)+1)
+			}
+			for _, P := range pts {
+				for _, Q := range pts {
+					R := c.Add(P, Q)
+					if !c.IsOnCurve(R) {
+						t.Fatal("sum isn't on the curve", P, Q, R)
+					}
+					// specialized formulas agree with the general ones
+					if !c.IsInfinity(R) && !R.Equal(c.addGeneral(P, Q)) {
+						t.Error("specialized addition law disagrees with the general one", P, Q)
+					}
+				}
+				if !c.IsInfinity(c.ScalarMul(P, nt.FromInt64(tc.order))) {
+					t.Error("#E * P != O for", P)
+				}
+			}
+		}
+	})
+}
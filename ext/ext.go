@@ -0,0 +1,302 @@
+// Package ext implements extension fields GF(p^k) = GF(p)[x]/(f(x)) where f is
+// an irreducible polynomial of degree k over the prime field GF(p).
+// Elements are the polynomials of degree less than k with coefficients in GF(p),
+// they're added coefficient wise and multiplied as polynomials modulo f.
+// ref : Modern Computer Algebra chapter 4 and Guide To Elliptic Curve Cryptography 2.4
+package ext
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var (
+	errReducibleModulus = errors.New("extension fields are defined over irreducible polynomials only")
+	errZeroInverse      = errors.New("zero has no multiplicative inverse")
+)
+
+// ExtensionField represents GF(p^k) given the base field GF(p) and a monic
+// irreducible polynomial f of degree k.
+type ExtensionField struct {
+	base ff.FiniteField
+	f    poly.Polynomial
+	k    int
+	// frob holds x^(pj) mod f for 0 <= j < k, the Frobenius map is linear
+	// over GF(p) so a^p = sum a_j x^(pj) needs no exponentiation.
+	frob []poly.Polynomial
+}
+
+// Element is an element of an extension field represented by a polynomial
+// of degree less than k with reduced coefficients.
+type Element struct {
+	c     poly.Polynomial
+	field *ExtensionField
+}
+
+// NewExtensionField creates GF(p^k) = GF(p)[x]/(f), f is made monic and
+// checked for irreducibility.
+func NewExtensionField(base ff.FiniteField, f poly.Polynomial) (*ExtensionField, error) {
+	p := base.Modulus()
+	g := f.Monic(p)
+	if g.Degree() < 1 || !g.IsIrreducible(p) {
+		return nil, errReducibleModulus
+	}
+	e := &ExtensionField{base: base, f: g, k: g.Degree()}
+	e.frob = make([]poly.Polynomial, e.k)
+	xp := poly.NewPolynomialInts(0, 1).PowMod(p, g, p)
+	e.frob[0] = poly.NewPolynomialInts(1)
+	for j := 1; j < e.k; j++ {
+		e.frob[j] = e.frob[j-1].Mul(xp, p).Mod(g, p)
+	}
+	return e, nil
+}
+
+// Base returns the prime field GF(p)
+func (e *ExtensionField) Base() ff.FiniteField {
+	return e.base
+}
+
+// Degree returns the extension degree k
+func (e *ExtensionField) Degree() int {
+	return e.k
+}
+
+// Modulus returns the irreducible polynomial f
+func (e *ExtensionField) Modulus() poly.Polynomial {
+	return e.f.Clone(0)
+}
+
+// Char returns the characteristic p
+func (e *ExtensionField) Char() *nt.Integer {
+	return e.base.Char()
+}
+
+// Order returns the number of elements p^k
+func (e *ExtensionField) Order() *nt.Integer {
+	return new(big.Int).Exp(e.Char(), big.NewInt(int64(e.k)), nil)
+}
+
+// String implements stringer
+func (e *ExtensionField) String() string {
+	return fmt.Sprintf("GF(%d)[x]/(%v)", e.Char(), e.f)
+}
+
+// reduce builds an element from any polynomial with integer coefficients
+func (e *ExtensionField) reduce(c poly.Polynomial) Element {
+	p := e.Char()
+	return Element{c: c.Clone(0).Mod(e.f, p), field: e}
+}
+
+// NewElement returns the element sum c_i x^i reduced modulo f
+func (e *ExtensionField) NewElement(coeffs ...*nt.Integer) Element {
+	c := make(poly.Polynomial, len(coeffs))
+	for i, ci := range coeffs {
+		c[i] = new(big.Int).Set(ci)
+	}
+	if len(c) == 0 {
+		c = poly.NewPolynomialInts(0)
+	}
+	return e.reduce(c)
+}
+
+// NewElementFromInt64 takes int64 coefficients starting from x^0
+func (e *ExtensionField) NewElementFromInt64(coeffs ...int64) Element {
+	c := make([]*nt.Integer, len(coeffs))
+	for i, ci := range coeffs {
+		c[i] = nt.FromInt64(ci)
+	}
+	return e.NewElement(c...)
+}
+
+// FromBase embeds an element of GF(p)
+func (e *ExtensionField) FromBase(a ff.FieldElement) Element {
+	return e.NewElement(a.Big())
+}
+
+// Zero returns the additive identity
+func (e *ExtensionField) Zero() Element {
+	return e.NewElementFromInt64(0)
+}
+
+// One returns the multiplicative identity
+func (e *ExtensionField) One() Element {
+	return e.NewElementFromInt64(1)
+}
+
+// Gen returns x mod f, a root of f
+func (e *ExtensionField) Gen() Element {
+	return e.NewElementFromInt64(0, 1)
+}
+
+// Rand returns a random element
+func (e *ExtensionField) Rand() (Element, error) {
+	c := make([]*nt.Integer, e.k)
+	for i := range c {
+		r, err := rand.Int(rand.Reader, e.Char())
+		if err != nil {
+			return Element{}, err
+		}
+		c[i] = r
+	}
+	return e.NewElement(c...), nil
+}
+
+// Add sums two elements
+func (e *ExtensionField) Add(a, b Element) Element {
+	return Element{c: a.c.Add(b.c, e.Char()), field: e}
+}
+
+// Sub subtracts two elements
+func (e *ExtensionField) Sub(a, b Element) Element {
+	return Element{c: a.c.Sub(b.c, e.Char()), field: e}
+}
+
+// Mul multiplies two elements
+func (e *ExtensionField) Mul(a, b Element) Element {
+	p := e.Char()
+	return Element{c: a.c.Mul(b.c, p).Mod(e.f, p), field: e}
+}
+
+// Div computes a/b
+func (e *ExtensionField) Div(a, b Element) (Element, error) {
+	inv, err := b.Inv()
+	if err != nil {
+		return Element{}, err
+	}
+	return e.Mul(a, inv), nil
+}
+
+// Field returns the field of the element
+func (a Element) Field() *ExtensionField {
+	return a.field
+}
+
+// Coeffs returns the k coefficients of a in the basis 1, x, ..., x^(k-1)
+func (a Element) Coeffs() []ff.FieldElement {
+	c := make([]ff.FieldElement, a.field.k)
+	for i := range c {
+		if i < len(a.c) {
+			c[i] = a.field.base.NewFieldElement(a.c[i])
+		} else {
+			c[i] = a.field.base.Zero()
+		}
+	}
+	return c
+}
+
+// Poly returns a copy of the polynomial representing a
+func (a Element) Poly() poly.Polynomial {
+	return a.c.Clone(0)
+}
+
+// String implements stringer
+func (a Element) String() string {
+	return a.c.String()
+}
+
+// IsZero returns true if a = 0
+func (a Element) IsZero() bool {
+	return a.c.Degree() == 0 && a.c[0].Sign() == 0
+}
+
+// IsOne returns true if a = 1
+func (a Element) IsOne() bool {
+	return a.c.Degree() == 0 && a.c[0].Cmp(nt.One) == 0
+}
+
+// Equal checks for equality between elements of the same field
+func (a Element) Equal(b Element) bool {
+	return a.c.Compare(&b.c) == 0
+}
+
+// Neg returns -a
+func (a Element) Neg() Element {
+	return a.field.Sub(a.field.Zero(), a)
+}
+
+// Double computes 2a
+func (a Element) Double() Element {
+	return a.field.Add(a, a)
+}
+
+// Square computes a^2
+func (a Element) Square() Element {
+	return a.field.Mul(a, a)
+}
+
+// Exp computes a^n for n >= 0 using square and multiply
+func (a Element) Exp(n *nt.Integer) Element {
+	p := a.field.Char()
+	return Element{c: a.c.PowMod(n, a.field.f, p), field: a.field}
+}
+
+// Inv computes a^-1 with the extended Euclidean algorithm, since f is
+// irreducible gcd(a, f) = d is a non zero constant and s*a + t*f = d
+// gives a^-1 = s/d.
+func (a Element) Inv() (Element, error) {
+	if a.IsZero() {
+		return Element{}, errZeroInverse
+	}
+	p := a.field.Char()
+	d, s, _ := a.c.XGCD(a.field.f, p)
+	dInv := nt.ModInv(d[0], p)
+	for i := range s {
+		s[i] = nt.ModMul(s[i], dInv, p)
+	}
+	return a.field.reduce(s), nil
+}
+
+// Frobenius computes a^(p^i), for a = sum a_j x^j we have
+// a^p = sum a_j x^(pj) since a_j^p = a_j.
+func (a Element) Frobenius(i int) Element {
+	e := a.field
+	p := e.Char()
+	i %= e.k
+	if i < 0 {
+		i += e.k
+	}
+	r := a
+	for ; i > 0; i-- {
+		acc := poly.NewPolynomialInts(0)
+		for j, c := range r.c {
+			if c.Sign() == 0 {
+				continue
+			}
+			term := e.frob[j].Clone(0)
+			for l := range term {
+				term[l] = nt.ModMul(term[l], c, p)
+			}
+			acc = acc.Add(term, p)
+		}
+		r = Element{c: acc, field: e}
+	}
+	return r
+}
+
+// Trace computes Tr(a) = a + a^p + ... + a^(p^(k-1)) which lies in GF(p)
+func (a Element) Trace() ff.FieldElement {
+	acc := a.field.Zero()
+	conj := a
+	for i := 0; i < a.field.k; i++ {
+		acc = a.field.Add(acc, conj)
+		conj = conj.Frobenius(1)
+	}
+	return a.field.base.NewFieldElement(acc.c[0])
+}
+
+// Norm computes N(a) = a * a^p * ... * a^(p^(k-1)) which lies in GF(p)
+func (a Element) Norm() ff.FieldElement {
+	acc := a.field.One()
+	conj := a
+	for i := 0; i < a.field.k; i++ {
+		acc = a.field.Mul(acc, conj)
+		conj = conj.Frobenius(1)
+	}
+	return a.field.base.NewFieldElement(acc.c[0])
+}
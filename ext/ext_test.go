@@ -0,0 +1,131 @@
+package ext
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/bf"
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+var bn254P, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+
+func TestExtensionField(t *testing.T) {
+
+	t.Run("TestNewExtensionField", func(t *testing.T) {
+		F5, _ := ff.NewFiniteField(nt.FromInt64(5))
+		// x^2 + 1 = (x + 2)(x + 3) mod 5
+		if _, err := NewExtensionField(F5, poly.NewPolynomialInts(1, 0, 1)); err == nil {
+			t.Error("reducible modulus should fail")
+		}
+		E, err := NewExtensionField(F5, poly.NewPolynomialInts(4, 0, 2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 2x^2 + 4 is made monic
+		monic := poly.NewPolynomialInts(2, 0, 1)
+		if f := E.Modulus(); f.Compare(&monic) != 0 {
+			t.Error("modulus isn't monic", E.Modulus())
+		}
+		if E.Order().Int64() != 25 {
+			t.Error("GF(5^2) has 25 elements")
+		}
+	})
+
+	t.Run("TestAES", func(t *testing.T) {
+		// GF(2^8) with the AES polynomial against the table based implementation
+		F2, _ := ff.NewFiniteField(nt.FromInt64(2))
+		E, _ := NewExtensionField(F2, poly.NewPolynomialInts(1, 1, 0, 1, 1, 0, 0, 0, 1))
+		gf, _ := bf.NewGF256(bf.PolyAES)
+		toElement := func(b byte) Element {
+			c := make([]int64, 8)
+			for i := range c {
+				c[i] = int64(b >> uint(i) & 1)
+			}
+			return E.NewElementFromInt64(c...)
+		}
+		for a := 1; a < 256; a += 7 {
+			for b := 0; b < 256; b += 13 {
+				if !E.Mul(toElement(byte(a)), toElement(byte(b))).Equal(toElement(gf.Mul(byte(a), byte(b)))) {
+					t.Fatal("multiplication disagrees with GF256")
+				}
+			}
+			inv, _ := toElement(byte(a)).Inv()
+			if !inv.Equal(toElement(gf.Inv(byte(a)))) {
+				t.Fatal("inversion disagrees with GF256")
+			}
+		}
+	})
+
+	t.Run("TestQuadratic", func(t *testing.T) {
+		// Fp2 = Fp[u]/(u^2 + 1) of BN254
+		Fp, _ := ff.NewFiniteField(bn254P)
+		Fp2, err := NewExtensionField(Fp, poly.NewPolynomialInts(1, 0, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, _ := Fp2.Rand()
+		c := a.Coeffs()
+		// the conjugate of a0 + a1u is a0 - a1u
+		conj := Fp2.NewElement(c[0].Big(), c[1].Neg().Big())
+		if !a.Frobenius(1).Equal(conj) {
+			t.Error("Frobenius isn't the conjugation")
+		}
+		if !a.Frobenius(1).Equal(a.Exp(bn254P)) {
+			t.Error("Frobenius disagrees with a^p")
+		}
+		if !a.Norm().Equal(Fp.Add(c[0].Square(), c[1].Square())) {
+			t.Error("N(a0 + a1u) != a0^2 + a1^2")
+		}
+		if !a.Trace().Equal(c[0].Double()) {
+			t.Error("Tr(a0 + a1u) != 2a0")
+		}
+		inv, _ := a.Inv()
+		if !Fp2.Mul(a, inv).IsOne() {
+			t.Error("a * a^-1 != 1")
+		}
+		if _, err := Fp2.Zero().Inv(); err == nil {
+			t.Error("inverting zero should fail")
+		}
+	})
+
+	t.Run("TestArithmetic", func(t *testing.T) {
+		F3, _ := ff.NewFiniteField(nt.FromInt64(3))
+		f, _ := poly.ConwayPolynomial(3, 5)
+		E, _ := NewExtensionField(F3, f)
+		order := nt.Sub(E.Order(), nt.One)
+		for i := 0; i < 10; i++ {
+			a, _ := E.Rand()
+			b, _ := E.Rand()
+			if a.IsZero() || b.IsZero() {
+				continue
+			}
+			if !a.Exp(order).IsOne() {
+				t.Error("a^(p^k - 1) != 1")
+			}
+			if !a.Frobenius(5).Equal(a) || !a.Frobenius(2).Equal(a.Exp(nt.FromInt64(9))) {
+				t.Error("Frobenius failed")
+			}
+			q, _ := E.Div(a, b)
+			if !E.Mul(q, b).Equal(a) {
+				t.Error("(a/b)*b != a")
+			}
+			if !E.Mul(a, b).Norm().Equal(F3.Mul(a.Norm(), b.Norm())) {
+				t.Error("norm isn't multiplicative")
+			}
+			if !E.Add(a, b).Trace().Equal(F3.Add(a.Trace(), b.Trace())) {
+				t.Error("trace isn't additive")
+			}
+			if !E.Add(a, a.Neg()).IsZero() {
+				t.Error("a + (-a) != 0")
+			}
+		}
+		// the Conway polynomial is primitive so x generates the multiplicative group
+		x := E.Gen()
+		if x.Exp(nt.FromInt64(121)).IsOne() || x.Exp(nt.FromInt64(22)).IsOne() {
+			t.Error("x should have order 242")
+		}
+	})
+}
@@ -5,15 +5,24 @@ package gauss
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"math"
+	"math/big"
 )
 
 var lsOne float64 = 1 << 31
 
+// tailCut bounds the support of the sampled distribution to
+// [-tailCut sigma, tailCut sigma], the mass outside is below 2^-100.
+// Bernoulli quantizes p to 2^-31 which would cut the support near 6.6 sigma
+// so Sample accepts with bernoulliExact instead.
+const tailCut = 13
+
 // Bernoulli returns a random 1/0 drawn from Bernoulli Distribution.
 func Bernoulli(p float64) uint {
 	// discretize the p value by doing a multiple by left shift
 	pInt := uint32(p * lsOne)
-	x := RandInt(32)
+	// draw as many bits as the discretization so that p = 1 always succeeds
+	x := RandInt(31)
 	if x < pInt {
 		return 1
 	}
@@ -36,3 +45,61 @@ func RandInt(bitlength uint32) uint32 {
 	x := binary.LittleEndian.Uint32(b)
 	return mask & x
 }
+
+// bernoulliExact returns true with probability exactly p, p = m 2^(e-53)
+// with a 53 bit integer m is compared to a uniform real u in [0, 1) whose
+// bits are drawn lazily, the first differing bit decides u < p and two
+// bits are read on average.
+func bernoulliExact(p float64) bool {
+	if p >= 1 {
+		return true
+	}
+	if p <= 0 {
+		return false
+	}
+	frac, e := math.Frexp(p)
+	m := uint64(frac * (1 << 53))
+	b := make([]byte, 8)
+	var word uint64
+	avail := 0
+	// the bit of weight 2^-i of p is the bit 53 - e - i of m
+	for j := 53 - e - 1; j >= 0; j-- {
+		if avail == 0 {
+			if _, err := rand.Read(b); err != nil {
+				panic(err)
+			}
+			word, avail = binary.LittleEndian.Uint64(b), 64
+		}
+		u := word & 1
+		word >>= 1
+		avail--
+		var pBit uint64
+		if j < 53 {
+			pBit = m >> uint(j) & 1
+		}
+		if u != pBit {
+			return u < pBit
+		}
+	}
+	// the remaining bits of p are zero so u >= p
+	return false
+}
+
+// Sample returns an integer drawn from the discrete gaussian distribution
+// centered at zero with parameter sigma, i.e the probability of x is
+// proportional to exp(-x^2 / 2 sigma^2). An integer x drawn uniformly from
+// the tail cut support is accepted with probability exp(-x^2 / 2 sigma^2).
+func Sample(sigma float64) int64 {
+	bound := int64(math.Ceil(tailCut * sigma))
+	width := big.NewInt(2*bound + 1)
+	for {
+		u, err := rand.Int(rand.Reader, width)
+		if err != nil {
+			panic(err)
+		}
+		x := u.Int64() - bound
+		if bernoulliExact(math.Exp(-float64(x*x) / (2 * sigma * sigma))) {
+			return x
+		}
+	}
+}
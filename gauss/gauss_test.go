@@ -0,0 +1,60 @@
+package gauss
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGauss(t *testing.T) {
+	t.Run("TestBernoulli", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			if Bernoulli(1) != 1 {
+				t.Fatal("Bernoulli(1) != 1")
+			}
+			if Bernoulli(0) != 0 {
+				t.Fatal("Bernoulli(0) != 0")
+			}
+			if !bernoulliExact(1) || bernoulliExact(0) {
+				t.Fatal("bernoulliExact(1) != true or bernoulliExact(0) != false")
+			}
+		}
+		// the empirical frequency is within 5 standard deviations of p
+		const n = 20000
+		for _, p := range []float64{0.5, 0.3, 0.01, 1 - 1.0/1024} {
+			hits, exact := 0, 0
+			for i := 0; i < n; i++ {
+				hits += int(Bernoulli(p))
+				if bernoulliExact(p) {
+					exact++
+				}
+			}
+			tol := 5 * math.Sqrt(p*(1-p)/n)
+			if math.Abs(float64(hits)/n-p) > tol || math.Abs(float64(exact)/n-p) > tol {
+				t.Errorf("frequencies %v and %v are too far from %v", float64(hits)/n, float64(exact)/n, p)
+			}
+		}
+	})
+	t.Run("TestSample", func(t *testing.T) {
+		const n = 20000
+		for _, sigma := range []float64{1.5, 3.2, 8} {
+			var sum, sq float64
+			for i := 0; i < n; i++ {
+				x := float64(Sample(sigma))
+				if math.Abs(x) > math.Ceil(tailCut*sigma) {
+					t.Fatal("sample outside of the tail cut support", x)
+				}
+				sum += x
+				sq += x * x
+			}
+			mean := sum / n
+			variance := sq/n - mean*mean
+			// the standard errors are sigma/sqrt(n) and sigma^2 sqrt(2/n)
+			if math.Abs(mean) > 5*sigma/math.Sqrt(n) {
+				t.Errorf("mean %v isn't close to 0 for sigma = %v", mean, sigma)
+			}
+			if math.Abs(variance-sigma*sigma) > 5*sigma*sigma*math.Sqrt(2.0/n) {
+				t.Errorf("variance %v isn't close to %v for sigma = %v", variance, sigma*sigma, sigma)
+			}
+		}
+	})
+}
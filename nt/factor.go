@@ -23,3 +23,100 @@ func PollardRho(n *Integer) *Integer {
 	}
 
 }
+
+// PrimeFactors returns the distinct prime factors of n > 0 in increasing
+// order, small factors are removed by trial division and the remaining
+// cofactor is split with Brent's variant of Pollard's rho.
+// Factoring is only practical when the cofactor has no two large prime factors.
+func PrimeFactors(n *Integer) []*Integer {
+	r := new(Integer).Abs(n)
+	if r.Sign() == 0 {
+		return nil
+	}
+	var factors []*Integer
+	rem := new(Integer)
+	for d := int64(2); d < 1<<12; d++ {
+		div := FromInt64(d)
+		if rem.Mod(r, div).Sign() == 0 {
+			factors = append(factors, div)
+			for rem.Mod(r, div).Sign() == 0 {
+				r.Div(r, div)
+			}
+		}
+		if Mul(div, div).Cmp(r) > 0 {
+			break
+		}
+	}
+	if r.Cmp(One) > 0 {
+		for _, q := range splitPrimes(r) {
+			if len(factors) == 0 || !Equal(factors[len(factors)-1], q) {
+				factors = append(factors, q)
+			}
+		}
+	}
+	return factors
+}
+
+// splitPrimes returns the sorted distinct prime factors of n
+func splitPrimes(n *Integer) []*Integer {
+	if IsPrime(n) {
+		return []*Integer{new(Integer).Set(n)}
+	}
+	var d *Integer
+	for c := int64(1); d == nil; c++ {
+		d = brent(n, FromInt64(c))
+	}
+	left := splitPrimes(d)
+	right := splitPrimes(Div(n, d))
+	// merge the two sorted lists
+	var res []*Integer
+	for len(left) > 0 || len(right) > 0 {
+		var next *Integer
+		switch {
+		case len(right) == 0 || (len(left) > 0 && left[0].Cmp(right[0]) < 0):
+			next, left = left[0], left[1:]
+		case len(left) == 0 || right[0].Cmp(left[0]) < 0:
+			next, right = right[0], right[1:]
+		default:
+			next, left, right = left[0], left[1:], right[1:]
+		}
+		res = append(res, next)
+	}
+	return res
+}
+
+// brent looks for a non trivial factor of n iterating x -> x^2 + c,
+// it returns nil when the cycle closes without finding one.
+func brent(n, c *Integer) *Integer {
+	y, x, ys := FromInt64(2), new(Integer), new(Integer)
+	q, g := FromInt64(1), FromInt64(1)
+	const m = 128
+	for r := 1; g.Cmp(One) == 0; r <<= 1 {
+		x.Set(y)
+		for i := 0; i < r; i++ {
+			y = ModAdd(ModMul(y, y, n), c, n)
+		}
+		for k := 0; k < r && g.Cmp(One) == 0; k += m {
+			ys.Set(y)
+			for i := 0; i < m && i < r-k; i++ {
+				y = ModAdd(ModMul(y, y, n), c, n)
+				q = ModMul(q, new(Integer).Abs(Sub(x, y)), n)
+			}
+			g = GCD(q, n)
+		}
+	}
+	if g.Cmp(n) == 0 {
+		// the batched gcd overshot, backtrack one step at a time
+		for {
+			ys = ModAdd(ModMul(ys, ys, n), c, n)
+			g = GCD(new(Integer).Abs(Sub(x, ys)), n)
+			if g.Cmp(One) > 0 {
+				break
+			}
+		}
+	}
+	if g.Cmp(n) == 0 {
+		return nil
+	}
+	return g
+}
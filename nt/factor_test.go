@@ -0,0 +1,64 @@
+package nt
+
+import (
+	"testing"
+)
+
+// ints returns the integers of xs
+func ints(xs ...int64) []*Integer {
+	r := make([]*Integer, len(xs))
+	for i, x := range xs {
+		r[i] = FromInt64(x)
+	}
+	return r
+}
+
+func TestPrimeFactors(t *testing.T) {
+	// the largest primes below 2^32 are beyond trial division
+	p, q := FromInt64(4294967291), FromInt64(4294967279)
+	mersenne61 := Sub(new(Integer).Lsh(One, 61), One)
+	testCases := []struct {
+		name    string
+		n       *Integer
+		factors []*Integer
+	}{
+		{"One", FromInt64(1), nil},
+		{"Two", FromInt64(2), ints(2)},
+		{"SmallPrimes", FromInt64(360), ints(2, 3, 5)},
+		{"RepeatedPrimes", Mul(FromInt64(1<<10), FromInt64(243)), ints(2, 3)},
+		{"Negative", FromInt64(-360), ints(2, 3, 5)},
+		{"SmallPrime", FromInt64(4093), ints(4093)},
+		{"LargePrime", mersenne61, []*Integer{mersenne61}},
+		{"SemiPrime", Mul(p, q), []*Integer{q, p}},
+		{"SquareOfLargePrime", Mul(p, p), []*Integer{p}},
+		{"Mixed", Mul(FromInt64(12*4099), Mul(Mul(p, q), q)), []*Integer{FromInt64(2), FromInt64(3), FromInt64(4099), q, p}},
+	}
+	for _, tc := range testCases {
+		t.Run("Test"+tc.name, func(t *testing.T) {
+			factors := PrimeFactors(tc.n)
+			if len(factors) != len(tc.factors) {
+				t.Fatalf("expected %v got %v", tc.factors, factors)
+			}
+			for i := range factors {
+				if !Equal(factors[i], tc.factors[i]) {
+					t.Fatalf("expected %v got %v", tc.factors, factors)
+				}
+			}
+		})
+	}
+	t.Run("TestZero", func(t *testing.T) {
+		if PrimeFactors(FromInt64(0)) != nil {
+			t.Error("zero has no prime factorization")
+		}
+	})
+	t.Run("TestBrent", func(t *testing.T) {
+		n := Mul(p, q)
+		var d *Integer
+		for c := int64(1); d == nil; c++ {
+			d = brent(n, FromInt64(c))
+		}
+		if !Equal(d, p) && !Equal(d, q) {
+			t.Error("brent returned a trivial factor", d)
+		}
+	})
+}
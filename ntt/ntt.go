@@ -1 +1,118 @@
+// Package ntt implements the negacyclic number theoretic transform used to
+// multiply polynomials in Z_q[x]/(x^n + 1). The transform evaluates a
+// polynomial at the odd powers of psi a primitive 2n-th root of unity, then
+// a product in the ring is a pointwise product of the transforms.
+// ref : Speeding up the Number Theoretic Transform for Faster Ideal
+// Lattice-Based Cryptography (Longa, Naehrig)
 package ntt
+
+import (
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// montMul computes a b R^-1 mod q with Montgomery reduction where
+// R = 2^bitlength and b is given in Montgomery form b R mod q, which returns
+// a b mod q without a division by q.
+func (p *NTTParams) montMul(a, b *nt.Integer) *nt.Integer {
+	t := new(nt.Integer).Mul(a, b)
+	// m = (t mod R) q' mod R where q q' = -1 mod R
+	m := lowBits(t, uint(p.bitlength))
+	m = lowBits(m.Mul(m, p.qInv), uint(p.bitlength))
+	// t + mq is divisible by R
+	t.Add(t, m.Mul(m, &p.q))
+	t.Rsh(t, uint(p.bitlength))
+	if t.Cmp(&p.q) >= 0 {
+		t.Sub(t, &p.q)
+	}
+	return t
+}
+
+// lowBits returns x mod 2^k
+func lowBits(x *nt.Integer, k uint) *nt.Integer {
+	mask := new(nt.Integer).Lsh(nt.One, k)
+	mask.Sub(mask, nt.One)
+	return mask.And(mask, x)
+}
+
+// checkLength panics unless the vectors have exactly n coefficients, a
+// shorter one would be read out of range and a longer one only partly
+// transformed. Both transforms share it so they fail the same way.
+func checkLength(n int, vs ...int) {
+	for _, l := range vs {
+		if l != n {
+			panic(errLength)
+		}
+	}
+}
+
+// NTT computes in place the forward negacyclic transform of a, the
+// coefficients of a polynomial of degree < n, with Cooley-Tukey butterflies.
+// The twiddles are the bit reversed powers of psi in Montgomery form, the
+// input is in standard order and the output in bit reversed order.
+// It panics unless len(a) = n.
+func (p *NTTParams) NTT(a []*nt.Integer) {
+	q := &p.q
+	n := int(p.n)
+	checkLength(n, len(a))
+	t := n
+	for m := 1; m < n; m <<= 1 {
+		t >>= 1
+		for i := 0; i < m; i++ {
+			j1 := 2 * i * t
+			s := p.PsiRevMont[m+i]
+			for j := j1; j < j1+t; j++ {
+				u := nt.Mod(a[j], q)
+				v := p.montMul(nt.Mod(a[j+t], q), s)
+				a[j] = nt.ModAdd(u, v, q)
+				a[j+t] = nt.ModSub(u, v, q)
+			}
+		}
+	}
+}
+
+// InvNTT computes in place the inverse negacyclic transform of a with
+// Gentleman-Sande butterflies, the input is in bit reversed order and the
+// output in standard order. The scaling by n^-1 is applied at the end.
+// It panics unless len(a) = n.
+func (p *NTTParams) InvNTT(a []*nt.Integer) {
+	q := &p.q
+	n := int(p.n)
+	checkLength(n, len(a))
+	t := 1
+	for m := n; m > 1; m >>= 1 {
+		j1 := 0
+		h := m >> 1
+		for i := 0; i < h; i++ {
+			s := p.PsiInvRevMont[h+i]
+			for j := j1; j < j1+t; j++ {
+				u, v := nt.Mod(a[j], q), nt.Mod(a[j+t], q)
+				a[j] = nt.ModAdd(u, v, q)
+				a[j+t] = p.montMul(nt.ModSub(u, v, q), s)
+			}
+			j1 += 2 * t
+		}
+		t <<= 1
+	}
+	for j := range a {
+		a[j] = nt.ModMul(a[j], p.nRev, q)
+	}
+}
+
+// PolyMul returns the product of a and b in Z_q[x]/(x^n + 1), both are
+// coefficient vectors of length n and are left unchanged, other lengths panic.
+func (p *NTTParams) PolyMul(a, b []*nt.Integer) []*nt.Integer {
+	checkLength(int(p.n), len(a), len(b))
+	q := &p.q
+	x := make([]*nt.Integer, p.n)
+	y := make([]*nt.Integer, p.n)
+	for i := range x {
+		x[i], y[i] = nt.Mod(a[i], q), nt.Mod(b[i], q)
+	}
+	p.NTT(x)
+	p.NTT(y)
+	for i := range x {
+		x[i] = nt.ModMul(x[i], y[i], q)
+	}
+	p.InvNTT(x)
+	return x
+}
@@ -0,0 +1,265 @@
+package ntt
+
+import (
+	"math/bits"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// maxWordBits bounds the moduli of the word sized transform, lazy
+// butterflies keep values in [0, 4q) which must fit in 64 bits.
+const maxWordBits = 62
+
+// Transformer is implemented by both the big integer and the word sized
+// transforms.
+type Transformer interface {
+	NTT(a []*nt.Integer)
+	InvNTT(a []*nt.Integer)
+	PolyMul(a, b []*nt.Integer) []*nt.Integer
+}
+
+// New returns the word sized transform when q fits in 62 bits and the big
+// integer one otherwise.
+func New(N int64, Q nt.Integer) (Transformer, error) {
+	if Q.BitLen() <= maxWordBits {
+		p, err := GenParams64(N, Q.Uint64())
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	p, err := GenParams(N, Q)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NTTParams64 holds the parameters of the negacyclic transform for moduli
+// under 2^62 with every twiddle w stored next to its Shoup quotient
+// floor(w 2^64 / q) so that butterflies multiply without a division.
+// ref : Faster arithmetic for number-theoretic transforms (Harvey)
+type NTTParams64 struct {
+	n int
+	q uint64
+	// qInv = -q^-1 mod 2^64 and r2 = 2^128 mod q for Montgomery products
+	qInv, r2                  uint64
+	psiRev, psiRevShoup       []uint64
+	psiInvRev, psiInvRevShoup []uint64
+	nInv, nInvShoup           uint64
+}
+
+// GenParams64 generates the word sized parameters for NTT and Inverse NTT
+func GenParams64(N int64, Q uint64) (*NTTParams64, error) {
+	if bits.Len64(Q) > maxWordBits {
+		return nil, errModulus
+	}
+	params, err := GenParams(N, *new(nt.Integer).SetUint64(Q))
+	if err != nil {
+		return nil, err
+	}
+	return genParams64(params), nil
+}
+
+// GenParams64WithRoot generates the word sized parameters for a given
+// primitive 2N-th root of unity psi
+func GenParams64WithRoot(N int64, Q, psi uint64) (*NTTParams64, error) {
+	if bits.Len64(Q) > maxWordBits {
+		return nil, errModulus
+	}
+	params, err := GenParamsWithRoot(N, *new(nt.Integer).SetUint64(Q), new(nt.Integer).SetUint64(psi))
+	if err != nil {
+		return nil, err
+	}
+	return genParams64(params), nil
+}
+
+// genParams64 converts the tables of params to words
+func genParams64(params *NTTParams) *NTTParams64 {
+	N, Q := params.n, params.q.Uint64()
+	p := &NTTParams64{
+		n:              int(N),
+		q:              Q,
+		psiRev:         make([]uint64, N),
+		psiRevShoup:    make([]uint64, N),
+		psiInvRev:      make([]uint64, N),
+		psiInvRevShoup: make([]uint64, N),
+	}
+	for i := range p.psiRev {
+		p.psiRev[i] = params.PsiRev[i].Uint64()
+		p.psiRevShoup[i] = shoup(p.psiRev[i], Q)
+		p.psiInvRev[i] = params.PsiInvRev[i].Uint64()
+		p.psiInvRevShoup[i] = shoup(p.psiInvRev[i], Q)
+	}
+	p.nInv = params.nRev.Uint64()
+	p.nInvShoup = shoup(p.nInv, Q)
+	// Newton iteration x = x(2 - qx) doubles the number of correct low bits
+	// of q^-1 starting from 3 bits since q q = 1 mod 8
+	inv := Q
+	for i := 0; i < 5; i++ {
+		inv *= 2 - Q*inv
+	}
+	p.qInv = -inv
+	r2 := new(nt.Integer).Lsh(nt.One, 128)
+	p.r2 = r2.Mod(r2, &params.q).Uint64()
+	return p
+}
+
+// shoup returns floor(w 2^64 / q) for w < q
+func shoup(w, q uint64) uint64 {
+	quo, _ := bits.Div64(w, 0, q)
+	return quo
+}
+
+// mulShoup returns a w mod q in [0, 2q) where wp = floor(w 2^64 / q), the
+// quotient estimate floor(a wp / 2^64) is off by at most one.
+func mulShoup(a, w, wp, q uint64) uint64 {
+	hi, _ := bits.Mul64(a, wp)
+	return a*w - hi*q
+}
+
+// montMul returns a b 2^-64 mod q for a b < q 2^64
+func (p *NTTParams64) montMul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	m := lo * p.qInv
+	mhi, mlo := bits.Mul64(m, p.q)
+	// lo + mlo = 0 mod 2^64 so only its carry matters
+	_, carry := bits.Add64(lo, mlo, 0)
+	t := hi + mhi + carry
+	if t >= p.q {
+		t -= p.q
+	}
+	return t
+}
+
+// mulMod returns a b mod q with two Montgomery products
+func (p *NTTParams64) mulMod(a, b uint64) uint64 {
+	return p.montMul(p.montMul(a, b), p.r2)
+}
+
+// NTT64 computes in place the forward negacyclic transform of a with
+// coefficients in [0, q), the butterflies reduce lazily and keep values in
+// [0, 4q) until a final correction. It panics unless len(a) = n.
+func (p *NTTParams64) NTT64(a []uint64) {
+	q, q2 := p.q, 2*p.q
+	n := p.n
+	checkLength(n, len(a))
+	t := n
+	for m := 1; m < n; m <<= 1 {
+		t >>= 1
+		for i := 0; i < m; i++ {
+			j1 := 2 * i * t
+			w, wp := p.psiRev[m+i], p.psiRevShoup[m+i]
+			for j := j1; j < j1+t; j++ {
+				x := a[j]
+				if x >= q2 {
+					x -= q2
+				}
+				y := mulShoup(a[j+t], w, wp, q)
+				a[j] = x + y
+				a[j+t] = x - y + q2
+			}
+		}
+	}
+	for j := range a {
+		if a[j] >= q2 {
+			a[j] -= q2
+		}
+		if a[j] >= q {
+			a[j] -= q
+		}
+	}
+}
+
+// InvNTT64 computes in place the inverse negacyclic transform of a with
+// coefficients in [0, 2q), values stay in [0, 2q) through the butterflies.
+// It panics unless len(a) = n.
+func (p *NTTParams64) InvNTT64(a []uint64) {
+	q, q2 := p.q, 2*p.q
+	n := p.n
+	checkLength(n, len(a))
+	t := 1
+	for m := n; m > 1; m >>= 1 {
+		j1 := 0
+		h := m >> 1
+		for i := 0; i < h; i++ {
+			w, wp := p.psiInvRev[h+i], p.psiInvRevShoup[h+i]
+			for j := j1; j < j1+t; j++ {
+				x, y := a[j], a[j+t]
+				s := x + y
+				if s >= q2 {
+					s -= q2
+				}
+				a[j] = s
+				a[j+t] = mulShoup(x-y+q2, w, wp, q)
+			}
+			j1 += 2 * t
+		}
+		t <<= 1
+	}
+	for j := range a {
+		v := mulShoup(a[j], p.nInv, p.nInvShoup, q)
+		if v >= q {
+			v -= q
+		}
+		a[j] = v
+	}
+}
+
+// PolyMul64 returns the product of a and b in Z_q[x]/(x^n + 1), both must
+// have length n.
+func (p *NTTParams64) PolyMul64(a, b []uint64) []uint64 {
+	checkLength(p.n, len(a), len(b))
+	x := make([]uint64, p.n)
+	y := make([]uint64, p.n)
+	for i := range x {
+		x[i], y[i] = a[i]%p.q, b[i]%p.q
+	}
+	p.NTT64(x)
+	p.NTT64(y)
+	for i := range x {
+		x[i] = p.mulMod(x[i], y[i])
+	}
+	p.InvNTT64(x)
+	return x
+}
+
+// toWords reduces a to words in [0, q)
+func (p *NTTParams64) toWords(a []*nt.Integer) []uint64 {
+	q := new(nt.Integer).SetUint64(p.q)
+	w := make([]uint64, len(a))
+	for i := range a {
+		w[i] = nt.Mod(a[i], q).Uint64()
+	}
+	return w
+}
+
+// fromWords writes back w into a
+func fromWords(a []*nt.Integer, w []uint64) {
+	for i := range w {
+		a[i] = new(nt.Integer).SetUint64(w[i])
+	}
+}
+
+// NTT computes in place the forward transform of a using word arithmetic
+func (p *NTTParams64) NTT(a []*nt.Integer) {
+	w := p.toWords(a)
+	p.NTT64(w)
+	fromWords(a, w)
+}
+
+// InvNTT computes in place the inverse transform of a using word arithmetic
+func (p *NTTParams64) InvNTT(a []*nt.Integer) {
+	w := p.toWords(a)
+	p.InvNTT64(w)
+	fromWords(a, w)
+}
+
+// PolyMul returns the product of a and b in Z_q[x]/(x^n + 1) using word
+// arithmetic
+func (p *NTTParams64) PolyMul(a, b []*nt.Integer) []*nt.Integer {
+	c := p.PolyMul64(p.toWords(a), p.toWords(b))
+	out := make([]*nt.Integer, len(c))
+	fromWords(out, c)
+	return out
+}
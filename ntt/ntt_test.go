@@ -0,0 +1,248 @@
+package ntt
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// schoolbook multiplies a and b in Z_q[x]/(x^n + 1) using x^n = -1
+func schoolbook(a, b []*nt.Integer, q *nt.Integer) []*nt.Integer {
+	n := len(a)
+	c := make([]*nt.Integer, n)
+	for i := range c {
+		c[i] = nt.FromInt64(0)
+	}
+	for i := range a {
+		for j := range b {
+			k := i + j
+			if k < n {
+				c[k] = nt.ModAdd(c[k], nt.Mul(a[i], b[j]), q)
+			} else {
+				c[k-n] = nt.ModSub(c[k-n], nt.Mul(a[i], b[j]), q)
+			}
+		}
+	}
+	return c
+}
+
+func randomVector(t *testing.T, n int64, q *nt.Integer) []*nt.Integer {
+	v := make([]*nt.Integer, n)
+	for i := range v {
+		x, err := rand.Int(rand.Reader, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v[i] = x
+	}
+	return v
+}
+
+var nttVec = []struct {
+	n int64
+	q *nt.Integer
+}{
+	{8, nt.FromInt64(17)},
+	{256, nt.FromInt64(7681)},
+	{512, nt.FromInt64(12289)},
+	{1024, nt.FromInt64(12289)},
+}
+
+func TestNTT(t *testing.T) {
+	t.Run("TestRoundTrip", func(t *testing.T) {
+		for _, v := range nttVec {
+			p, err := GenParams(v.n, *v.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := randomVector(t, v.n, v.q)
+			b := make([]*nt.Integer, v.n)
+			copy(b, a)
+			p.NTT(b)
+			p.InvNTT(b)
+			for i := range a {
+				if !nt.Equal(a[i], b[i]) {
+					t.Fatalf("InvNTT(NTT(a)) != a for n = %d q = %v", v.n, v.q)
+				}
+			}
+		}
+	})
+	t.Run("TestEvaluation", func(t *testing.T) {
+		// NTT(x)[bitrev(i)] = psi^(2i+1) where psi is the first twiddle
+		// after 1 in the bit reversed table i.e PsiRev[n/2] = psi
+		p, _ := GenParams(8, *nt.FromInt64(17))
+		psi := p.PsiRev[4]
+		x := []*nt.Integer{nt.FromInt64(0), nt.FromInt64(1)}
+		for len(x) < 8 {
+			x = append(x, nt.FromInt64(0))
+		}
+		p.NTT(x)
+		for i := int64(0); i < 8; i++ {
+			want := nt.ModExp(psi, nt.FromInt64(2*i+1), nt.FromInt64(17))
+			if !nt.Equal(x[bitRev(i, 3)], want) {
+				t.Errorf("NTT doesn't evaluate at the odd powers of psi got %v expected %v", x[bitRev(i, 3)], want)
+			}
+		}
+	})
+	t.Run("TestPolyMul", func(t *testing.T) {
+		for _, v := range nttVec {
+			p, err := GenParams(v.n, *v.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := randomVector(t, v.n, v.q)
+			b := randomVector(t, v.n, v.q)
+			got := p.PolyMul(a, b)
+			want := schoolbook(a, b, v.q)
+			for i := range want {
+				if !nt.Equal(got[i], want[i]) {
+					t.Fatalf("NTT product doesn't match schoolbook for n = %d q = %v", v.n, v.q)
+				}
+			}
+		}
+	})
+}
+
+// q = 2^17 k + 1 with k smooth is a 61 bit NTT friendly prime
+var wordPrime = nt.FromInt64(1152921630393499649)
+
+func TestNTT64(t *testing.T) {
+	vec := append(nttVec, struct {
+		n int64
+		q *nt.Integer
+	}{1024, wordPrime})
+	t.Run("TestMatchesBigInt", func(t *testing.T) {
+		for _, v := range vec {
+			p, err := GenParams(v.n, *v.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p64, err := GenParams64(v.n, v.q.Uint64())
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := randomVector(t, v.n, v.q)
+			b := make([]*nt.Integer, v.n)
+			copy(b, a)
+			p.NTT(a)
+			p64.NTT(b)
+			for i := range a {
+				if !nt.Equal(a[i], b[i]) {
+					t.Fatalf("word sized NTT doesn't match for n = %d q = %v", v.n, v.q)
+				}
+			}
+			p64.InvNTT(b)
+			p.InvNTT(a)
+			for i := range a {
+				if !nt.Equal(a[i], b[i]) {
+					t.Fatalf("word sized InvNTT doesn't match for n = %d q = %v", v.n, v.q)
+				}
+			}
+		}
+	})
+	t.Run("TestPolyMul", func(t *testing.T) {
+		for _, v := range vec {
+			p64, err := GenParams64(v.n, v.q.Uint64())
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := randomVector(t, v.n, v.q)
+			b := randomVector(t, v.n, v.q)
+			got := p64.PolyMul(a, b)
+			want := schoolbook(a, b, v.q)
+			for i := range want {
+				if !nt.Equal(got[i], want[i]) {
+					t.Fatalf("word sized product doesn't match schoolbook for n = %d q = %v", v.n, v.q)
+				}
+			}
+		}
+	})
+	t.Run("TestMontgomery", func(t *testing.T) {
+		q := wordPrime.Uint64()
+		p, _ := GenParams64(8, q)
+		for _, x := range [][2]uint64{{0, 5}, {1, 1}, {q - 1, q - 1}, {q - 2, 12345678901234567}} {
+			want := nt.ModMul(new(nt.Integer).SetUint64(x[0]), new(nt.Integer).SetUint64(x[1]), wordPrime)
+			if got := p.mulMod(x[0], x[1]); got != want.Uint64() {
+				t.Errorf("mulMod(%d, %d) = %d expected %v", x[0], x[1], got, want)
+			}
+		}
+	})
+	t.Run("TestNew", func(t *testing.T) {
+		for _, q := range []*nt.Integer{wordPrime, nt.FromInt64(7681)} {
+			p, err := New(256, *q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := p.(*NTTParams64); !ok {
+				t.Error("word sized moduli should use the fast path")
+			}
+		}
+		// 2^64 - 2^32 + 1 is NTT friendly but doesn't fit the lazy reduction
+		q, _ := new(nt.Integer).SetString("18446744069414584321", 10)
+		p, err := New(256, *q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := p.(*NTTParams); !ok {
+			t.Error("moduli over 62 bits should use big integers")
+		}
+		if _, err := New(256, *nt.FromInt64(7682)); err == nil {
+			t.Error("composite moduli should be rejected")
+		}
+	})
+	t.Run("TestLength", func(t *testing.T) {
+		// both transforms reject vectors whose length isn't n the same way
+		panics := func(f func()) (err interface{}) {
+			defer func() { err = recover() }()
+			f()
+			return nil
+		}
+		q := nt.FromInt64(7681)
+		p, _ := GenParams(256, *q)
+		p64, _ := GenParams64(256, q.Uint64())
+		for _, n := range []int64{128, 255, 257, 512} {
+			for _, tr := range []Transformer{p, p64} {
+				a := randomVector(t, n, q)
+				b := randomVector(t, 256, q)
+				for _, f := range []func(){
+					func() { tr.NTT(a) },
+					func() { tr.InvNTT(a) },
+					func() { tr.PolyMul(a, b) },
+					func() { tr.PolyMul(b, a) },
+				} {
+					if err := panics(f); err != errLength {
+						t.Errorf("length %d should panic with %v got %v", n, errLength, err)
+					}
+				}
+			}
+			if err := panics(func() { p64.NTT64(make([]uint64, n)) }); err != errLength {
+				t.Errorf("NTT64 of length %d should panic with %v got %v", n, errLength, err)
+			}
+		}
+	})
+}
+
+func BenchmarkNTT(b *testing.B) {
+	p, _ := GenParams(1024, *wordPrime)
+	a := make([]*nt.Integer, 1024)
+	for i := range a {
+		a[i] = nt.FromInt64(int64(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.NTT(a)
+	}
+}
+
+func BenchmarkNTT64(b *testing.B) {
+	p, _ := GenParams64(1024, wordPrime.Uint64())
+	a := make([]uint64, 1024)
+	for i := range a {
+		a[i] = uint64(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.NTT64(a)
+	}
+}
@@ -1,15 +1,24 @@
 package ntt
 
 import (
+	"errors"
 	"math/big"
 
 	"github.com/actuallyachraf/algebra/nt"
 )
 
+var (
+	errDimension = errors.New("N must be a power of two")
+	errModulus   = errors.New("Q must be a prime")
+	errNoRoot    = errors.New("order of the root must divide Q - 1")
+	errNoPrime   = errors.New("not enough NTT friendly primes of the requested size")
+	errLength    = errors.New("vector length must be N")
+)
+
 // NTTParams defines the parameters of the number-theoretic transform.
 type NTTParams struct {
 	n             int64
-	nRev          uint64
+	nRev          *nt.Integer
 	bitlength     uint64
 	q             nt.Integer
 	qInv          *nt.Integer
@@ -19,25 +28,48 @@ type NTTParams struct {
 	PsiInvRevMont []*nt.Integer
 }
 
-// GenParams generates the parameters for NTT and Inverse NTT.
-func GenParams(N int64, Q nt.Integer) *NTTParams {
+// GenParams generates the parameters for NTT and Inverse NTT, N must be a
+// power of two and Q a prime with 2N dividing Q - 1.
+func GenParams(N int64, Q nt.Integer) (*NTTParams, error) {
+	if N < 1 || N&(N-1) != 0 {
+		return nil, errDimension
+	}
+	// compute the 2-nth root of unity and its inverse
+	// psi^n = -1 mod q
+	// psiInv = psi^-1 mod q
+	psi, err := PrimitiveRootOfUnity(2*N, &Q)
+	if err != nil {
+		return nil, err
+	}
+	return genParams(N, Q, psi), nil
+}
+
+// GenParamsWithRoot generates the parameters for a given primitive 2N-th
+// root of unity psi i.e psi^N = -1 mod Q, standards such as ML-KEM and
+// ML-DSA fix psi so that transformed values match bit for bit.
+func GenParamsWithRoot(N int64, Q nt.Integer, psi *nt.Integer) (*NTTParams, error) {
+	if N < 1 || N&(N-1) != 0 {
+		return nil, errDimension
+	}
+	if Q.Cmp(nt.FromInt64(2)) < 0 || !Q.ProbablyPrime(20) {
+		return nil, errModulus
+	}
+	// N is a power of two so psi^N = -1 makes psi of order exactly 2N
+	if nt.ModExp(psi, nt.FromInt64(N), &Q).Cmp(nt.Sub(&Q, nt.One)) != 0 {
+		return nil, errNoRoot
+	}
+	return genParams(N, Q, nt.Mod(psi, &Q)), nil
+}
+
+// genParams computes the tables of the transform for the root psi
+func genParams(N int64, Q nt.Integer, psi *nt.Integer) *NTTParams {
+	psiInv := nt.ModInv(psi, &Q)
 	// setting up initial parameters
 	var nttParams = &NTTParams{
 		n:    N,
-		nRev: nt.ModInv(nt.FromInt64(int64(N)), &Q).Uint64(),
+		nRev: nt.ModInv(nt.FromInt64(N), &Q),
 		q:    Q,
 	}
-	// computing Psi terms
-	g := primitiveRoot(&Q)
-	fi := nt.Sub(&Q, nt.One)
-
-	// compute the 2-nth root of unity and its inverse
-	// psi = g^(fi/2n) mod q
-	// psiInv = psi^-1 mod q
-	twoN := nt.FromInt64(2 * N)
-	exp := nt.Div(fi, twoN)
-	psi := nt.ModExp(g, exp, &Q)
-	psiInv := nt.ModInv(psi, &Q)
 	// compute the powers of psi and psiInv
 	nttParams.PsiRev = make([]*nt.Integer, N)
 	nttParams.PsiInvRev = make([]*nt.Integer, N)
@@ -78,6 +110,71 @@ func GenParams(N int64, Q nt.Integer) *NTTParams {
 	return nttParams
 }
 
+// PrimitiveRootOfUnity returns a primitive root of unity of the given order
+// modulo the prime q i.e w^order = 1 and w^(order/p) != 1 for every prime p
+// dividing order. Only the order is factored, which unlike finding a
+// generator of Zq* stays cheap for large q.
+func PrimitiveRootOfUnity(order int64, q *nt.Integer) (*nt.Integer, error) {
+	if q.Cmp(nt.FromInt64(2)) < 0 || !q.ProbablyPrime(20) {
+		return nil, errModulus
+	}
+	qMinusOne := nt.Sub(q, nt.One)
+	n := nt.FromInt64(order)
+	if order < 1 || nt.Mod(qMinusOne, n).Sign() != 0 {
+		return nil, errNoRoot
+	}
+	factors := getFactors(n)
+	cofactor := nt.Div(qMinusOne, n)
+	// w = g^((q-1)/order) has order dividing order for every g, a fraction
+	// phi(order)/order of the choices of g gives it exactly that order
+	for g := nt.FromInt64(2); ; g = nt.Add(g, nt.One) {
+		if g.Cmp(q) >= 0 {
+			// only reachable for q = 2 and order = 1
+			return nt.FromInt64(1), nil
+		}
+		w := nt.ModExp(g, cofactor, q)
+		primitive := true
+		for _, p := range factors {
+			if nt.ModExp(w, nt.Div(n, p), q).Cmp(nt.One) == 0 {
+				primitive = false
+				break
+			}
+		}
+		if primitive {
+			return w, nil
+		}
+	}
+}
+
+// GenPrimes returns count distinct primes of the given bit size with
+// q = 1 mod 2N in decreasing order starting from 2^bits, such primes support
+// the negacyclic transform of size N.
+func GenPrimes(bits int, N int64, count int) ([]*nt.Integer, error) {
+	if N < 1 || N&(N-1) != 0 {
+		return nil, errDimension
+	}
+	if bits < 2 {
+		return nil, errNoPrime
+	}
+	twoN := nt.FromInt64(2 * N)
+	lo := new(nt.Integer).Lsh(nt.One, uint(bits-1))
+	// largest q = 1 mod 2N below 2^bits
+	q := new(nt.Integer).Lsh(nt.One, uint(bits))
+	q.Sub(q, nt.One)
+	q.Sub(q, nt.Mod(nt.Sub(q, nt.One), twoN))
+	var primes []*nt.Integer
+	for len(primes) < count && q.Cmp(lo) > 0 {
+		if q.ProbablyPrime(20) {
+			primes = append(primes, new(nt.Integer).Set(q))
+		}
+		q.Sub(q, twoN)
+	}
+	if len(primes) < count {
+		return nil, errNoPrime
+	}
+	return primes, nil
+}
+
 // bitRev calculates the bit-reverse index.
 func bitRev(index int64, bitLen uint32) uint32 {
 	indexReverse := uint32(0)
@@ -89,81 +186,9 @@ func bitRev(index int64, bitLen uint32) uint32 {
 	return indexReverse
 }
 
-// polynomialPollardsRho calculates x1^2 + c mod x2, and is used in factorizationPollardsRho
-func polynomialPollardsRho(x1, x2, c *nt.Integer) *nt.Integer {
-	two := nt.FromInt64(2)
-	z := new(nt.Integer).Exp(x1, two, x2) // x1^2 mod x2
-	z.Add(z, c)                           // (x1^2 mod x2) + 1
-	z.Mod(z, x2)                          // (x1^2 + 1) mod x2
-	return z
-}
-
-// factorizationPollardsRho realizes Pollard's Rho algorithm for fast prime factorization,
-// but this function only returns one factor a time
-func factorizationPollardsRho(m *nt.Integer) *nt.Integer {
-	var x, y, d, c *nt.Integer
-	zero := nt.Zero
-	one := nt.One
-	ten := nt.FromInt64(10)
-
-	// c is to change the polynomial used in Pollard's Rho algorithm,
-	// Every time the algorithm fails to get a factor, increasing c to retry,
-	// because Pollard's Rho algorithm sometimes will miss some small prime factors.
-	for c = nt.FromInt64(1); !nt.Equal(c, ten); c.Add(c, one) {
-		x, y, d = nt.FromInt64(2), nt.FromInt64(2), nt.FromInt64(1)
-		for d.Cmp(zero) != 0 {
-			x = polynomialPollardsRho(x, m, c)
-			y = polynomialPollardsRho(polynomialPollardsRho(y, m, c), m, c)
-			sub := nt.Sub(x, y)
-			d.GCD(nil, nil, sub.Abs(sub), m)
-			if d.Cmp(one) == 1 {
-				return d
-			}
-		}
-	}
-	return d
-}
-
 // getFactors returns all the prime factors of m
 func getFactors(n *nt.Integer) []*nt.Integer {
-	var factor *nt.Integer
-	var factors []*nt.Integer
-	var m, tmp nt.Integer
-	m.Set(n)
-	zero := nt.Zero
-	one := nt.One
-
-	// first, append small prime factors
-	for i := range smallPrimes {
-		smallPrime := nt.FromInt64(smallPrimes[i])
-		addFactor := false
-		for tmp.Mod(&m, smallPrime).Cmp(zero) == 0 {
-			m.Div(&m, smallPrime)
-			addFactor = true
-		}
-		if addFactor {
-			factors = append(factors, smallPrime)
-		}
-	}
-
-	if m.Cmp(one) == 0 {
-		return factors
-	}
-
-	// second, find other prime factors
-	for {
-		factor = factorizationPollardsRho(&m)
-		if factor.Cmp(zero) == 0 {
-			factors = append(factors, &m)
-			break
-		}
-		m.Div(&m, factor)
-		if len(factors) > 0 && factor.Cmp(factors[len(factors)-1]) == 0 {
-			continue
-		}
-		factors = append(factors, factor)
-	}
-	return factors
+	return nt.PrimeFactors(n)
 }
 
 // primitiveRoot calculates one primitive root of prime q
@@ -188,8 +213,3 @@ func primitiveRoot(q *nt.Integer) *nt.Integer {
 	}
 	return g
 }
-
-var smallPrimes = []int64{
-	2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 73, 79, 83, 89, 97,
-	101, 103, 107, 109, 113, 127, 131, 139, 149, 151, 163, 167, 173, 179, 181, 191, 193, 197, 199,
-}
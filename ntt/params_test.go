@@ -47,3 +47,118 @@ func TestPrimitiveRoot(t *testing.T) {
 		}
 	}
 }
+
+func TestGenParams(t *testing.T) {
+	invalid := []struct {
+		n int64
+		q *nt.Integer
+	}{
+		// N isn't a power of two
+		{6, nt.FromInt64(7681)},
+		// 2N doesn't divide q - 1
+		{1024, nt.FromInt64(7681)},
+		// q isn't prime
+		{8, nt.FromInt64(7697)},
+	}
+	for i, v := range invalid {
+		if _, err := GenParams(v.n, *v.q); err == nil {
+			t.Errorf("invalid parameters %d should be rejected", i)
+		}
+	}
+	p, err := GenParams(256, *nt.FromInt64(7681))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// psi is a primitive 512-th root of unity so psi^256 = -1
+	psi := p.PsiRev[bitRev(1, 8)]
+	if nt.ModExp(psi, nt.FromInt64(256), nt.FromInt64(7681)).Int64() != 7680 {
+		t.Error("psi isn't a primitive 2N-th root of unity")
+	}
+}
+
+func TestGenParamsWithRoot(t *testing.T) {
+	// the first twiddles of the ML-KEM and ML-DSA tables of FIPS 203 and 204
+	vec := []struct {
+		n        int64
+		q, psi   uint64
+		twiddles []uint64
+	}{
+		{128, 3329, 17, []uint64{1, 1729, 2580, 3289, 2642}},
+		{256, 8380417, 1753, []uint64{1, 4808194, 3765607, 3761513, 5178923}},
+	}
+	for _, v := range vec {
+		p, err := GenParams64WithRoot(v.n, v.q, v.psi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, w := range v.twiddles {
+			if p.psiRev[i] != w {
+				t.Errorf("twiddle %d modulo %d is %d expected %d", i, v.q, p.psiRev[i], w)
+			}
+		}
+	}
+	// 3 isn't a root of unity of order 256 modulo 3329
+	if _, err := GenParams64WithRoot(128, 3329, 3); err == nil {
+		t.Error("psi of the wrong order should be rejected")
+	}
+	if _, err := GenParamsWithRoot(96, *nt.FromInt64(3329), nt.FromInt64(17)); err == nil {
+		t.Error("N must be a power of two")
+	}
+}
+
+func TestPrimitiveRootOfUnity(t *testing.T) {
+	for _, testPair := range rootsVec {
+		for _, order := range []int64{1, 2, 256, 512, 7680} {
+			if nt.Mod(nt.Sub(testPair.q, nt.One), nt.FromInt64(order)).Sign() != 0 {
+				continue
+			}
+			w, err := PrimitiveRootOfUnity(order, testPair.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nt.ModExp(w, nt.FromInt64(order), testPair.q).Cmp(nt.One) != 0 {
+				t.Errorf("w^%d != 1 mod %v", order, testPair.q)
+			}
+			for _, p := range nt.PrimeFactors(nt.FromInt64(order)) {
+				if nt.ModExp(w, nt.Div(nt.FromInt64(order), p), testPair.q).Cmp(nt.One) == 0 {
+					t.Errorf("w of order %d mod %v isn't primitive", order, testPair.q)
+				}
+			}
+		}
+	}
+	if _, err := PrimitiveRootOfUnity(7, nt.FromInt64(7681)); err == nil {
+		t.Error("order not dividing q - 1 should be rejected")
+	}
+	if _, err := PrimitiveRootOfUnity(2, nt.FromInt64(7683)); err == nil {
+		t.Error("composite moduli should be rejected")
+	}
+}
+
+func TestGenPrimes(t *testing.T) {
+	for _, bits := range []int{30, 50, 61} {
+		primes, err := GenPrimes(bits, 4096, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, q := range primes {
+			if q.BitLen() != bits || !q.ProbablyPrime(20) {
+				t.Errorf("%v isn't a %d bit prime", q, bits)
+			}
+			if nt.Mod(q, nt.FromInt64(8192)).Cmp(nt.One) != 0 {
+				t.Errorf("%v isn't 1 mod 2N", q)
+			}
+			if i > 0 && q.Cmp(primes[i-1]) >= 0 {
+				t.Error("primes should be distinct and decreasing")
+			}
+			if _, err := GenParams(4096, *q); err != nil {
+				t.Errorf("generated prime %v should support the transform: %v", q, err)
+			}
+		}
+	}
+	if _, err := GenPrimes(12, 4096, 1); err == nil {
+		t.Error("no 12 bit prime is 1 mod 8192")
+	}
+	if _, err := GenPrimes(30, 1000, 1); err == nil {
+		t.Error("N must be a power of two")
+	}
+}
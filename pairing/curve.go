@@ -0,0 +1,172 @@
+package pairing
+
+import (
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+// family distinguishes the parametrized families of pairing-friendly curves
+type family int
+
+const (
+	// bnFamily are Barreto-Naehrig curves with p = 36u^4 + 36u^3 + 24u^2 + 6u + 1
+	bnFamily family = iota
+	// bls12Family are Barreto-Lynn-Scott curves with p = (u - 1)^2(u^4 - u^2 + 1)/3 + u
+	bls12Family
+)
+
+// Curve is a pairing-friendly curve E: y^2 = x^3 + b of embedding degree 12
+// with its groups G1 in E(Fp), G2 in the sextic twist E'(Fp2) and GT the
+// r-th roots of unity in Fp12.
+type Curve struct {
+	Name string
+	*Tower
+	G1 *G1
+	G2 *G2
+	// R is the prime order of G1, G2 and GT
+	R *nt.Integer
+	// U is the parameter of the family, p and r are polynomials in U
+	U      *nt.Integer
+	family family
+	// loop is the Miller loop scalar 6u + 2 for BN curves and |u| for BLS12
+	loop *nt.Integer
+}
+
+// evalInt evaluates the polynomial of integer coefficients c at u
+func evalInt(u *nt.Integer, c ...int) *nt.Integer {
+	return poly.NewPolynomialInts(c...).Eval(u, nil)
+}
+
+// BN254 returns the curve y^2 = x^3 + 3 with u = 4965661367192848881 used
+// by Ethereum precompiles (EIP-197), its twist is of D-type.
+func BN254() *Curve {
+	u := nt.FromInt64(4965661367192848881)
+	tw := BN254Tower()
+	fp, fp2 := tw.Fp, tw.Fp2
+	r := evalInt(u, 1, 6, 18, 36, 36)
+	// b' = 3/xi
+	xiInv, _ := fp2.Inv(tw.Fp6.Xi())
+	bTwist := fp2.MulByFp(xiInv, fp.NewFieldElementFromInt64(3))
+	// #E'(Fp2) = r(2p - r)
+	h2 := nt.Sub(nt.Mul(nt.FromInt64(2), fp.Modulus()), r)
+	c := &Curve{
+		Name:   "BN254",
+		Tower:  tw,
+		R:      r,
+		U:      u,
+		family: bnFamily,
+		loop:   evalInt(u, 2, 6),
+	}
+	c.G1 = &G1{
+		Fp:       fp,
+		B:        fp.NewFieldElementFromInt64(3),
+		R:        r,
+		Cofactor: nt.FromInt64(1),
+		gen:      G1Point{X: fp.NewFieldElementFromInt64(1), Y: fp.NewFieldElementFromInt64(2)},
+	}
+	c.G2 = &G2{
+		Fp2:      fp2,
+		B:        bTwist,
+		R:        r,
+		Cofactor: h2,
+		twist:    dType,
+		gamma:    &tw.Fp6.gamma,
+		gen: G2Point{
+			X: fp2.New(
+				fromDecimal("10857046999023057135944570762232829481370756359578518086990519993285655852781"),
+				fromDecimal("11559732032986387107991004021392285783925812861821192530917403151452391805634"),
+			),
+			Y: fp2.New(
+				fromDecimal("8495653923123431417604973247489272438418190587263600148770280649306958101930"),
+				fromDecimal("4082367875863433681332203403145435568316851327593401208105741076214120093531"),
+			),
+		},
+	}
+	return c
+}
+
+// BLS12381 returns the curve y^2 = x^3 + 4 with u = -0xd201000000010000,
+// its twist y^2 = x^3 + 4(1 + u) is of M-type.
+func BLS12381() *Curve {
+	u := new(nt.Integer).Neg(fromHex("d201000000010000"))
+	tw := BLS12381Tower()
+	fp, fp2 := tw.Fp, tw.Fp2
+	r := evalInt(u, 1, 0, -1, 0, 1)
+	// h1 = (u - 1)^2/3 and h2 = (u^8 - 4u^7 + 5u^6 - 4u^4 + 6u^3 - 4u^2 - 4u + 13)/9
+	h1 := nt.Div(evalInt(u, 1, -2, 1), nt.FromInt64(3))
+	h2 := nt.Div(evalInt(u, 13, -4, -4, 6, -4, 0, 5, -4, 1), nt.FromInt64(9))
+	c := &Curve{
+		Name:   "BLS12-381",
+		Tower:  tw,
+		R:      r,
+		U:      u,
+		family: bls12Family,
+		loop:   new(nt.Integer).Neg(u),
+	}
+	c.G1 = &G1{
+		Fp:       fp,
+		B:        fp.NewFieldElementFromInt64(4),
+		R:        r,
+		Cofactor: h1,
+		gen: G1Point{
+			X: fp.NewFieldElement(fromHex("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")),
+			Y: fp.NewFieldElement(fromHex("08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1")),
+		},
+	}
+	c.G2 = &G2{
+		Fp2:      fp2,
+		B:        fp2.NewFromInt64(4, 4),
+		R:        r,
+		Cofactor: h2,
+		twist:    mType,
+		gamma:    &tw.Fp6.gamma,
+		gen: G2Point{
+			X: fp2.New(
+				fromHex("024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"),
+				fromHex("13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e"),
+			),
+			Y: fp2.New(
+				fromHex("0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801"),
+				fromHex("0606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be"),
+			),
+		},
+	}
+	return c
+}
+
+// FpSize returns the byte size of elements of the base field
+func (c *Curve) FpSize() int {
+	return (c.Fp.Modulus().BitLen() + 7) / 8
+}
+
+// AppendFp appends the integers xs to b big endian on FpSize bytes each
+func (c *Curve) AppendFp(b []byte, xs ...*nt.Integer) []byte {
+	size := c.FpSize()
+	for _, x := range xs {
+		b = append(b, x.FillBytes(make([]byte, size))...)
+	}
+	return b
+}
+
+// ReadFp decodes n big endian integers of FpSize bytes each from b which
+// must hold at least n FpSize bytes
+func (c *Curve) ReadFp(b []byte, n int) []*nt.Integer {
+	size := c.FpSize()
+	xs := make([]*nt.Integer, n)
+	for i := range xs {
+		xs[i] = new(nt.Integer).SetBytes(b[i*size : (i+1)*size])
+	}
+	return xs
+}
+
+func fromDecimal(s string) *nt.Integer {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func fromHex(s string) *nt.Integer {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
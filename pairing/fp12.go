@@ -0,0 +1,212 @@
+package pairing
+
+import (
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// Fp12 is an element c0 + c1w of Fp12 = Fp6[w]/(w^2 - v)
+type Fp12 struct {
+	C0, C1 Fp6
+}
+
+// Fp12Field represents the quadratic extension Fp6[w]/(w^2 - v),
+// as an extension of Fp2 it's Fp2[w]/(w^6 - xi).
+type Fp12Field struct {
+	Fp6 *Fp6Field
+}
+
+// NewFp12Field creates Fp12 = Fp6[w]/(w^2 - v)
+func NewFp12Field(fp6 *Fp6Field) *Fp12Field {
+	return &Fp12Field{Fp6: fp6}
+}
+
+// Zero returns the additive identity
+func (f *Fp12Field) Zero() Fp12 {
+	return Fp12{f.Fp6.Zero(), f.Fp6.Zero()}
+}
+
+// One returns the multiplicative identity
+func (f *Fp12Field) One() Fp12 {
+	return Fp12{f.Fp6.One(), f.Fp6.Zero()}
+}
+
+// Rand returns a random element
+func (f *Fp12Field) Rand() (Fp12, error) {
+	c0, err := f.Fp6.Rand()
+	if err != nil {
+		return Fp12{}, err
+	}
+	c1, err := f.Fp6.Rand()
+	if err != nil {
+		return Fp12{}, err
+	}
+	return Fp12{c0, c1}, nil
+}
+
+// IsZero returns true if a = 0
+func (f *Fp12Field) IsZero(a Fp12) bool {
+	return f.Fp6.IsZero(a.C0) && f.Fp6.IsZero(a.C1)
+}
+
+// IsOne returns true if a = 1
+func (f *Fp12Field) IsOne(a Fp12) bool {
+	return f.Fp6.IsOne(a.C0) && f.Fp6.IsZero(a.C1)
+}
+
+// Equal returns true if a = b
+func (f *Fp12Field) Equal(a, b Fp12) bool {
+	return f.Fp6.Equal(a.C0, b.C0) && f.Fp6.Equal(a.C1, b.C1)
+}
+
+// Add computes a + b
+func (f *Fp12Field) Add(a, b Fp12) Fp12 {
+	return Fp12{f.Fp6.Add(a.C0, b.C0), f.Fp6.Add(a.C1, b.C1)}
+}
+
+// Sub computes a - b
+func (f *Fp12Field) Sub(a, b Fp12) Fp12 {
+	return Fp12{f.Fp6.Sub(a.C0, b.C0), f.Fp6.Sub(a.C1, b.C1)}
+}
+
+// Neg computes -a
+func (f *Fp12Field) Neg(a Fp12) Fp12 {
+	return Fp12{f.Fp6.Neg(a.C0), f.Fp6.Neg(a.C1)}
+}
+
+// Conjugate computes c0 - c1w which is a^(p^6), for elements of the
+// cyclotomic subgroup it's also the inverse.
+func (f *Fp12Field) Conjugate(a Fp12) Fp12 {
+	return Fp12{a.C0, f.Fp6.Neg(a.C1)}
+}
+
+// Mul computes a*b with Karatsuba's method :
+// t0 = a0b0, t1 = a1b1, c0 = t0 + t1v and c1 = (a0 + a1)(b0 + b1) - t0 - t1
+func (f *Fp12Field) Mul(a, b Fp12) Fp12 {
+	fp6 := f.Fp6
+	t0 := fp6.Mul(a.C0, b.C0)
+	t1 := fp6.Mul(a.C1, b.C1)
+	c1 := fp6.Mul(fp6.Add(a.C0, a.C1), fp6.Add(b.C0, b.C1))
+	c1 = fp6.Sub(fp6.Sub(c1, t0), t1)
+	return Fp12{fp6.Add(t0, fp6.MulByV(t1)), c1}
+}
+
+// Square computes a^2 with the complex method :
+// t = a0a1, c0 = (a0 + a1)(a0 + a1v) - t - tv and c1 = 2t
+func (f *Fp12Field) Square(a Fp12) Fp12 {
+	fp6 := f.Fp6
+	t := fp6.Mul(a.C0, a.C1)
+	c0 := fp6.Mul(fp6.Add(a.C0, a.C1), fp6.Add(a.C0, fp6.MulByV(a.C1)))
+	c0 = fp6.Sub(fp6.Sub(c0, t), fp6.MulByV(t))
+	return Fp12{c0, fp6.Double(t)}
+}
+
+// Inv computes a^-1 = (a0 - a1w)/(a0^2 - a1^2v)
+func (f *Fp12Field) Inv(a Fp12) (Fp12, error) {
+	if f.IsZero(a) {
+		return Fp12{}, errZeroInverse
+	}
+	fp6 := f.Fp6
+	d := fp6.Sub(fp6.Square(a.C0), fp6.MulByV(fp6.Square(a.C1)))
+	dInv, err := fp6.Inv(d)
+	if err != nil {
+		return Fp12{}, err
+	}
+	return Fp12{fp6.Mul(a.C0, dInv), fp6.Neg(fp6.Mul(a.C1, dInv))}, nil
+}
+
+// Exp computes a^k using square and multiply
+func (f *Fp12Field) Exp(a Fp12, k *nt.Integer) Fp12 {
+	r := f.One()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = f.Square(r)
+		if k.Bit(i) == 1 {
+			r = f.Mul(r, a)
+		}
+	}
+	return r
+}
+
+// Frobenius computes a^(p^i), viewing a as sum c_kw^k with c_k in Fp2 the
+// coefficients are conjugated i times and w^k becomes gamma[i][k]w^k.
+func (f *Fp12Field) Frobenius(a Fp12, i int) Fp12 {
+	fp2 := f.Fp6.Fp2
+	for _, j := range frobeniusSteps(i, 12) {
+		g := f.Fp6.gamma[j]
+		frob := func(c Fp2, k int) Fp2 {
+			return fp2.Mul(fp2.Frobenius(c, j), g[k])
+		}
+		// c0 = c00 + c01w^2 + c02w^4 and c1w = c10w + c11w^3 + c12w^5
+		a = Fp12{
+			Fp6{frob(a.C0.C0, 0), frob(a.C0.C1, 2), frob(a.C0.C2, 4)},
+			Fp6{frob(a.C1.C0, 1), frob(a.C1.C1, 3), frob(a.C1.C2, 5)},
+		}
+	}
+	return a
+}
+
+// CyclotomicSquare computes a^2 for a in the cyclotomic subgroup of order
+// p^4 - p^2 + 1 which contains the result of the easy part of the final
+// exponentiation, it costs six squarings in Fp2 instead of twelve
+// multiplications (Granger-Scott).
+func (f *Fp12Field) CyclotomicSquare(a Fp12) Fp12 {
+	fp2 := f.Fp6.Fp2
+	xi := f.Fp6.MulByXi
+	// squares in Fp4 = Fp2[w^3]/(w^6 - xi) of (g0, g4), (g2, g3) and (g5, g1)
+	fp4Square := func(x, y Fp2) (Fp2, Fp2) {
+		x2, y2 := fp2.Square(x), fp2.Square(y)
+		xy := fp2.Sub(fp2.Sub(fp2.Square(fp2.Add(x, y)), x2), y2)
+		return fp2.Add(xi(y2), x2), xy
+	}
+	t0, t6 := fp4Square(a.C0.C0, a.C1.C1)
+	t2, t7 := fp4Square(a.C1.C0, a.C0.C2)
+	t4, t8 := fp4Square(a.C0.C1, a.C1.C2)
+	t8 = xi(t8)
+	// 3t - 2g for the first half and 3t + 2g for the second
+	minus := func(t, g Fp2) Fp2 {
+		return fp2.Add(fp2.Double(fp2.Sub(t, g)), t)
+	}
+	plus := func(t, g Fp2) Fp2 {
+		return fp2.Add(fp2.Double(fp2.Add(t, g)), t)
+	}
+	return Fp12{
+		Fp6{minus(t0, a.C0.C0), minus(t2, a.C0.C1), minus(t4, a.C0.C2)},
+		Fp6{plus(t8, a.C1.C0), plus(t6, a.C1.C1), plus(t7, a.C1.C2)},
+	}
+}
+
+// CyclotomicExp computes a^k for a in the cyclotomic subgroup using
+// cyclotomic squarings, negative exponents use the conjugate as inverse.
+func (f *Fp12Field) CyclotomicExp(a Fp12, k *nt.Integer) Fp12 {
+	if k.Sign() < 0 {
+		return f.Conjugate(f.CyclotomicExp(a, new(nt.Integer).Neg(k)))
+	}
+	r := f.One()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = f.CyclotomicSquare(r)
+		if k.Bit(i) == 1 {
+			r = f.Mul(r, a)
+		}
+	}
+	return r
+}
+
+// MulBy034 computes a*(c0 + (c3 + c4v)w), the indices refer to the basis
+// 1, v, v^2, w, vw, v^2w and it's the shape of the line functions of D-type
+// twists such as BN254.
+func (f *Fp12Field) MulBy034(a Fp12, c0, c3, c4 Fp2) Fp12 {
+	fp6 := f.Fp6
+	t0 := fp6.MulByFp2(a.C0, c0)
+	t1 := fp6.MulBy01(a.C1, c3, c4)
+	d := fp6.MulBy01(fp6.Add(a.C0, a.C1), fp6.Fp2.Add(c0, c3), c4)
+	return Fp12{fp6.Add(fp6.MulByV(t1), t0), fp6.Sub(fp6.Sub(d, t0), t1)}
+}
+
+// MulBy014 computes a*((c0 + c1v) + c4vw), the shape of the line functions
+// of M-type twists such as BLS12-381.
+func (f *Fp12Field) MulBy014(a Fp12, c0, c1, c4 Fp2) Fp12 {
+	fp6 := f.Fp6
+	t0 := fp6.MulBy01(a.C0, c0, c1)
+	t1 := fp6.MulBy1(a.C1, c4)
+	d := fp6.MulBy01(fp6.Add(a.C0, a.C1), c0, fp6.Fp2.Add(c1, c4))
+	return Fp12{fp6.Add(fp6.MulByV(t1), t0), fp6.Sub(fp6.Sub(d, t0), t1)}
+}
@@ -0,0 +1,212 @@
+package pairing
+
+import (
+	"fmt"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// Fp2 is an element c0 + c1u of Fp2 = Fp[u]/(u^2 - beta)
+type Fp2 struct {
+	C0, C1 ff.FieldElement
+}
+
+// String implements stringer
+func (a Fp2) String() string {
+	return fmt.Sprintf("%v + %vu", a.C0.Big(), a.C1.Big())
+}
+
+// Fp2Field represents the quadratic extension Fp[u]/(u^2 - beta) where beta
+// is a quadratic non residue of Fp.
+type Fp2Field struct {
+	Fp   ff.FiniteField
+	beta ff.FieldElement
+}
+
+// NewFp2Field creates Fp2 = Fp[u]/(u^2 - beta)
+func NewFp2Field(fp ff.FiniteField, beta *nt.Integer) (*Fp2Field, error) {
+	b := fp.NewFieldElement(beta)
+	// Euler's criterion
+	if b.Exp(nt.Div(nt.Sub(fp.Modulus(), nt.One), nt.FromInt64(2))).Equal(fp.One()) {
+		return nil, errResidue
+	}
+	return &Fp2Field{Fp: fp, beta: b}, nil
+}
+
+// Beta returns the non residue beta = u^2
+func (f *Fp2Field) Beta() ff.FieldElement {
+	return f.beta
+}
+
+// New returns c0 + c1u
+func (f *Fp2Field) New(c0, c1 *nt.Integer) Fp2 {
+	return Fp2{f.Fp.NewFieldElement(c0), f.Fp.NewFieldElement(c1)}
+}
+
+// NewFromInt64 returns c0 + c1u
+func (f *Fp2Field) NewFromInt64(c0, c1 int64) Fp2 {
+	return f.New(nt.FromInt64(c0), nt.FromInt64(c1))
+}
+
+// Zero returns the additive identity
+func (f *Fp2Field) Zero() Fp2 {
+	return Fp2{f.Fp.Zero(), f.Fp.Zero()}
+}
+
+// One returns the multiplicative identity
+func (f *Fp2Field) One() Fp2 {
+	return Fp2{f.Fp.One(), f.Fp.Zero()}
+}
+
+// Rand returns a random element
+func (f *Fp2Field) Rand() (Fp2, error) {
+	c0, err := f.Fp.Rand()
+	if err != nil {
+		return Fp2{}, err
+	}
+	c1, err := f.Fp.Rand()
+	if err != nil {
+		return Fp2{}, err
+	}
+	return Fp2{f.Fp.NewFieldElement(c0.Big()), f.Fp.NewFieldElement(c1.Big())}, nil
+}
+
+// IsZero returns true if a = 0
+func (f *Fp2Field) IsZero(a Fp2) bool {
+	return a.C0.IsZero() && a.C1.IsZero()
+}
+
+// IsOne returns true if a = 1
+func (f *Fp2Field) IsOne(a Fp2) bool {
+	return a.C0.Equal(f.Fp.One()) && a.C1.IsZero()
+}
+
+// Equal returns true if a = b
+func (f *Fp2Field) Equal(a, b Fp2) bool {
+	return a.C0.Equal(b.C0) && a.C1.Equal(b.C1)
+}
+
+// Add computes a + b
+func (f *Fp2Field) Add(a, b Fp2) Fp2 {
+	return Fp2{f.Fp.Add(a.C0, b.C0), f.Fp.Add(a.C1, b.C1)}
+}
+
+// Sub computes a - b
+func (f *Fp2Field) Sub(a, b Fp2) Fp2 {
+	return Fp2{f.Fp.Sub(a.C0, b.C0), f.Fp.Sub(a.C1, b.C1)}
+}
+
+// Double computes 2a
+func (f *Fp2Field) Double(a Fp2) Fp2 {
+	return f.Add(a, a)
+}
+
+// Neg computes -a
+func (f *Fp2Field) Neg(a Fp2) Fp2 {
+	return Fp2{a.C0.Neg(), a.C1.Neg()}
+}
+
+// Conjugate computes c0 - c1u which is a^p
+func (f *Fp2Field) Conjugate(a Fp2) Fp2 {
+	return Fp2{a.C0, a.C1.Neg()}
+}
+
+// mulByBeta computes beta*x in Fp
+func (f *Fp2Field) mulByBeta(x ff.FieldElement) ff.FieldElement {
+	return f.Fp.Mul(f.beta, x)
+}
+
+// Mul computes a*b with Karatsuba's method using three multiplications in Fp :
+// v0 = a0b0, v1 = a1b1, c0 = v0 + beta*v1 and c1 = (a0 + a1)(b0 + b1) - v0 - v1
+func (f *Fp2Field) Mul(a, b Fp2) Fp2 {
+	fp := f.Fp
+	v0 := fp.Mul(a.C0, b.C0)
+	v1 := fp.Mul(a.C1, b.C1)
+	c1 := fp.Sub(fp.Sub(fp.Mul(fp.Add(a.C0, a.C1), fp.Add(b.C0, b.C1)), v0), v1)
+	return Fp2{fp.Add(v0, f.mulByBeta(v1)), c1}
+}
+
+// Square computes a^2 with the complex method using two multiplications :
+// v = a0a1, c0 = (a0 + a1)(a0 + beta*a1) - v - beta*v and c1 = 2v
+func (f *Fp2Field) Square(a Fp2) Fp2 {
+	fp := f.Fp
+	v := fp.Mul(a.C0, a.C1)
+	c0 := fp.Mul(fp.Add(a.C0, a.C1), fp.Add(a.C0, f.mulByBeta(a.C1)))
+	c0 = fp.Sub(fp.Sub(c0, v), f.mulByBeta(v))
+	return Fp2{c0, v.Double()}
+}
+
+// MulByFp computes a*s for s in Fp
+func (f *Fp2Field) MulByFp(a Fp2, s ff.FieldElement) Fp2 {
+	return Fp2{f.Fp.Mul(a.C0, s), f.Fp.Mul(a.C1, s)}
+}
+
+// Inv computes a^-1 = (a0 - a1u)/(a0^2 - beta*a1^2)
+func (f *Fp2Field) Inv(a Fp2) (Fp2, error) {
+	if f.IsZero(a) {
+		return Fp2{}, errZeroInverse
+	}
+	fp := f.Fp
+	norm := fp.Sub(a.C0.Square(), f.mulByBeta(a.C1.Square()))
+	inv := norm.Inv()
+	return Fp2{fp.Mul(a.C0, inv), fp.Mul(a.C1.Neg(), inv)}, nil
+}
+
+// Sqrt computes a square root of a, writing n = a0^2 - beta*a1^2 for the
+// norm of a the root is x0 + x1u with x0^2 = (a0 +- sqrt(n))/2 and
+// x1 = a1/(2x0).
+func (f *Fp2Field) Sqrt(a Fp2) (Fp2, error) {
+	fp := f.Fp
+	p := fp.Modulus()
+	sqrt := func(x ff.FieldElement) (ff.FieldElement, bool) {
+		r := new(nt.Integer).ModSqrt(x.Big(), p)
+		if r == nil {
+			return ff.FieldElement{}, false
+		}
+		return fp.NewFieldElement(r), true
+	}
+	if a.C1.IsZero() {
+		if x0, ok := sqrt(a.C0); ok {
+			return Fp2{x0, fp.Zero()}, nil
+		}
+		// a0 isn't a square in Fp but a0/beta is
+		x1, ok := sqrt(fp.Div(a.C0, f.beta))
+		if !ok {
+			return Fp2{}, errNoSqrt
+		}
+		return Fp2{fp.Zero(), x1}, nil
+	}
+	s, ok := sqrt(fp.Sub(a.C0.Square(), f.mulByBeta(a.C1.Square())))
+	if !ok {
+		return Fp2{}, errNoSqrt
+	}
+	half := fp.NewFieldElementFromInt64(2).Inv()
+	x0, ok := sqrt(fp.Mul(fp.Add(a.C0, s), half))
+	if !ok {
+		if x0, ok = sqrt(fp.Mul(fp.Sub(a.C0, s), half)); !ok {
+			return Fp2{}, errNoSqrt
+		}
+	}
+	return Fp2{x0, fp.Div(a.C1, x0.Double())}, nil
+}
+
+// Exp computes a^k using square and multiply
+func (f *Fp2Field) Exp(a Fp2, k *nt.Integer) Fp2 {
+	r := f.One()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = f.Square(r)
+		if k.Bit(i) == 1 {
+			r = f.Mul(r, a)
+		}
+	}
+	return r
+}
+
+// Frobenius computes a^(p^i) which is a for even i and the conjugate otherwise
+func (f *Fp2Field) Frobenius(a Fp2, i int) Fp2 {
+	if i%2 == 0 {
+		return a
+	}
+	return f.Conjugate(a)
+}
@@ -0,0 +1,243 @@
+package pairing
+
+import (
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// Fp6 is an element c0 + c1v + c2v^2 of Fp6 = Fp2[v]/(v^3 - xi)
+type Fp6 struct {
+	C0, C1, C2 Fp2
+}
+
+// Fp6Field represents the cubic extension Fp2[v]/(v^3 - xi) where xi is
+// neither a square nor a cube in Fp2.
+// ref : Multiplication and Squaring on Pairing-Friendly Fields (Devegili et al.)
+type Fp6Field struct {
+	Fp2 *Fp2Field
+	xi  Fp2
+	// gamma[i][k] = xi^(k(p^i - 1)/6) for 1 <= i <= 3 is w^(k(p^i - 1)) where
+	// w^6 = xi, the Frobenius maps w^k to gamma[i][k]w^k.
+	gamma [4][6]Fp2
+}
+
+// NewFp6Field creates Fp6 = Fp2[v]/(v^3 - xi)
+func NewFp6Field(fp2 *Fp2Field, xi Fp2) (*Fp6Field, error) {
+	// xi is a square (resp. a cube) if xi^((p^2 - 1)/2) = 1 (resp. xi^((p^2 - 1)/3) = 1)
+	p := fp2.Fp.Modulus()
+	order := nt.Sub(nt.Mul(p, p), nt.One)
+	if fp2.IsZero(xi) {
+		return nil, errResidue
+	}
+	for _, d := range []int64{2, 3} {
+		if fp2.IsOne(fp2.Exp(xi, nt.Div(order, nt.FromInt64(d)))) {
+			return nil, errResidue
+		}
+	}
+	f := &Fp6Field{Fp2: fp2, xi: xi}
+	// p = 1 mod 6 for the curves we support so the exponents are integers
+	pi := nt.FromInt64(1)
+	for i := 1; i <= 3; i++ {
+		pi = nt.Mul(pi, p)
+		g := fp2.Exp(xi, nt.Div(nt.Sub(pi, nt.One), nt.FromInt64(6)))
+		f.gamma[i][0] = fp2.One()
+		for k := 1; k < 6; k++ {
+			f.gamma[i][k] = fp2.Mul(f.gamma[i][k-1], g)
+		}
+	}
+	return f, nil
+}
+
+// Xi returns the non residue xi = v^3
+func (f *Fp6Field) Xi() Fp2 {
+	return f.xi
+}
+
+// Zero returns the additive identity
+func (f *Fp6Field) Zero() Fp6 {
+	z := f.Fp2.Zero()
+	return Fp6{z, z, z}
+}
+
+// One returns the multiplicative identity
+func (f *Fp6Field) One() Fp6 {
+	z := f.Fp2.Zero()
+	return Fp6{f.Fp2.One(), z, z}
+}
+
+// Rand returns a random element
+func (f *Fp6Field) Rand() (Fp6, error) {
+	var r Fp6
+	var err error
+	for _, c := range []*Fp2{&r.C0, &r.C1, &r.C2} {
+		if *c, err = f.Fp2.Rand(); err != nil {
+			return Fp6{}, err
+		}
+	}
+	return r, nil
+}
+
+// IsZero returns true if a = 0
+func (f *Fp6Field) IsZero(a Fp6) bool {
+	return f.Fp2.IsZero(a.C0) && f.Fp2.IsZero(a.C1) && f.Fp2.IsZero(a.C2)
+}
+
+// IsOne returns true if a = 1
+func (f *Fp6Field) IsOne(a Fp6) bool {
+	return f.Fp2.IsOne(a.C0) && f.Fp2.IsZero(a.C1) && f.Fp2.IsZero(a.C2)
+}
+
+// Equal returns true if a = b
+func (f *Fp6Field) Equal(a, b Fp6) bool {
+	return f.Fp2.Equal(a.C0, b.C0) && f.Fp2.Equal(a.C1, b.C1) && f.Fp2.Equal(a.C2, b.C2)
+}
+
+// Add computes a + b
+func (f *Fp6Field) Add(a, b Fp6) Fp6 {
+	return Fp6{f.Fp2.Add(a.C0, b.C0), f.Fp2.Add(a.C1, b.C1), f.Fp2.Add(a.C2, b.C2)}
+}
+
+// Sub computes a - b
+func (f *Fp6Field) Sub(a, b Fp6) Fp6 {
+	return Fp6{f.Fp2.Sub(a.C0, b.C0), f.Fp2.Sub(a.C1, b.C1), f.Fp2.Sub(a.C2, b.C2)}
+}
+
+// Double computes 2a
+func (f *Fp6Field) Double(a Fp6) Fp6 {
+	return f.Add(a, a)
+}
+
+// Neg computes -a
+func (f *Fp6Field) Neg(a Fp6) Fp6 {
+	return Fp6{f.Fp2.Neg(a.C0), f.Fp2.Neg(a.C1), f.Fp2.Neg(a.C2)}
+}
+
+// MulByXi computes xi*a in Fp2
+func (f *Fp6Field) MulByXi(a Fp2) Fp2 {
+	return f.Fp2.Mul(f.xi, a)
+}
+
+// MulByV computes a*v = xi*a2 + a0v + a1v^2
+func (f *Fp6Field) MulByV(a Fp6) Fp6 {
+	return Fp6{f.MulByXi(a.C2), a.C0, a.C1}
+}
+
+// MulByFp2 computes a*s for s in Fp2
+func (f *Fp6Field) MulByFp2(a Fp6, s Fp2) Fp6 {
+	return Fp6{f.Fp2.Mul(a.C0, s), f.Fp2.Mul(a.C1, s), f.Fp2.Mul(a.C2, s)}
+}
+
+// Mul computes a*b with Karatsuba's method using six multiplications in Fp2
+func (f *Fp6Field) Mul(a, b Fp6) Fp6 {
+	fp2 := f.Fp2
+	v0 := fp2.Mul(a.C0, b.C0)
+	v1 := fp2.Mul(a.C1, b.C1)
+	v2 := fp2.Mul(a.C2, b.C2)
+	// c0 = ((a1 + a2)(b1 + b2) - v1 - v2)xi + v0
+	c0 := fp2.Mul(fp2.Add(a.C1, a.C2), fp2.Add(b.C1, b.C2))
+	c0 = fp2.Add(f.MulByXi(fp2.Sub(fp2.Sub(c0, v1), v2)), v0)
+	// c1 = (a0 + a1)(b0 + b1) - v0 - v1 + xi*v2
+	c1 := fp2.Mul(fp2.Add(a.C0, a.C1), fp2.Add(b.C0, b.C1))
+	c1 = fp2.Add(fp2.Sub(fp2.Sub(c1, v0), v1), f.MulByXi(v2))
+	// c2 = (a0 + a2)(b0 + b2) - v0 - v2 + v1
+	c2 := fp2.Mul(fp2.Add(a.C0, a.C2), fp2.Add(b.C0, b.C2))
+	c2 = fp2.Add(fp2.Sub(fp2.Sub(c2, v0), v2), v1)
+	return Fp6{c0, c1, c2}
+}
+
+// MulBy01 computes a*(b0 + b1v), the sparse product used by line functions
+func (f *Fp6Field) MulBy01(a Fp6, b0, b1 Fp2) Fp6 {
+	fp2 := f.Fp2
+	v0 := fp2.Mul(a.C0, b0)
+	v1 := fp2.Mul(a.C1, b1)
+	// c0 = a0b0 + xi*a2b1
+	c0 := fp2.Sub(fp2.Mul(fp2.Add(a.C1, a.C2), b1), v1)
+	c0 = fp2.Add(f.MulByXi(c0), v0)
+	// c1 = a0b1 + a1b0
+	c1 := fp2.Mul(fp2.Add(a.C0, a.C1), fp2.Add(b0, b1))
+	c1 = fp2.Sub(fp2.Sub(c1, v0), v1)
+	// c2 = a2b0 + a1b1
+	c2 := fp2.Add(fp2.Sub(fp2.Mul(fp2.Add(a.C0, a.C2), b0), v0), v1)
+	return Fp6{c0, c1, c2}
+}
+
+// MulBy1 computes a*(b1v)
+func (f *Fp6Field) MulBy1(a Fp6, b1 Fp2) Fp6 {
+	fp2 := f.Fp2
+	return Fp6{f.MulByXi(fp2.Mul(a.C2, b1)), fp2.Mul(a.C0, b1), fp2.Mul(a.C1, b1)}
+}
+
+// Square computes a^2 with the CH-SQR2 formulas (Chung-Hasan)
+func (f *Fp6Field) Square(a Fp6) Fp6 {
+	fp2 := f.Fp2
+	s0 := fp2.Square(a.C0)
+	s1 := fp2.Double(fp2.Mul(a.C0, a.C1))
+	s2 := fp2.Square(fp2.Add(fp2.Sub(a.C0, a.C1), a.C2))
+	s3 := fp2.Double(fp2.Mul(a.C1, a.C2))
+	s4 := fp2.Square(a.C2)
+	c0 := fp2.Add(f.MulByXi(s3), s0)
+	c1 := fp2.Add(f.MulByXi(s4), s1)
+	c2 := fp2.Sub(fp2.Sub(fp2.Add(fp2.Add(s1, s2), s3), s0), s4)
+	return Fp6{c0, c1, c2}
+}
+
+// Inv computes a^-1 = (t0 + t1v + t2v^2)/(a0t0 + xi(a2t1 + a1t2)) where
+// t0 = a0^2 - xi*a1a2, t1 = xi*a2^2 - a0a1 and t2 = a1^2 - a0a2
+func (f *Fp6Field) Inv(a Fp6) (Fp6, error) {
+	if f.IsZero(a) {
+		return Fp6{}, errZeroInverse
+	}
+	fp2 := f.Fp2
+	t0 := fp2.Sub(fp2.Square(a.C0), f.MulByXi(fp2.Mul(a.C1, a.C2)))
+	t1 := fp2.Sub(f.MulByXi(fp2.Square(a.C2)), fp2.Mul(a.C0, a.C1))
+	t2 := fp2.Sub(fp2.Square(a.C1), fp2.Mul(a.C0, a.C2))
+	d := fp2.Add(fp2.Mul(a.C2, t1), fp2.Mul(a.C1, t2))
+	d = fp2.Add(f.MulByXi(d), fp2.Mul(a.C0, t0))
+	dInv, err := fp2.Inv(d)
+	if err != nil {
+		return Fp6{}, err
+	}
+	return Fp6{fp2.Mul(t0, dInv), fp2.Mul(t1, dInv), fp2.Mul(t2, dInv)}, nil
+}
+
+// Exp computes a^k using square and multiply
+func (f *Fp6Field) Exp(a Fp6, k *nt.Integer) Fp6 {
+	r := f.One()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = f.Square(r)
+		if k.Bit(i) == 1 {
+			r = f.Mul(r, a)
+		}
+	}
+	return r
+}
+
+// frobeniusSteps splits i mod n in steps of at most 3 powers of p
+func frobeniusSteps(i, n int) []int {
+	i %= n
+	if i < 0 {
+		i += n
+	}
+	var steps []int
+	for ; i > 0; i -= 3 {
+		if i >= 3 {
+			steps = append(steps, 3)
+		} else {
+			steps = append(steps, i)
+		}
+	}
+	return steps
+}
+
+// Frobenius computes a^(p^i), v^(p^i) = gamma[i][2]v and the coefficients
+// in Fp2 are conjugated i times.
+func (f *Fp6Field) Frobenius(a Fp6, i int) Fp6 {
+	fp2 := f.Fp2
+	for _, j := range frobeniusSteps(i, 6) {
+		a = Fp6{
+			fp2.Frobenius(a.C0, j),
+			fp2.Mul(fp2.Frobenius(a.C1, j), f.gamma[j][2]),
+			fp2.Mul(fp2.Frobenius(a.C2, j), f.gamma[j][4]),
+		}
+	}
+	return a
+}
@@ -0,0 +1,151 @@
+package pairing
+
+import (
+	"fmt"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// G1Point is an affine point on E(Fp), Inf marks the point at infinity
+type G1Point struct {
+	X, Y ff.FieldElement
+	Inf  bool
+}
+
+// String implements stringer
+func (p *G1Point) String() string {
+	if p.Inf {
+		return "O"
+	}
+	return fmt.Sprintf("(%v, %v)", p.X.Big(), p.Y.Big())
+}
+
+// G1 is the subgroup of order r of E(Fp) where E: y^2 = x^3 + b
+type G1 struct {
+	Fp ff.FiniteField
+	B  ff.FieldElement
+	// R is the prime order of the subgroup and Cofactor is #E(Fp)/R
+	R        *nt.Integer
+	Cofactor *nt.Integer
+	gen      G1Point
+}
+
+// Generator returns the fixed generator of G1
+func (g *G1) Generator() *G1Point {
+	p := g.gen
+	return &p
+}
+
+// Infinity returns the point at infinity
+func (g *G1) Infinity() *G1Point {
+	return &G1Point{X: g.Fp.Zero(), Y: g.Fp.Zero(), Inf: true}
+}
+
+// rhs computes x^3 + b
+func (g *G1) rhs(x ff.FieldElement) ff.FieldElement {
+	return g.Fp.Add(g.Fp.Mul(x.Square(), x), g.B)
+}
+
+// NewPoint returns (x, y) if it's on the curve
+func (g *G1) NewPoint(x, y *nt.Integer) (*G1Point, error) {
+	p := &G1Point{X: g.Fp.NewFieldElement(x), Y: g.Fp.NewFieldElement(y)}
+	if !g.IsOnCurve(p) {
+		return nil, errNotOnCurve
+	}
+	return p, nil
+}
+
+// At returns a point of abscissa x if x^3 + b is a square
+func (g *G1) At(x *nt.Integer) (*G1Point, error) {
+	fx := g.Fp.NewFieldElement(x)
+	y := new(nt.Integer).ModSqrt(g.rhs(fx).Big(), g.Fp.Modulus())
+	if y == nil {
+		return nil, errNoSqrt
+	}
+	return &G1Point{X: fx, Y: g.Fp.NewFieldElement(y)}, nil
+}
+
+// IsOnCurve checks that y^2 = x^3 + b
+func (g *G1) IsOnCurve(p *G1Point) bool {
+	return p.Inf || p.Y.Square().Equal(g.rhs(p.X))
+}
+
+// InSubgroup checks that p is on the curve and has order r
+func (g *G1) InSubgroup(p *G1Point) bool {
+	return g.IsOnCurve(p) && g.ScalarMul(p, g.R).Inf
+}
+
+// Equal checks if two points are equal
+func (g *G1) Equal(p, q *G1Point) bool {
+	if p.Inf || q.Inf {
+		return p.Inf == q.Inf
+	}
+	return p.X.Equal(q.X) && p.Y.Equal(q.Y)
+}
+
+// Neg computes -(x, y) = (x, -y)
+func (g *G1) Neg(p *G1Point) *G1Point {
+	if p.Inf {
+		return g.Infinity()
+	}
+	return &G1Point{X: p.X, Y: p.Y.Neg()}
+}
+
+// Double computes 2p using the tangent slope 3x^2/2y
+func (g *G1) Double(p *G1Point) *G1Point {
+	if p.Inf || p.Y.IsZero() {
+		return g.Infinity()
+	}
+	fp := g.Fp
+	lambda := fp.Div(fp.Mul(fp.NewFieldElementFromInt64(3), p.X.Square()), p.Y.Double())
+	return g.chord(p, p.X, lambda)
+}
+
+// Add computes p + q using the chord slope (y2 - y1)/(x2 - x1)
+func (g *G1) Add(p, q *G1Point) *G1Point {
+	switch {
+	case p.Inf:
+		return q
+	case q.Inf:
+		return p
+	case p.X.Equal(q.X):
+		if p.Y.Equal(q.Y) {
+			return g.Double(p)
+		}
+		return g.Infinity()
+	}
+	fp := g.Fp
+	lambda := fp.Div(fp.Sub(q.Y, p.Y), fp.Sub(q.X, p.X))
+	return g.chord(p, q.X, lambda)
+}
+
+// chord returns the third point on the line of slope lambda through p and
+// a point of abscissa x2 reflected over the x-axis :
+// x3 = lambda^2 - x1 - x2 and y3 = lambda(x1 - x3) - y1
+func (g *G1) chord(p *G1Point, x2, lambda ff.FieldElement) *G1Point {
+	fp := g.Fp
+	x3 := fp.Sub(fp.Sub(lambda.Square(), p.X), x2)
+	y3 := fp.Sub(fp.Mul(lambda, fp.Sub(p.X, x3)), p.Y)
+	return &G1Point{X: x3, Y: y3}
+}
+
+// ScalarMul computes kp using double and add, negative scalars multiply -p
+func (g *G1) ScalarMul(p *G1Point, k *nt.Integer) *G1Point {
+	if k.Sign() < 0 {
+		return g.ScalarMul(g.Neg(p), new(nt.Integer).Neg(k))
+	}
+	r := g.Infinity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = g.Double(r)
+		if k.Bit(i) == 1 {
+			r = g.Add(r, p)
+		}
+	}
+	return r
+}
+
+// ClearCofactor maps a point of E(Fp) to G1 by multiplying by the cofactor
+func (g *G1) ClearCofactor(p *G1Point) *G1Point {
+	return g.ScalarMul(p, g.Cofactor)
+}
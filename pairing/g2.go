@@ -0,0 +1,194 @@
+package pairing
+
+import (
+	"fmt"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// G2Point is an affine point on the twist E'(Fp2), Inf marks the point at
+// infinity.
+type G2Point struct {
+	X, Y Fp2
+	Inf  bool
+}
+
+// String implements stringer
+func (p *G2Point) String() string {
+	if p.Inf {
+		return "O"
+	}
+	return fmt.Sprintf("(%v, %v)", p.X, p.Y)
+}
+
+// twistType tells how the sextic twist E' maps to E over Fp12
+type twistType int
+
+const (
+	// dType twists are E': y^2 = x^3 + b/xi mapped to E by (x, y) -> (xw^2, yw^3)
+	dType twistType = iota
+	// mType twists are E': y^2 = x^3 + b*xi mapped to E by (x, y) -> (x/w^2, y/w^3)
+	mType
+)
+
+// G2 is the subgroup of order r of the sextic twist E'(Fp2) where
+// E': y^2 = x^3 + b', it's isomorphic to the trace zero subgroup of E(Fp12).
+type G2 struct {
+	Fp2 *Fp2Field
+	B   Fp2
+	// R is the prime order of the subgroup and Cofactor is #E'(Fp2)/R
+	R        *nt.Integer
+	Cofactor *nt.Integer
+	twist    twistType
+	gen      G2Point
+	// gamma holds the constants of the twisted Frobenius
+	gamma *[4][6]Fp2
+}
+
+// Generator returns the fixed generator of G2
+func (g *G2) Generator() *G2Point {
+	p := g.gen
+	return &p
+}
+
+// Infinity returns the point at infinity
+func (g *G2) Infinity() *G2Point {
+	return &G2Point{X: g.Fp2.Zero(), Y: g.Fp2.Zero(), Inf: true}
+}
+
+// rhs computes x^3 + b'
+func (g *G2) rhs(x Fp2) Fp2 {
+	fp2 := g.Fp2
+	return fp2.Add(fp2.Mul(fp2.Square(x), x), g.B)
+}
+
+// NewPoint returns (x, y) if it's on the twist
+func (g *G2) NewPoint(x, y Fp2) (*G2Point, error) {
+	p := &G2Point{X: x, Y: y}
+	if !g.IsOnCurve(p) {
+		return nil, errNotOnCurve
+	}
+	return p, nil
+}
+
+// At returns a point of abscissa x if x^3 + b' is a square
+func (g *G2) At(x Fp2) (*G2Point, error) {
+	y, err := g.Fp2.Sqrt(g.rhs(x))
+	if err != nil {
+		return nil, err
+	}
+	return &G2Point{X: x, Y: y}, nil
+}
+
+// IsOnCurve checks that y^2 = x^3 + b'
+func (g *G2) IsOnCurve(p *G2Point) bool {
+	return p.Inf || g.Fp2.Equal(g.Fp2.Square(p.Y), g.rhs(p.X))
+}
+
+// InSubgroup checks that p is on the twist and has order r
+func (g *G2) InSubgroup(p *G2Point) bool {
+	return g.IsOnCurve(p) && g.ScalarMul(p, g.R).Inf
+}
+
+// Equal checks if two points are equal
+func (g *G2) Equal(p, q *G2Point) bool {
+	if p.Inf || q.Inf {
+		return p.Inf == q.Inf
+	}
+	return g.Fp2.Equal(p.X, q.X) && g.Fp2.Equal(p.Y, q.Y)
+}
+
+// Neg computes -(x, y) = (x, -y)
+func (g *G2) Neg(p *G2Point) *G2Point {
+	if p.Inf {
+		return g.Infinity()
+	}
+	return &G2Point{X: p.X, Y: g.Fp2.Neg(p.Y)}
+}
+
+// tangent returns the slope 3x^2/2y of the tangent at p
+func (g *G2) tangent(p *G2Point) Fp2 {
+	fp2 := g.Fp2
+	x2 := fp2.Square(p.X)
+	inv, _ := fp2.Inv(fp2.Double(p.Y))
+	return fp2.Mul(fp2.Add(fp2.Double(x2), x2), inv)
+}
+
+// slope returns the slope (y2 - y1)/(x2 - x1) of the chord through p and q
+func (g *G2) slope(p, q *G2Point) Fp2 {
+	fp2 := g.Fp2
+	inv, _ := fp2.Inv(fp2.Sub(q.X, p.X))
+	return fp2.Mul(fp2.Sub(q.Y, p.Y), inv)
+}
+
+// Double computes 2p
+func (g *G2) Double(p *G2Point) *G2Point {
+	if p.Inf || g.Fp2.IsZero(p.Y) {
+		return g.Infinity()
+	}
+	return g.chord(p, p.X, g.tangent(p))
+}
+
+// Add computes p + q
+func (g *G2) Add(p, q *G2Point) *G2Point {
+	switch {
+	case p.Inf:
+		return q
+	case q.Inf:
+		return p
+	case g.Fp2.Equal(p.X, q.X):
+		if g.Fp2.Equal(p.Y, q.Y) {
+			return g.Double(p)
+		}
+		return g.Infinity()
+	}
+	return g.chord(p, q.X, g.slope(p, q))
+}
+
+// chord returns the third point on the line of slope lambda through p and
+// a point of abscissa x2 reflected over the x-axis.
+func (g *G2) chord(p *G2Point, x2, lambda Fp2) *G2Point {
+	fp2 := g.Fp2
+	x3 := fp2.Sub(fp2.Sub(fp2.Square(lambda), p.X), x2)
+	y3 := fp2.Sub(fp2.Mul(lambda, fp2.Sub(p.X, x3)), p.Y)
+	return &G2Point{X: x3, Y: y3}
+}
+
+// ScalarMul computes kp using double and add, negative scalars multiply -p
+func (g *G2) ScalarMul(p *G2Point, k *nt.Integer) *G2Point {
+	if k.Sign() < 0 {
+		return g.ScalarMul(g.Neg(p), new(nt.Integer).Neg(k))
+	}
+	r := g.Infinity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = g.Double(r)
+		if k.Bit(i) == 1 {
+			r = g.Add(r, p)
+		}
+	}
+	return r
+}
+
+// ClearCofactor maps a point of E'(Fp2) to G2 by multiplying by the cofactor
+func (g *G2) ClearCofactor(p *G2Point) *G2Point {
+	return g.ScalarMul(p, g.Cofactor)
+}
+
+// Frobenius computes the endomorphism psi = phi^-1 o pi o phi where phi is
+// the untwisting isomorphism and pi the p-power Frobenius of E, on G2 it
+// acts as multiplication by p.
+func (g *G2) Frobenius(p *G2Point) *G2Point {
+	if p.Inf {
+		return g.Infinity()
+	}
+	fp2 := g.Fp2
+	x, y := fp2.Conjugate(p.X), fp2.Conjugate(p.Y)
+	if g.twist == dType {
+		// (xw^2)^p = x^p w^(2(p - 1)) w^2
+		return &G2Point{X: fp2.Mul(x, g.gamma[1][2]), Y: fp2.Mul(y, g.gamma[1][3])}
+	}
+	// (x/w^2)^p = x^p w^(-2(p - 1)) / w^2
+	xc, _ := fp2.Inv(g.gamma[1][2])
+	yc, _ := fp2.Inv(g.gamma[1][3])
+	return &G2Point{X: fp2.Mul(x, xc), Y: fp2.Mul(y, yc)}
+}
@@ -0,0 +1,192 @@
+package pairing
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+var errLength = errors.New("pairing requires as many G1 points as G2 points")
+
+// mulLine multiplies f by the line of slope lambda through t on the twist
+// evaluated at p. For D-type twists the line mapped to E is
+// yP - lambda*xP*w + (lambda*xT - yT)w^3, for M-type twists it is the same
+// line multiplied by w^3 which is killed by the final exponentiation.
+func (c *Curve) mulLine(f Fp12, t *G2Point, lambda Fp2, p *G1Point) Fp12 {
+	fp2 := c.Fp2
+	a := fp2.Sub(fp2.Mul(lambda, t.X), t.Y)
+	b := fp2.MulByFp(lambda, p.X.Neg())
+	y := Fp2{p.Y, c.Fp.Zero()}
+	if c.G2.twist == dType {
+		return c.Fp12.MulBy034(f, y, b, a)
+	}
+	return c.Fp12.MulBy014(f, a, b, y)
+}
+
+// doubleStep multiplies f by the tangent at t evaluated at p and doubles t,
+// vertical lines lie in a proper subfield and are skipped.
+func (c *Curve) doubleStep(f Fp12, t *G2Point, p *G1Point) (Fp12, *G2Point) {
+	if t.Inf || c.Fp2.IsZero(t.Y) {
+		return f, c.G2.Infinity()
+	}
+	lambda := c.G2.tangent(t)
+	return c.mulLine(f, t, lambda, p), c.G2.chord(t, t.X, lambda)
+}
+
+// addStep multiplies f by the chord through t and q evaluated at p and
+// computes t + q.
+func (c *Curve) addStep(f Fp12, t, q *G2Point, p *G1Point) (Fp12, *G2Point) {
+	switch {
+	case t.Inf:
+		return f, q
+	case c.Fp2.Equal(t.X, q.X):
+		if c.Fp2.Equal(t.Y, q.Y) {
+			return c.doubleStep(f, t, p)
+		}
+		return f, c.G2.Infinity()
+	}
+	lambda := c.G2.slope(t, q)
+	return c.mulLine(f, t, lambda, p), c.G2.chord(t, q.X, lambda)
+}
+
+// MillerLoop computes the product of the Miller functions of the optimal ate
+// pairing f_{s,Q_i}(P_i) sharing the squarings of the accumulator, for BN
+// curves s = 6u + 2 and two more lines through pi(Q) and -pi^2(Q) are added,
+// for BLS12 curves s = u.
+// ref : Optimal Pairings (Vercauteren) and High-Speed Software Implementation
+// of the Optimal Ate Pairing over Barreto-Naehrig Curves
+func (c *Curve) MillerLoop(ps []*G1Point, qs []*G2Point) (Fp12, error) {
+	if len(ps) != len(qs) {
+		return Fp12{}, errLength
+	}
+	var pp []*G1Point
+	var qq, ts []*G2Point
+	for i := range ps {
+		// e(O, Q) = e(P, O) = 1
+		if ps[i].Inf || qs[i].Inf {
+			continue
+		}
+		pp = append(pp, ps[i])
+		qq = append(qq, qs[i])
+		ts = append(ts, qs[i])
+	}
+	f := c.Fp12.One()
+	for i := c.loop.BitLen() - 2; i >= 0; i-- {
+		f = c.Fp12.Square(f)
+		for j := range pp {
+			f, ts[j] = c.doubleStep(f, ts[j], pp[j])
+		}
+		if c.loop.Bit(i) == 1 {
+			for j := range pp {
+				f, ts[j] = c.addStep(f, ts[j], qq[j], pp[j])
+			}
+		}
+	}
+	if c.U.Sign() < 0 {
+		// f_{-s,Q} = 1/(f_{s,Q} v_{sQ}) and the inverse is the conjugate
+		// after the final exponentiation
+		f = c.Fp12.Conjugate(f)
+	}
+	if c.family == bnFamily {
+		for j := range pp {
+			q1 := c.G2.Frobenius(qq[j])
+			q2 := c.G2.Neg(c.G2.Frobenius(q1))
+			f, ts[j] = c.addStep(f, ts[j], q1, pp[j])
+			f, _ = c.addStep(f, ts[j], q2, pp[j])
+		}
+	}
+	return f, nil
+}
+
+// FinalExponentiation computes f^((p^12 - 1)/r) as the easy part
+// f^((p^6 - 1)(p^2 + 1)) followed by the hard part f^((p^4 - p^2 + 1)/r)
+// written in base p with coefficients polynomial in u, for BLS12 curves the
+// hard part is raised to a multiple 3(p^4 - p^2 + 1)/r.
+func (c *Curve) FinalExponentiation(f Fp12) Fp12 {
+	fp12 := c.Fp12
+	inv, err := fp12.Inv(f)
+	if err != nil {
+		return fp12.Zero()
+	}
+	f = fp12.Mul(fp12.Conjugate(f), inv)
+	f = fp12.Mul(fp12.Frobenius(f, 2), f)
+	if c.family == bnFamily {
+		return c.hardPartBN(f)
+	}
+	return c.hardPartBLS12(f)
+}
+
+// hardPartBN computes f^(l0 + l1p + l2p^2 + p^3) where
+// l0 = -36u^3 - 30u^2 - 18u - 2, l1 = -36u^3 - 18u^2 - 12u + 1 and
+// l2 = 6u^2 + 1.
+// ref : On the Final Exponentiation for Calculating Pairings on Ordinary
+// Elliptic Curves (Scott et al.)
+func (c *Curve) hardPartBN(f Fp12) Fp12 {
+	fp12 := c.Fp12
+	fu := fp12.CyclotomicExp(f, c.U)
+	fu2 := fp12.CyclotomicExp(fu, c.U)
+	fu3 := fp12.CyclotomicExp(fu2, c.U)
+	// prod f^(k_i) over the powers f, f^u, f^u^2 and f^u^3
+	eval := func(k0, k1, k2, k3 int64) Fp12 {
+		r := fp12.One()
+		for i, b := range []Fp12{f, fu, fu2, fu3} {
+			k := []int64{k0, k1, k2, k3}[i]
+			if k != 0 {
+				r = fp12.Mul(r, fp12.CyclotomicExp(b, nt.FromInt64(k)))
+			}
+		}
+		return r
+	}
+	r := eval(-2, -18, -30, -36)
+	r = fp12.Mul(r, fp12.Frobenius(eval(1, -12, -18, -36), 1))
+	r = fp12.Mul(r, fp12.Frobenius(eval(1, 0, 6, 0), 2))
+	return fp12.Mul(r, fp12.Frobenius(f, 3))
+}
+
+// hardPartBLS12 computes f^((u - 1)^2(u + p)(u^2 + p^2 - 1) + 3) which is
+// f^(3(p^4 - p^2 + 1)/r) for BLS12 curves, since 3 is prime to r the cube
+// of the reduced pairing is still bilinear and non degenerate and it's the
+// value computed by other BLS12-381 implementations.
+// ref : Efficient Final Exponentiation via Cyclotomic Structure for Pairings
+// over Families of Elliptic Curves (Hayashida et al.)
+func (c *Curve) hardPartBLS12(f Fp12) Fp12 {
+	fp12 := c.Fp12
+	u1 := nt.Sub(c.U, nt.One)
+	g := fp12.CyclotomicExp(fp12.CyclotomicExp(f, u1), u1)
+	// g^(u + p)
+	g = fp12.Mul(fp12.CyclotomicExp(g, c.U), fp12.Frobenius(g, 1))
+	// g^(u^2 + p^2 - 1)
+	gu2 := fp12.CyclotomicExp(fp12.CyclotomicExp(g, c.U), c.U)
+	g = fp12.Mul(fp12.Mul(gu2, fp12.Frobenius(g, 2)), fp12.Conjugate(g))
+	return fp12.Mul(g, fp12.Mul(fp12.CyclotomicSquare(f), f))
+}
+
+// Pair computes the optimal ate pairing e(P, Q) for P in G1 and Q in G2
+func (c *Curve) Pair(p *G1Point, q *G2Point) Fp12 {
+	f, _ := c.MillerLoop([]*G1Point{p}, []*G2Point{q})
+	return c.FinalExponentiation(f)
+}
+
+// MultiPair computes the product of the pairings e(P_i, Q_i) with a single
+// final exponentiation.
+func (c *Curve) MultiPair(ps []*G1Point, qs []*G2Point) (Fp12, error) {
+	f, err := c.MillerLoop(ps, qs)
+	if err != nil {
+		return Fp12{}, err
+	}
+	return c.FinalExponentiation(f), nil
+}
+
+// PairingCheck returns true if the product of the pairings e(P_i, Q_i) is 1
+func (c *Curve) PairingCheck(ps []*G1Point, qs []*G2Point) (bool, error) {
+	e, err := c.MultiPair(ps, qs)
+	if err != nil {
+		return false, err
+	}
+	return c.Fp12.IsOne(e), nil
+}
+
+// GTExp computes a^k for a in GT
+func (c *Curve) GTExp(a Fp12, k *nt.Integer) Fp12 {
+	return c.Fp12.CyclotomicExp(a, k)
+}
@@ -0,0 +1,173 @@
+package pairing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// gtHex encodes a in the order used by zkcrypto, circl and the Go bn256
+// packages, coefficients are written big endian starting from C1.C2.C1
+func gtHex(c *Curve, a Fp12) string {
+	var out []byte
+	for _, c6 := range []Fp6{a.C1, a.C0} {
+		for _, c2 := range []Fp2{c6.C2, c6.C1, c6.C0} {
+			out = c.AppendFp(out, c2.C1.Big(), c2.C0.Big())
+		}
+	}
+	return hex.EncodeToString(out)
+}
+
+func randScalar(t *testing.T, c *Curve) *nt.Integer {
+	k, err := rand.Int(rand.Reader, c.R)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+// e(G1, G2) as published by go-ethereum's bn256 and zkcrypto's bls12_381
+var pairingVectors = map[string]string{
+	"BN254":     "108c19d15f9446f744d0f110405d3856d6cc3bda6c4d537663729f52576284170dc26f240656bbe2029bd441d77c221f0ba4c70c94b29b5f17f0f6d08745a069279db296f9d479292532c7c493d8e0722b6efae42158387564889c79fc038ee31ad9db1937fd72f4ac462173d31d3d6117411fa48dba8d499d762b47edb3b54a27ed208e7a0b55ae6e710bbfbd2fd922669c026360e37cc5b2ab8624115361042c53748bcd21a7c038fb30ddc8ac3bf0af25d7859cfbc12c30c866276c5659092b03614464f04dd772d86df88674c270ffc8747ea13e72da95e3594468f222c401676555de427abc409c4a394bc5426886302996919d4bf4bdd02236e14b36362067586885c3318eeffa1938c754fe3c60224ee5ae15e66af6b5104c47c8c5d80e841c2ac18a4003ac9326b9558380e0bc27fdd375e3605f96b819a358d34bde084f330485b09e866bc2f2ea2b897394deaf3f12aa31f28cb0552990967d470412c70e90e12b7874510cd1707e8856f71bf7f61d72631e268fca81000db9a1f5",
+	"BLS12-381": "0f41e58663bf08cf068672cbd01a7ec73baca4d72ca93544deff686bfd6df543d48eaa24afe47e1efde449383b67663104c581234d086a9902249b64728ffd21a189e87935a954051c7cdba7b3872629a4fafc05066245cb9108f0242d0fe3ef03350f55a7aefcd3c31b4fcb6ce5771cc6a0e9786ab5973320c806ad360829107ba810c5a09ffdd9be2291a0c25a99a211b8b424cd48bf38fcef68083b0b0ec5c81a93b330ee1a677d0d15ff7b984e8978ef48881e32fac91b93b47333e2ba5706fba23eb7c5af0d9f80940ca771b6ffd5857baaf222eb95a7d2809d61bfe02e1bfd1b68ff02f0b8102ae1c2d5d5ab1a19f26337d205fb469cd6bd15c3d5a04dc88784fbb3d0b2dbdea54d43b2b73f2cbb12d58386a8703e0f948226e47ee89d018107154f25a764bd3c79937a45b84546da634b8f6be14a8061e55cceba478b23f7dacaa35c8ca78beae9624045b4b601b2f522473d171391125ba84dc4007cfbf2f8da752f7c74185203fcca589ac719c34dffbbaad8431dad1c1fb597aaa5193502b86edb8857c273fa075a50512937e0794e1e65a7617c90d8bd66065b1fffe51d7a579973b1315021ec3c19934f1368bb445c7c2d209703f239689ce34c0378a68e72a6b3b216da0e22a5031b54ddff57309396b38c881c4c849ec23e87089a1c5b46e5110b86750ec6a532348868a84045483c92b7af5af689452eafabf1a8943e50439f1d59882a98eaa0170f1250ebd871fc0a92a7b2d83168d0d727272d441befa15c503dd8e90ce98db3e7b6d194f60839c508a84305aaca1789b6",
+}
+
+func TestPairing(t *testing.T) {
+
+	curves := []*Curve{BN254(), BLS12381()}
+
+	for _, c := range curves {
+		c := c
+		t.Run("TestParameters"+c.Name, func(t *testing.T) {
+			u := c.U
+			var p *nt.Integer
+			if c.family == bnFamily {
+				p = evalInt(u, 1, 6, 24, 36, 36)
+			} else {
+				p = nt.Add(nt.Div(nt.Mul(evalInt(u, 1, -2, 1), c.R), nt.FromInt64(3)), u)
+			}
+			if p.Cmp(c.Fp.Modulus()) != 0 {
+				t.Error("p doesn't match the parameter u")
+			}
+			if !c.R.ProbablyPrime(16) {
+				t.Error("r isn't prime")
+			}
+			if !c.G1.InSubgroup(c.G1.Generator()) {
+				t.Error("G1 generator isn't of order r")
+			}
+			if !c.G2.InSubgroup(c.G2.Generator()) {
+				t.Error("G2 generator isn't of order r")
+			}
+		})
+
+		t.Run("TestGroups"+c.Name, func(t *testing.T) {
+			g1, g2 := c.G1, c.G2
+			a, b := randScalar(t, c), randScalar(t, c)
+			P, Q := g1.Generator(), g2.Generator()
+			// (a + b)P = aP + bP
+			if !g1.Equal(g1.ScalarMul(P, nt.Add(a, b)), g1.Add(g1.ScalarMul(P, a), g1.ScalarMul(P, b))) {
+				t.Error("G1 scalar multiplication isn't linear")
+			}
+			if !g2.Equal(g2.ScalarMul(Q, nt.Add(a, b)), g2.Add(g2.ScalarMul(Q, a), g2.ScalarMul(Q, b))) {
+				t.Error("G2 scalar multiplication isn't linear")
+			}
+			if !g1.Add(P, g1.Neg(P)).Inf || !g2.Add(Q, g2.Neg(Q)).Inf {
+				t.Error("P - P != O")
+			}
+			if !g2.Equal(g2.Frobenius(Q), g2.ScalarMul(Q, c.Fp.Modulus())) {
+				t.Error("Frobenius doesn't act as multiplication by p on G2")
+			}
+			// points found by increments are mapped to the subgroups
+			for x := int64(1); ; x++ {
+				R, err := g1.At(nt.FromInt64(x))
+				if err != nil {
+					continue
+				}
+				if !g1.InSubgroup(g1.ClearCofactor(R)) {
+					t.Error("cofactor clearing fails in G1")
+				}
+				break
+			}
+			for x := int64(1); ; x++ {
+				R, err := g2.At(c.Fp2.NewFromInt64(x, 1))
+				if err != nil {
+					continue
+				}
+				if !g2.IsOnCurve(R) {
+					t.Fatal("At returned a point off the twist")
+				}
+				if !g2.InSubgroup(g2.ClearCofactor(R)) {
+					t.Error("cofactor clearing fails in G2")
+				}
+				break
+			}
+		})
+
+		t.Run("TestEncoding"+c.Name, func(t *testing.T) {
+			size := c.FpSize()
+			if size != (c.Fp.Modulus().BitLen()+7)/8 || size*8 < c.Fp.Modulus().BitLen() {
+				t.Fatal("wrong byte size of Fp", size)
+			}
+			xs := []*nt.Integer{nt.FromInt64(0), nt.FromInt64(1), nt.Sub(c.Fp.Modulus(), nt.One)}
+			b := c.AppendFp([]byte{0xff}, xs...)
+			if len(b) != 1+3*size || b[1+size-1] != 0 || b[1+2*size-1] != 1 {
+				t.Fatal("integers aren't encoded big endian on FpSize bytes")
+			}
+			for i, x := range c.ReadFp(b[1:], 3) {
+				if !nt.Equal(x, xs[i]) {
+					t.Error("ReadFp doesn't invert AppendFp expected", xs[i], "got", x)
+				}
+			}
+		})
+		t.Run("TestVector"+c.Name, func(t *testing.T) {
+			e := c.Pair(c.G1.Generator(), c.G2.Generator())
+			if got := gtHex(c, e); got != pairingVectors[c.Name] {
+				t.Error("e(G1, G2) doesn't match the test vector", got)
+			}
+		})
+
+		t.Run("TestBilinearity"+c.Name, func(t *testing.T) {
+			a, b := randScalar(t, c), randScalar(t, c)
+			P, Q := c.G1.Generator(), c.G2.Generator()
+			e := c.Pair(P, Q)
+			// non degeneracy and e(P, Q)^r = 1
+			if c.Fp12.IsOne(e) {
+				t.Error("e(G1, G2) = 1")
+			}
+			if !c.Fp12.IsOne(c.GTExp(e, c.R)) {
+				t.Error("e(G1, G2) isn't an r-th root of unity")
+			}
+			eab := c.Pair(c.G1.ScalarMul(P, a), c.G2.ScalarMul(Q, b))
+			if !c.Fp12.Equal(eab, c.GTExp(e, nt.ModMul(a, b, c.R))) {
+				t.Error("e(aP, bQ) != e(P, Q)^ab")
+			}
+			if !c.Fp12.IsOne(c.Pair(c.G1.Infinity(), Q)) || !c.Fp12.IsOne(c.Pair(P, c.G2.Infinity())) {
+				t.Error("pairing with the point at infinity isn't 1")
+			}
+		})
+
+		t.Run("TestMultiPair"+c.Name, func(t *testing.T) {
+			a, b := randScalar(t, c), randScalar(t, c)
+			P, Q := c.G1.Generator(), c.G2.Generator()
+			// e(aP, bQ) e(-abP, Q) = 1
+			ps := []*G1Point{c.G1.ScalarMul(P, a), c.G1.ScalarMul(c.G1.Neg(P), nt.ModMul(a, b, c.R))}
+			qs := []*G2Point{c.G2.ScalarMul(Q, b), Q}
+			ok, err := c.PairingCheck(ps, qs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Error("pairing check fails")
+			}
+			ps[1] = P
+			if ok, _ := c.PairingCheck(ps, qs); ok {
+				t.Error("pairing check should fail")
+			}
+			if _, err := c.MultiPair(ps, qs[:1]); err == nil {
+				t.Error("mismatched lengths should fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package pairing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/actuallyachraf/algebra/ec"
+	"github.com/actuallyachraf/algebra/ext"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+var (
+	errEmbeddingDegree = errors.New("r doesn't divide p^k - 1")
+	errDegenerate      = errors.New("miller function has a zero or a pole at the evaluation point")
+	errNoDistortion    = errors.New("no known distortion map for this curve")
+)
+
+// ExtPoint is an affine point on E(GF(p^k)), Inf marks the point at infinity
+type ExtPoint struct {
+	X, Y ext.Element
+	Inf  bool
+}
+
+// String implements stringer
+func (p *ExtPoint) String() string {
+	if p.Inf {
+		return "O"
+	}
+	return fmt.Sprintf("(%v, %v)", p.X, p.Y)
+}
+
+// Textbook implements the Tate and Weil pairings with Miller's algorithm on
+// a curve E: y^2 = x^3 + ax + b over Fp with points of prime order r defined
+// over GF(p^k). It favors clarity over speed, every line and vertical is
+// evaluated in GF(p^k) and divided out explicitly.
+// ref : Elliptic Curves Number Theory and Cryptography (Washington) chapter 11
+type Textbook struct {
+	Curve *ec.Curve
+	R     *nt.Integer
+	F     *ext.ExtensionField
+	a, b  ext.Element
+}
+
+// NewTextbook returns the pairings of order r on c over the extension F of
+// degree k, r must divide p^k - 1.
+func NewTextbook(c *ec.Curve, r *nt.Integer, F *ext.ExtensionField) (*Textbook, error) {
+	if nt.Mod(nt.Sub(F.Order(), nt.One), r).Sign() != 0 {
+		return nil, errEmbeddingDegree
+	}
+	return &Textbook{Curve: c, R: r, F: F, a: F.FromBase(c.A), b: F.FromBase(c.B)}, nil
+}
+
+// Infinity returns the point at infinity
+func (t *Textbook) Infinity() *ExtPoint {
+	return &ExtPoint{X: t.F.Zero(), Y: t.F.Zero(), Inf: true}
+}
+
+// Lift embeds a point of E(Fp) in E(GF(p^k)), ec.Inf is mapped to infinity
+func (t *Textbook) Lift(p *ec.Point) *ExtPoint {
+	if p.Equal(ec.Inf) {
+		return t.Infinity()
+	}
+	return &ExtPoint{X: t.F.NewElement(p.X), Y: t.F.NewElement(p.Y)}
+}
+
+// NewPoint returns (x, y) if it's on the curve
+func (t *Textbook) NewPoint(x, y ext.Element) (*ExtPoint, error) {
+	p := &ExtPoint{X: x, Y: y}
+	if !t.IsOnCurve(p) {
+		return nil, errNotOnCurve
+	}
+	return p, nil
+}
+
+// IsOnCurve checks that y^2 = x^3 + ax + b
+func (t *Textbook) IsOnCurve(p *ExtPoint) bool {
+	if p.Inf {
+		return true
+	}
+	F := t.F
+	rhs := F.Add(F.Mul(F.Add(p.X.Square(), t.a), p.X), t.b)
+	return p.Y.Square().Equal(rhs)
+}
+
+// Equal checks if two points are equal
+func (t *Textbook) Equal(p, q *ExtPoint) bool {
+	if p.Inf || q.Inf {
+		return p.Inf == q.Inf
+	}
+	return p.X.Equal(q.X) && p.Y.Equal(q.Y)
+}
+
+// Neg computes -(x, y) = (x, -y)
+func (t *Textbook) Neg(p *ExtPoint) *ExtPoint {
+	if p.Inf {
+		return t.Infinity()
+	}
+	return &ExtPoint{X: p.X, Y: p.Y.Neg()}
+}
+
+// slope returns the slope of the line through p and q, the tangent when
+// p = q, and false when the line is vertical.
+func (t *Textbook) slope(p, q *ExtPoint) (ext.Element, bool) {
+	F := t.F
+	if p.X.Equal(q.X) {
+		if !p.Y.Equal(q.Y) || p.Y.IsZero() {
+			return ext.Element{}, false
+		}
+		// (3x^2 + a)/2y
+		num := F.Add(F.Mul(F.NewElementFromInt64(3), p.X.Square()), t.a)
+		l, _ := F.Div(num, p.Y.Double())
+		return l, true
+	}
+	l, _ := F.Div(F.Sub(q.Y, p.Y), F.Sub(q.X, p.X))
+	return l, true
+}
+
+// Add computes p + q
+func (t *Textbook) Add(p, q *ExtPoint) *ExtPoint {
+	switch {
+	case p.Inf:
+		return q
+	case q.Inf:
+		return p
+	}
+	l, ok := t.slope(p, q)
+	if !ok {
+		return t.Infinity()
+	}
+	F := t.F
+	x3 := F.Sub(F.Sub(l.Square(), p.X), q.X)
+	y3 := F.Sub(F.Mul(l, F.Sub(p.X, x3)), p.Y)
+	return &ExtPoint{X: x3, Y: y3}
+}
+
+// ScalarMul computes kp using double and add
+func (t *Textbook) ScalarMul(p *ExtPoint, k *nt.Integer) *ExtPoint {
+	if k.Sign() < 0 {
+		return t.ScalarMul(t.Neg(p), new(nt.Integer).Neg(k))
+	}
+	r := t.Infinity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = t.Add(r, r)
+		if k.Bit(i) == 1 {
+			r = t.Add(r, p)
+		}
+	}
+	return r
+}
+
+// lineOverVertical evaluates at s the function l/v of divisor
+// (p) + (q) - (p + q) - (O) where l is the line through p and q and v the
+// vertical through p + q, it returns p + q as well.
+func (t *Textbook) lineOverVertical(p, q, s *ExtPoint) (ext.Element, *ExtPoint, error) {
+	F := t.F
+	if p.Inf || q.Inf {
+		return F.One(), t.Add(p, q), nil
+	}
+	l, ok := t.slope(p, q)
+	if !ok {
+		// p + q = O and the line is the vertical x - xp
+		v := F.Sub(s.X, p.X)
+		if v.IsZero() {
+			return ext.Element{}, nil, errDegenerate
+		}
+		return v, t.Infinity(), nil
+	}
+	r := t.Add(p, q)
+	// y - yp - l(x - xp)
+	num := F.Sub(F.Sub(s.Y, p.Y), F.Mul(l, F.Sub(s.X, p.X)))
+	den := F.Sub(s.X, r.X)
+	if num.IsZero() || den.IsZero() {
+		return ext.Element{}, nil, errDegenerate
+	}
+	g, _ := F.Div(num, den)
+	return g, r, nil
+}
+
+// Miller computes f_{r,P}(S) where f_{r,P} is the function of divisor
+// r(P) - r(O) when rP = O, using the recurrence
+// f_{i+j} = f_i f_j l_{iP,jP}/v_{(i+j)P}.
+func (t *Textbook) Miller(p, s *ExtPoint) (ext.Element, error) {
+	F := t.F
+	if p.Inf || s.Inf {
+		return ext.Element{}, errDegenerate
+	}
+	f := F.One()
+	T := p
+	for i := t.R.BitLen() - 2; i >= 0; i-- {
+		g, T2, err := t.lineOverVertical(T, T, s)
+		if err != nil {
+			return ext.Element{}, err
+		}
+		f, T = F.Mul(f.Square(), g), T2
+		if t.R.Bit(i) == 1 {
+			g, T2, err = t.lineOverVertical(T, p, s)
+			if err != nil {
+				return ext.Element{}, err
+			}
+			f, T = F.Mul(f, g), T2
+		}
+	}
+	return f, nil
+}
+
+// Tate computes the reduced Tate pairing f_{r,P}(Q)^((p^k - 1)/r)
+func (t *Textbook) Tate(p, q *ExtPoint) (ext.Element, error) {
+	f, err := t.Miller(p, q)
+	if err != nil {
+		return ext.Element{}, err
+	}
+	return f.Exp(nt.Div(nt.Sub(t.F.Order(), nt.One), t.R)), nil
+}
+
+// Weil computes the Weil pairing e_r(P, Q) = (-1)^r f_{r,P}(Q)/f_{r,Q}(P)
+// for P != Q, it's an alternating r-th root of unity.
+func (t *Textbook) Weil(p, q *ExtPoint) (ext.Element, error) {
+	if t.Equal(p, q) {
+		return t.F.One(), nil
+	}
+	fp, err := t.Miller(p, q)
+	if err != nil {
+		return ext.Element{}, err
+	}
+	fq, err := t.Miller(q, p)
+	if err != nil {
+		return ext.Element{}, err
+	}
+	w, err := t.F.Div(fp, fq)
+	if err != nil {
+		return ext.Element{}, err
+	}
+	if t.R.Bit(0) == 1 {
+		w = w.Neg()
+	}
+	return w, nil
+}
+
+// rootOfUnity returns a primitive n-th root of unity of GF(p^k) where n is
+// a prime power dividing p^k - 1 with smallest prime factor d, z is
+// primitive if z^(n/d) != 1.
+func (t *Textbook) rootOfUnity(n, d int64) (ext.Element, error) {
+	F := t.F
+	e := nt.Div(nt.Sub(F.Order(), nt.One), nt.FromInt64(n))
+	for c := int64(1); c < 256; c++ {
+		z := F.Add(F.Gen(), F.NewElementFromInt64(c)).Exp(e)
+		if !z.Exp(nt.FromInt64(n / d)).IsOne() {
+			return z, nil
+		}
+	}
+	return ext.Element{}, errNoDistortion
+}
+
+// Distort applies the distortion map of the supersingular curves
+// y^2 = x^3 + ax with p = 3 mod 4, (x, y) -> (-x, iy) where i^2 = -1, and
+// y^2 = x^3 + b with p = 2 mod 3, (x, y) -> (zx, y) where z^3 = 1. It maps
+// points of E(Fp) to independent points of E(GF(p^2)) so that the modified
+// pairing e(P, Distort(P)) isn't degenerate.
+func (t *Textbook) Distort(p *ExtPoint) (*ExtPoint, error) {
+	if p.Inf {
+		return p, nil
+	}
+	F := t.F
+	mod := func(n int64) int64 {
+		return nt.Mod(t.Curve.F.Modulus(), nt.FromInt64(n)).Int64()
+	}
+	switch {
+	case t.b.IsZero() && mod(4) == 3 && F.Degree()%2 == 0:
+		i, err := t.rootOfUnity(4, 2)
+		if err != nil {
+			return nil, err
+		}
+		return &ExtPoint{X: p.X.Neg(), Y: F.Mul(i, p.Y)}, nil
+	case t.a.IsZero() && mod(3) == 2 && F.Degree()%2 == 0:
+		z, err := t.rootOfUnity(3, 3)
+		if err != nil {
+			return nil, err
+		}
+		return &ExtPoint{X: F.Mul(z, p.X), Y: p.Y}, nil
+	}
+	return nil, errNoDistortion
+}
@@ -0,0 +1,168 @@
+package pairing
+
+import (
+	"testing"
+
+	"github.com/actuallyachraf/algebra/ec"
+	"github.com/actuallyachraf/algebra/ext"
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+// toyPairing returns the pairings of order r on y^2 = x^3 + ax + b over
+// Fp with GF(p^2) = Fp[i]/(i^2 - nr) and a point of order r
+func toyPairing(t *testing.T, p, a, b, r, nr int64) (*Textbook, *ExtPoint) {
+	fp, _ := ff.NewFiniteField(nt.FromInt64(p))
+	c := ec.NewEllipticCurve(fp.NewFieldElementFromInt64(a), fp.NewFieldElementFromInt64(b), fp)
+	F, err := ext.NewExtensionField(fp, poly.NewPolynomialInts(int(-nr), 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb, err := NewTextbook(c, nt.FromInt64(r), F)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// #E(Fp) = p + 1 for supersingular curves
+	h := nt.FromInt64((p + 1) / r)
+	for x := int64(1); x < p; x++ {
+		pt, err := c.At(nt.FromInt64(x))
+		if err != nil {
+			continue
+		}
+		if P := tb.ScalarMul(tb.Lift(pt), h); !P.Inf {
+			return tb, P
+		}
+	}
+	t.Fatal("no point of order r")
+	return nil, nil
+}
+
+func TestTextbook(t *testing.T) {
+
+	t.Run("TestEmbeddingDegree", func(t *testing.T) {
+		fp, _ := ff.NewFiniteField(nt.FromInt64(59))
+		c := ec.NewEllipticCurve(fp.One(), fp.Zero(), fp)
+		F, _ := ext.NewExtensionField(fp, poly.NewPolynomialInts(1, 0, 1))
+		// 7 doesn't divide 59^2 - 1
+		if _, err := NewTextbook(c, nt.FromInt64(7), F); err == nil {
+			t.Error("r must divide p^k - 1")
+		}
+	})
+
+	// y^2 = x^3 + x over F59 has 60 points and y^2 = x^3 + 1 over F29 has 30,
+	// points of order 3 on the latter are (0, +-1) which are fixed by the
+	// distortion map so we use r = 5.
+	toys := []struct {
+		name           string
+		p, a, b, r, nr int64
+	}{
+		{"F59", 59, 1, 0, 5, -1},
+		{"F29", 29, 0, 1, 5, 2},
+	}
+	for _, toy := range toys {
+		toy := toy
+		t.Run("TestWeil"+toy.name, func(t *testing.T) {
+			tb, P := toyPairing(t, toy.p, toy.a, toy.b, toy.r, toy.nr)
+			Q, err := tb.Distort(P)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tb.IsOnCurve(Q) || !tb.ScalarMul(Q, tb.R).Inf {
+				t.Fatal("distortion map doesn't preserve the r-torsion")
+			}
+			e, err := tb.Weil(P, Q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.IsOne() || !e.Exp(tb.R).IsOne() {
+				t.Error("e(P, Q) isn't a primitive r-th root of unity")
+			}
+			if w, _ := tb.Weil(P, P); !w.IsOne() {
+				t.Error("e(P, P) != 1")
+			}
+			// e(Q, P) = e(P, Q)^-1
+			eqp, _ := tb.Weil(Q, P)
+			if !tb.F.Mul(e, eqp).IsOne() {
+				t.Error("Weil pairing isn't alternating")
+			}
+			// e(2P, 3Q) = e(P, Q)^6
+			e23, err := tb.Weil(tb.ScalarMul(P, nt.FromInt64(2)), tb.ScalarMul(Q, nt.FromInt64(3)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !e23.Equal(e.Exp(nt.FromInt64(6))) {
+				t.Error("Weil pairing isn't bilinear")
+			}
+		})
+
+		t.Run("TestTate"+toy.name, func(t *testing.T) {
+			tb, P := toyPairing(t, toy.p, toy.a, toy.b, toy.r, toy.nr)
+			Q, _ := tb.Distort(P)
+			e, err := tb.Tate(P, Q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.IsOne() || !e.Exp(tb.R).IsOne() {
+				t.Error("t(P, Q) isn't a primitive r-th root of unity")
+			}
+			for a := int64(1); a < toy.r; a++ {
+				for b := int64(1); b < toy.r; b++ {
+					eab, err := tb.Tate(tb.ScalarMul(P, nt.FromInt64(a)), tb.ScalarMul(Q, nt.FromInt64(b)))
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !eab.Equal(e.Exp(nt.FromInt64(a * b))) {
+						t.Error("Tate pairing isn't bilinear")
+					}
+				}
+			}
+			// e_r(P, Q)^((q - 1)/r) = t(P, Q)/t(Q, P)
+			w, _ := tb.Weil(P, Q)
+			eqp, _ := tb.Tate(Q, P)
+			ratio, _ := tb.F.Div(e, eqp)
+			if !w.Exp(nt.Div(nt.Sub(tb.F.Order(), nt.One), tb.R)).Equal(ratio) {
+				t.Error("Weil and Tate pairings disagree")
+			}
+		})
+	}
+
+	t.Run("TestAteOracle", func(t *testing.T) {
+		// for BLS12 curves the optimal ate pairing is the ate pairing with
+		// T = t - 1 = u, which relates to the Tate pairing by
+		// t(Q, P)^L = a(Q, P)^c with L = (u^12 - 1)/r and c = 12u^11 mod r,
+		// our final exponentiation computes a(Q, P)^3.
+		c := BLS12381()
+		tw := c.Tower
+		curve := ec.NewEllipticCurve(tw.Fp.Zero(), c.G1.B, tw.Fp)
+		F := flatField(t, tw)
+		tb, err := NewTextbook(curve, c.R, F)
+		if err != nil {
+			t.Fatal(err)
+		}
+		P, Q := c.G1.Generator(), c.G2.Generator()
+		Pe := tb.Lift(&ec.Point{X: P.X.Big(), Y: P.Y.Big()})
+		// untwist (x, y) -> (x/w^2, y/w^3)
+		embed := func(a Fp2) ext.Element {
+			return flatten(tw, F, Fp12{Fp6{a, tw.Fp2.Zero(), tw.Fp2.Zero()}, tw.Fp6.Zero()})
+		}
+		w := F.Gen()
+		x, _ := F.Div(embed(Q.X), w.Square())
+		y, _ := F.Div(embed(Q.Y), w.Exp(nt.FromInt64(3)))
+		Qe, err := tb.NewPoint(x, y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tate, err := tb.Tate(Qe, Pe)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := c.U
+		L := nt.Div(nt.Sub(new(nt.Integer).Exp(u, nt.FromInt64(12), nil), nt.One), c.R)
+		k := nt.Mod(nt.Mul(nt.FromInt64(12), new(nt.Integer).Exp(u, nt.FromInt64(11), nil)), c.R)
+		ate := flatten(tw, F, c.GTExp(c.Pair(P, Q), k))
+		if !ate.Equal(tate.Exp(nt.Mod(nt.Mul(nt.FromInt64(3), L), c.R))) {
+			t.Error("optimal ate pairing disagrees with the Tate pairing")
+		}
+	})
+}
@@ -0,0 +1,71 @@
+// Package pairing implements bilinear pairings on the pairing-friendly curves
+// BN254 and BLS12-381 and the tower of extension fields they require.
+// Fp12 is built as Fp2 = Fp[u]/(u^2 - beta), Fp6 = Fp2[v]/(v^3 - xi) and
+// Fp12 = Fp6[w]/(w^2 - v) so that multiplications break down into
+// Karatsuba products of the lower levels.
+// ref : Implementing Pairing Based Cryptography and Pairings For Beginners
+package pairing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+var (
+	errResidue     = errors.New("extension requires a non residue")
+	errZeroInverse = errors.New("zero has no multiplicative inverse")
+	errNoSqrt      = errors.New("element is not a square")
+	errNotOnCurve  = errors.New("point is not on the curve")
+)
+
+// Tower holds the fields Fp, Fp2, Fp6 and Fp12 of a pairing-friendly curve
+type Tower struct {
+	Fp   ff.FiniteField
+	Fp2  *Fp2Field
+	Fp6  *Fp6Field
+	Fp12 *Fp12Field
+}
+
+// NewTower builds the tower over Fp with u^2 = beta and v^3 = xi0 + xi1u
+func NewTower(p *nt.Integer, beta int64, xi0, xi1 int64) (*Tower, error) {
+	fp, err := ff.NewFiniteField(p)
+	if err != nil {
+		return nil, err
+	}
+	fp2, err := NewFp2Field(fp, nt.FromInt64(beta))
+	if err != nil {
+		return nil, err
+	}
+	fp6, err := NewFp6Field(fp2, fp2.NewFromInt64(xi0, xi1))
+	if err != nil {
+		return nil, err
+	}
+	return &Tower{Fp: fp, Fp2: fp2, Fp6: fp6, Fp12: NewFp12Field(fp6)}, nil
+}
+
+// Base field moduli of the supported curves
+var (
+	BN254P, _    = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+	BLS12381P, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+)
+
+// BN254Tower returns the tower of BN254 with u^2 = -1 and v^3 = 9 + u
+func BN254Tower() *Tower {
+	t, err := NewTower(BN254P, -1, 9, 1)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// BLS12381Tower returns the tower of BLS12-381 with u^2 = -1 and v^3 = 1 + u
+func BLS12381Tower() *Tower {
+	t, err := NewTower(BLS12381P, -1, 1, 1)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
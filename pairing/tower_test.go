@@ -0,0 +1,152 @@
+package pairing
+
+import (
+	"testing"
+
+	"github.com/actuallyachraf/algebra/ext"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/poly"
+)
+
+// flatField builds Fp12 as Fp[w]/(w^12 - 2xi0w^6 + xi0^2 - beta) which is the
+// same field as the tower when xi = xi0 + u since u = w^6 - xi0.
+func flatField(t *testing.T, tw *Tower) *ext.ExtensionField {
+	p := tw.Fp.Modulus()
+	xi0 := tw.Fp6.Xi().C0.Big()
+	f := make(poly.Polynomial, 13)
+	for i := range f {
+		f[i] = nt.FromInt64(0)
+	}
+	f[0] = nt.Mod(nt.Sub(nt.Mul(xi0, xi0), tw.Fp2.Beta().Big()), p)
+	f[6] = nt.Mod(nt.Mul(nt.FromInt64(-2), xi0), p)
+	f[12] = nt.FromInt64(1)
+	E, err := ext.NewExtensionField(tw.Fp, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return E
+}
+
+// flatten maps sum c_kw^k with c_k = a + bu to Fp[w]
+func flatten(tw *Tower, E *ext.ExtensionField, a Fp12) ext.Element {
+	p := tw.Fp.Modulus()
+	xi0 := tw.Fp6.Xi().C0.Big()
+	c := make([]*nt.Integer, 12)
+	for i := range c {
+		c[i] = nt.FromInt64(0)
+	}
+	coeffs := []Fp2{a.C0.C0, a.C1.C0, a.C0.C1, a.C1.C1, a.C0.C2, a.C1.C2}
+	for k, ck := range coeffs {
+		// a + b(w^6 - xi0)
+		c[k] = nt.Mod(nt.Sub(ck.C0.Big(), nt.Mul(ck.C1.Big(), xi0)), p)
+		c[k+6] = nt.Mod(ck.C1.Big(), p)
+	}
+	return E.NewElement(c...)
+}
+
+func TestTower(t *testing.T) {
+
+	towers := map[string]*Tower{
+		"BN254":     BN254Tower(),
+		"BLS12-381": BLS12381Tower(),
+	}
+
+	t.Run("TestNonResidue", func(t *testing.T) {
+		tw := BN254Tower()
+		// -1 is a non residue since p = 3 mod 4 but 4 is a square
+		if _, err := NewFp2Field(tw.Fp, nt.FromInt64(4)); err == nil {
+			t.Error("4 is a quadratic residue")
+		}
+		// 1 + u is a square root of 2u which isn't a valid xi
+		if _, err := NewFp6Field(tw.Fp2, tw.Fp2.NewFromInt64(0, 2)); err == nil {
+			t.Error("2u is a square in Fp2")
+		}
+	})
+
+	for name, tw := range towers {
+		tw := tw
+		t.Run("TestFlat"+name, func(t *testing.T) {
+			E := flatField(t, tw)
+			F := tw.Fp12
+			a, _ := F.Rand()
+			b, _ := F.Rand()
+			fa, fb := flatten(tw, E, a), flatten(tw, E, b)
+			if !flatten(tw, E, F.Mul(a, b)).Equal(E.Mul(fa, fb)) {
+				t.Error("multiplication disagrees with Fp[w]")
+			}
+			if !flatten(tw, E, F.Square(a)).Equal(fa.Square()) {
+				t.Error("squaring disagrees with Fp[w]")
+			}
+			inv, err := F.Inv(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !F.IsOne(F.Mul(a, inv)) {
+				t.Error("a * a^-1 != 1")
+			}
+			for i := 1; i < 12; i++ {
+				if !flatten(tw, E, F.Frobenius(a, i)).Equal(fa.Frobenius(i)) {
+					t.Error("Frobenius disagrees with Fp[w] for i =", i)
+				}
+			}
+			if _, err := F.Inv(F.Zero()); err == nil {
+				t.Error("zero is not invertible")
+			}
+		})
+
+		t.Run("TestFrobenius"+name, func(t *testing.T) {
+			p := tw.Fp.Modulus()
+			a, _ := tw.Fp6.Rand()
+			if !tw.Fp6.Equal(tw.Fp6.Frobenius(a, 1), tw.Fp6.Exp(a, p)) {
+				t.Error("Fp6 Frobenius disagrees with a^p")
+			}
+			if !tw.Fp6.Equal(tw.Fp6.Frobenius(a, 6), a) {
+				t.Error("a^(p^6) != a in Fp6")
+			}
+			b, _ := tw.Fp12.Rand()
+			if !tw.Fp12.Equal(tw.Fp12.Frobenius(b, 6), tw.Fp12.Conjugate(b)) {
+				t.Error("a^(p^6) isn't the conjugate")
+			}
+			if !tw.Fp12.Equal(tw.Fp12.Frobenius(b, 1), tw.Fp12.Exp(b, p)) {
+				t.Error("Fp12 Frobenius disagrees with a^p")
+			}
+		})
+
+		t.Run("TestCyclotomic"+name, func(t *testing.T) {
+			F := tw.Fp12
+			a, _ := F.Rand()
+			// g = a^((p^6 - 1)(p^2 + 1)) lies in the cyclotomic subgroup
+			inv, _ := F.Inv(a)
+			g := F.Mul(F.Conjugate(a), inv)
+			g = F.Mul(F.Frobenius(g, 2), g)
+			if !F.Equal(F.CyclotomicSquare(g), F.Square(g)) {
+				t.Error("cyclotomic squaring disagrees with squaring")
+			}
+			if !F.IsOne(F.Mul(g, F.Conjugate(g))) {
+				t.Error("conjugate isn't the inverse in the cyclotomic subgroup")
+			}
+			k := nt.FromInt64(-4965661367192848881)
+			if !F.Equal(F.CyclotomicExp(g, k), F.Conjugate(F.Exp(g, new(nt.Integer).Neg(k)))) {
+				t.Error("cyclotomic exponentiation disagrees with Exp")
+			}
+		})
+
+		t.Run("TestSparse"+name, func(t *testing.T) {
+			F := tw.Fp12
+			fp2 := tw.Fp2
+			a, _ := F.Rand()
+			c0, _ := fp2.Rand()
+			c1, _ := fp2.Rand()
+			c2, _ := fp2.Rand()
+			z := fp2.Zero()
+			b034 := Fp12{Fp6{c0, z, z}, Fp6{c1, c2, z}}
+			if !F.Equal(F.MulBy034(a, c0, c1, c2), F.Mul(a, b034)) {
+				t.Error("MulBy034 disagrees with Mul")
+			}
+			b014 := Fp12{Fp6{c0, c1, z}, Fp6{z, c2, z}}
+			if !F.Equal(F.MulBy014(a, c0, c1, c2), F.Mul(a, b014)) {
+				t.Error("MulBy014 disagrees with Mul")
+			}
+		})
+	}
+}
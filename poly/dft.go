@@ -0,0 +1,287 @@
+package poly
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/ntt"
+)
+
+// maxRadix is the largest prime factor handled by a Cooley-Tukey step,
+// lengths left with larger factors only are transformed with Bluestein's
+// algorithm.
+const maxRadix = 13
+
+var (
+	errLength = errors.New("number of values doesn't match the transform length")
+	errZero   = errors.New("chirp-z ratio must be non zero")
+)
+
+// DFT is the discrete Fourier transform of length n over a prime field
+// A_k = sum a_j w^(jk) where w is a primitive n-th root of unity, it exists
+// for every n dividing q - 1. Lengths with small prime factors are
+// transformed by a mixed radix Cooley-Tukey recursion and the remaining
+// factor by Bluestein's algorithm which turns the transform into a product
+// of polynomials computed by MulFast, the cost is O(n log n) field products
+// up to the small factors.
+type DFT struct {
+	f        ff.FiniteField
+	n        int
+	w        *nt.Integer
+	nInv     *nt.Integer
+	fwd, inv *stage
+}
+
+// stage is one level of the mixed radix recursion over a length n with a
+// root w of order n, it either splits n = radix m or runs a chirp.
+type stage struct {
+	n, radix int
+	// twiddles[i] = w^i
+	twiddles []*nt.Integer
+	sub      *stage
+	chirp    *chirp
+}
+
+// NewDFT returns the transform of length n over the field f
+func NewDFT(f ff.FiniteField, n int) (*DFT, error) {
+	q := f.Modulus()
+	w, err := ntt.PrimitiveRootOfUnity(int64(n), q)
+	if err != nil {
+		return nil, err
+	}
+	wInv := nt.ModInv(w, q)
+	return &DFT{
+		f:    f,
+		n:    n,
+		w:    w,
+		nInv: nt.ModInv(nt.FromInt64(int64(n)), q),
+		fwd:  newStage(n, w, q),
+		inv:  newStage(n, wInv, q),
+	}, nil
+}
+
+// newStage plans the transform of length n with the root w of order n
+func newStage(n int, w, q *nt.Integer) *stage {
+	s := &stage{n: n}
+	if n == 1 {
+		return s
+	}
+	p := smallestFactor(n)
+	if p > maxRadix {
+		s.chirp = newChirp(w, n, n, q)
+		return s
+	}
+	s.radix = p
+	s.twiddles = make([]*nt.Integer, n)
+	s.twiddles[0] = nt.FromInt64(1)
+	for i := 1; i < n; i++ {
+		s.twiddles[i] = nt.ModMul(s.twiddles[i-1], w, q)
+	}
+	s.sub = newStage(n/p, nt.ModExp(w, nt.FromInt64(int64(p)), q), q)
+	return s
+}
+
+// smallestFactor returns the smallest prime factor of n > 1
+func smallestFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
+
+// transform returns the transform of a of length s.n
+func (s *stage) transform(a []*nt.Integer, q *nt.Integer) []*nt.Integer {
+	if s.n == 1 {
+		return []*nt.Integer{nt.Mod(a[0], q)}
+	}
+	if s.chirp != nil {
+		return s.chirp.transform(a)
+	}
+	// decimation in time, writing j = r + p i the transform is
+	// A_k = sum_r w^(rk) B_r[k mod m] where B_r is the transform of length m
+	// of the subsequence a_(r + p i)
+	p, m := s.radix, s.n/s.radix
+	subs := make([][]*nt.Integer, p)
+	for r := range subs {
+		x := make([]*nt.Integer, m)
+		for i := range x {
+			x[i] = a[r+p*i]
+		}
+		subs[r] = s.sub.transform(x, q)
+	}
+	out := make([]*nt.Integer, s.n)
+	for k := range out {
+		acc := new(nt.Integer).Set(subs[0][k%m])
+		for r := 1; r < p; r++ {
+			acc.Add(acc, nt.Mul(s.twiddles[(r*k)%s.n], subs[r][k%m]))
+		}
+		out[k] = acc.Mod(acc, q)
+	}
+	return out
+}
+
+// Len returns the length of the transform
+func (d *DFT) Len() int {
+	return d.n
+}
+
+// Root returns the primitive n-th root of unity w
+func (d *DFT) Root() ff.FieldElement {
+	return d.f.NewFieldElement(d.w)
+}
+
+func (d *DFT) values(a []ff.FieldElement) ([]*nt.Integer, error) {
+	if len(a) != d.n {
+		return nil, errLength
+	}
+	v := make([]*nt.Integer, len(a))
+	for i := range a {
+		v[i] = a[i].Big()
+	}
+	return v, nil
+}
+
+func (d *DFT) elements(v []*nt.Integer) []ff.FieldElement {
+	out := make([]ff.FieldElement, len(v))
+	for i := range v {
+		out[i] = d.f.NewFieldElement(v[i])
+	}
+	return out
+}
+
+// Transform returns A_k = sum a_j w^(jk) for k in [0, n)
+func (d *DFT) Transform(a []ff.FieldElement) ([]ff.FieldElement, error) {
+	v, err := d.values(a)
+	if err != nil {
+		return nil, err
+	}
+	return d.elements(d.fwd.transform(v, d.f.Modulus())), nil
+}
+
+// Inverse returns a_j = n^-1 sum A_k w^(-jk) for j in [0, n)
+func (d *DFT) Inverse(a []ff.FieldElement) ([]ff.FieldElement, error) {
+	v, err := d.values(a)
+	if err != nil {
+		return nil, err
+	}
+	q := d.f.Modulus()
+	out := d.inv.transform(v, q)
+	for i := range out {
+		out[i] = nt.ModMul(out[i], d.nInv, q)
+	}
+	return d.elements(out), nil
+}
+
+// Evaluate returns P(w^k) for k in [0, n) i.e the evaluations of P over
+// the multiplicative subgroup of order n, P is first reduced modulo
+// x^n - 1 which doesn't change its values on the subgroup.
+func (d *DFT) Evaluate(p Polynomial) []ff.FieldElement {
+	q := d.f.Modulus()
+	v := make([]*nt.Integer, d.n)
+	for i := range v {
+		v[i] = nt.FromInt64(0)
+	}
+	for i := range p {
+		v[i%d.n].Add(v[i%d.n], p[i])
+	}
+	return d.elements(d.fwd.transform(v, q))
+}
+
+// Interpolate returns the polynomial of degree less than n taking the given
+// values over the subgroup of order n
+func (d *DFT) Interpolate(values []ff.FieldElement) (Polynomial, error) {
+	coeffs, err := d.Inverse(values)
+	if err != nil {
+		return nil, err
+	}
+	return NewPolynomial(coeffs), nil
+}
+
+// chirp holds the tables of the chirp-z transform of n inputs and m
+// outputs with ratio w, using jk = C(j+k, 2) - C(j, 2) - C(k, 2) the
+// transform A_k = w^-C(k,2) sum_j a_j w^-C(j,2) w^C(j+k,2) is a correlation
+// with the chirp w^C(i,2). Unlike the usual jk = (j^2 + k^2 - (k-j)^2)/2
+// this doesn't need a square root of w.
+// ref : The chirp z-transform algorithm (Rabiner, Schafer, Rader)
+type chirp struct {
+	n, m int
+	q    *nt.Integer
+	// u[j] = w^-C(j,2) for j < max(n, m)
+	u []*nt.Integer
+	// b[i] = w^C(i,2) for i < n + m - 1
+	b Polynomial
+}
+
+func newChirp(w *nt.Integer, n, m int, q *nt.Integer) *chirp {
+	wInv := nt.ModInv(w, q)
+	c := &chirp{n: n, m: m, q: q, b: make(Polynomial, n+m-1)}
+	size := n
+	if m > size {
+		size = m
+	}
+	c.u = make([]*nt.Integer, size)
+	// C(i+1, 2) = C(i, 2) + i
+	wi, b := nt.FromInt64(1), nt.FromInt64(1)
+	for i := range c.b {
+		c.b[i] = b
+		b, wi = nt.ModMul(b, wi, q), nt.ModMul(wi, w, q)
+	}
+	wi, u := nt.FromInt64(1), nt.FromInt64(1)
+	for i := range c.u {
+		c.u[i] = u
+		u, wi = nt.ModMul(u, wi, q), nt.ModMul(wi, wInv, q)
+	}
+	return c
+}
+
+// transform returns the m outputs of the chirp-z transform of a
+func (c *chirp) transform(a []*nt.Integer) []*nt.Integer {
+	// sum_j u_j a_j b_(j+k) is the coefficient n - 1 + k of the product of
+	// b with the reversed sequence u_j a_j
+	v := make(Polynomial, c.n)
+	for j := range v {
+		v[c.n-1-j] = nt.ModMul(a[j], c.u[j], c.q)
+	}
+	conv := v.MulFast(c.b, c.q)
+	out := make([]*nt.Integer, c.m)
+	for k := range out {
+		if i := c.n - 1 + k; i < len(conv) {
+			out[k] = nt.ModMul(conv[i], c.u[k], c.q)
+		} else {
+			// the product was trimmed
+			out[k] = nt.FromInt64(0)
+		}
+	}
+	return out
+}
+
+// ChirpZ returns A_k = sum a_j z_k^j for k in [0, m) where z_k = s w^k i.e the
+// evaluations of the polynomial with coefficients a at the m points of the
+// geometric progression s, s w, s w^2 ..., w must be non zero but unlike
+// the DFT no root of unity is needed.
+func ChirpZ(a []ff.FieldElement, s, w ff.FieldElement, m int) ([]ff.FieldElement, error) {
+	if w.IsZero() {
+		return nil, errZero
+	}
+	if len(a) == 0 || m < 1 {
+		return nil, errLength
+	}
+	f := w.Field()
+	q := f.Modulus()
+	// a_j s^j absorbs the starting point
+	v := make([]*nt.Integer, len(a))
+	sj := nt.FromInt64(1)
+	for j := range a {
+		v[j] = nt.ModMul(a[j].Big(), sj, q)
+		sj = nt.ModMul(sj, s.Big(), q)
+	}
+	out := newChirp(w.Big(), len(a), m, q).transform(v)
+	res := make([]ff.FieldElement, m)
+	for k := range out {
+		res[k] = f.NewFieldElement(out[k])
+	}
+	return res, nil
+}
@@ -0,0 +1,134 @@
+package poly
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/ff"
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// naiveDFT is the reference quadratic transform
+func naiveDFT(a []ff.FieldElement, w ff.FieldElement) []ff.FieldElement {
+	f := w.Field()
+	out := make([]ff.FieldElement, len(a))
+	wk := f.One()
+	for k := range out {
+		acc, x := f.Zero(), f.One()
+		for j := range a {
+			acc = f.Add(acc, f.Mul(a[j], x))
+			x = f.Mul(x, wk)
+		}
+		out[k] = acc
+		wk = f.Mul(wk, w)
+	}
+	return out
+}
+
+func randomElements(rr *rand.Rand, f ff.FiniteField, n int) []ff.FieldElement {
+	a := make([]ff.FieldElement, n)
+	for i := range a {
+		a[i] = f.NewFieldElement(new(nt.Integer).Rand(rr, f.Modulus()))
+	}
+	return a
+}
+
+func equalElements(a, b []ff.FieldElement) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDFT(t *testing.T) {
+	rr := rand.New(rand.NewSource(7))
+	vec := []struct {
+		q int64
+		n []int
+	}{
+		// 7680 = 2^9 3 5
+		{7681, []int{1, 2, 3, 15, 64, 240, 640}},
+		// 606 = 2 3 101 so 101 and 303 go through Bluestein
+		{607, []int{101, 202, 303, 606}},
+		// 9972 = 2^2 3^2 277
+		{9973, []int{277, 831}},
+	}
+	for _, v := range vec {
+		f, _ := ff.NewFiniteField(nt.FromInt64(v.q))
+		for _, n := range v.n {
+			d, err := NewDFT(f, n)
+			if err != nil {
+				t.Fatalf("NewDFT(%d, %d) failed with %v", v.q, n, err)
+			}
+			a := randomElements(rr, f, n)
+			got, err := d.Transform(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalElements(got, naiveDFT(a, d.Root())) {
+				t.Fatalf("DFT of length %d over F_%d doesn't match the naive transform", n, v.q)
+			}
+			back, err := d.Inverse(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalElements(back, a) {
+				t.Fatalf("Inverse(Transform(a)) != a for length %d over F_%d", n, v.q)
+			}
+		}
+	}
+	f, _ := ff.NewFiniteField(nt.FromInt64(607))
+	t.Run("TestErrors", func(t *testing.T) {
+		if _, err := NewDFT(f, 7); err == nil {
+			t.Error("7 doesn't divide 606")
+		}
+		d, _ := NewDFT(f, 6)
+		if _, err := d.Transform(randomElements(rr, f, 5)); err == nil {
+			t.Error("wrong number of values should be rejected")
+		}
+	})
+	t.Run("TestEvaluate", func(t *testing.T) {
+		d, _ := NewDFT(f, 101)
+		p := randomPoly(rr, 150, f.Modulus())
+		values := d.Evaluate(p)
+		x := f.One()
+		for k := range values {
+			if !values[k].Equal(f.NewFieldElement(p.Eval(x.Big(), f.Modulus()))) {
+				t.Fatalf("Evaluate doesn't match Eval at w^%d", k)
+			}
+			x = f.Mul(x, d.Root())
+		}
+		q := randomPoly(rr, 100, f.Modulus())
+		interp, err := d.Interpolate(d.Evaluate(q))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if interp.Compare(&q) != 0 {
+			t.Error("Interpolate(Evaluate(q)) != q")
+		}
+	})
+	t.Run("TestChirpZ", func(t *testing.T) {
+		a := randomElements(rr, f, 40)
+		s, w := f.NewFieldElementFromInt64(5), f.NewFieldElementFromInt64(3)
+		got, err := ChirpZ(a, s, w, 70)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := NewPolynomial(a)
+		z := s
+		for k := range got {
+			if !got[k].Equal(f.NewFieldElement(p.Eval(z.Big(), f.Modulus()))) {
+				t.Fatalf("ChirpZ doesn't match Eval at s w^%d", k)
+			}
+			z = f.Mul(z, w)
+		}
+		if _, err := ChirpZ(a, s, f.Zero(), 3); err == nil {
+			t.Error("zero ratio should be rejected")
+		}
+	})
+}
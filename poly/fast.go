@@ -0,0 +1,232 @@
+package poly
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/ntt"
+)
+
+const (
+	// fastMulThreshold is the degree from which Mul switches to MulFast
+	fastMulThreshold = 64
+	// karatsubaThreshold is the length under which Karatsuba recursion ends
+	// with a schoolbook product
+	karatsubaThreshold = 32
+	// crtPrimeBits and crtMaxSize size the three primes of the CRT product,
+	// they support transforms of length up to 2^20
+	crtPrimeBits = 61
+	crtMaxSize   = 1 << 20
+	// maxTransforms bounds the number of cached NTT parameters, it holds
+	// the CRT primes at every size alongside a few other moduli
+	maxTransforms = 128
+)
+
+// transformEntry is a cached transform, a nil transform records a modulus
+// without the needed roots of unity.
+type transformEntry struct {
+	key string
+	t   ntt.Transformer
+}
+
+// transforms caches the NTT parameters by size and modulus, the least
+// recently used entry is evicted once maxTransforms entries are held.
+var transforms = struct {
+	sync.Mutex
+	cache map[string]*list.Element
+	// lru orders the entries from the most to the least recently used
+	lru *list.List
+}{cache: make(map[string]*list.Element), lru: list.New()}
+
+// transform returns the negacyclic transform of size n modulo m or nil
+func transform(n int, m *nt.Integer) ntt.Transformer {
+	key := fmt.Sprintf("%d:%x", n, m)
+	transforms.Lock()
+	defer transforms.Unlock()
+	if e, ok := transforms.cache[key]; ok {
+		transforms.lru.MoveToFront(e)
+		return e.Value.(*transformEntry).t
+	}
+	// a failed setup returns a nil transform
+	t, _ := ntt.New(int64(n), *m)
+	transforms.cache[key] = transforms.lru.PushFront(&transformEntry{key: key, t: t})
+	if transforms.lru.Len() > maxTransforms {
+		last := transforms.lru.Back()
+		transforms.lru.Remove(last)
+		delete(transforms.cache, last.Value.(*transformEntry).key)
+	}
+	return t
+}
+
+var (
+	crtOnce    sync.Once
+	crtPrimes  []*nt.Integer
+	crtModulus *nt.Integer
+)
+
+// crtBasis returns three NTT friendly word sized primes and their product
+func crtBasis() ([]*nt.Integer, *nt.Integer) {
+	crtOnce.Do(func() {
+		primes, err := ntt.GenPrimes(crtPrimeBits, crtMaxSize, 3)
+		if err != nil {
+			panic(err)
+		}
+		crtPrimes = primes
+		crtModulus = nt.FromInt64(1)
+		for _, q := range primes {
+			crtModulus = nt.Mul(crtModulus, q)
+		}
+	})
+	return crtPrimes, crtModulus
+}
+
+// vector returns the coefficients of p reduced modulo m and padded with
+// zeros to length n, p is left unchanged
+func vector(p Polynomial, n int, m *nt.Integer) []*nt.Integer {
+	v := make([]*nt.Integer, n)
+	for i := range v {
+		switch {
+		case i >= len(p):
+			v[i] = big.NewInt(0)
+		case m != nil:
+			v[i] = nt.Mod(p[i], m)
+		default:
+			v[i] = new(big.Int).Set(p[i])
+		}
+	}
+	return v
+}
+
+// MulFast computes P * Q with a transform of length the power of two n
+// above deg P + deg Q. The product is computed with the negacyclic NTT
+// modulo m when m is a prime with 2n | m - 1, and otherwise it's computed
+// over the integers with three word sized NTTs recombined by the CRT when
+// the coefficients fit, or with Karatsuba's algorithm.
+func (p Polynomial) MulFast(q Polynomial, m *nt.Integer) Polynomial {
+	n := 1
+	for n < len(p)+len(q)-1 {
+		n <<= 1
+	}
+	if m != nil {
+		// deg PQ < n so the product doesn't wrap around x^n = -1
+		if t := transform(n, m); t != nil {
+			return NewPolynomialBigInt(t.PolyMul(vector(p, n, m), vector(q, n, m))...)
+		}
+		if r := mulCRT(p, q, n, m); r != nil {
+			return r
+		}
+	}
+	return mulKaratsuba(p, q, m)
+}
+
+// mulCRT computes P * Q over the integers modulo three word sized primes
+// and reconstructs the product with the CRT, it returns nil when the
+// coefficients of the product may exceed the product of the primes.
+func mulCRT(p, q Polynomial, n int, m *nt.Integer) Polynomial {
+	primes, modulus := crtBasis()
+	if n > crtMaxSize {
+		return nil
+	}
+	// every coefficient of the product is at most min(len p, len q) (m - 1)^2
+	terms := len(p)
+	if len(q) < terms {
+		terms = len(q)
+	}
+	bound := nt.Mul(nt.FromInt64(int64(terms)), nt.Mul(nt.Sub(m, nt.One), nt.Sub(m, nt.One)))
+	if bound.Cmp(modulus) >= 0 {
+		return nil
+	}
+	a, b := vector(p, n, m), vector(q, n, m)
+	residues := make([][]*nt.Integer, len(primes))
+	for i, qi := range primes {
+		t := transform(n, qi)
+		if t == nil {
+			return nil
+		}
+		residues[i] = t.PolyMul(a, b)
+	}
+	// x = sum r_i M_i (M_i^-1 mod q_i) mod M where M_i = M/q_i
+	coeffs := make([]*nt.Integer, len(residues[0]))
+	basis := make([]*nt.Integer, len(primes))
+	for i, qi := range primes {
+		mi := nt.Div(modulus, qi)
+		basis[i] = nt.Mul(mi, nt.ModInv(nt.Mod(mi, qi), qi))
+	}
+	for j := range coeffs {
+		x := big.NewInt(0)
+		for i := range primes {
+			x.Add(x, nt.Mul(residues[i][j], basis[i]))
+		}
+		coeffs[j] = nt.Mod(nt.Mod(x, modulus), m)
+	}
+	return NewPolynomialBigInt(coeffs...)
+}
+
+// mulKaratsuba computes P * Q with Karatsuba's algorithm
+func mulKaratsuba(p, q Polynomial, m *nt.Integer) Polynomial {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	return NewPolynomialBigInt(karatsuba(vector(p, n, m), vector(q, n, m), m)...)
+}
+
+// karatsuba returns the 2n - 1 coefficients of the product of the vectors
+// a and b of length n, writing a = a0 + x^h a1 and b = b0 + x^h b1 the
+// product is a0b0 + x^h ((a0 + a1)(b0 + b1) - a0b0 - a1b1) + x^2h a1b1
+// which takes three half size products instead of four.
+func karatsuba(a, b []*nt.Integer, m *nt.Integer) []*nt.Integer {
+	n := len(a)
+	r := make([]*nt.Integer, 2*n-1)
+	for i := range r {
+		r[i] = big.NewInt(0)
+	}
+	if n <= karatsubaThreshold {
+		for i := range a {
+			for j := range b {
+				r[i+j].Add(r[i+j], new(big.Int).Mul(a[i], b[j]))
+			}
+		}
+		reduceVector(r, m)
+		return r
+	}
+	h := n / 2
+	z0 := karatsuba(a[:h], b[:h], m)
+	z2 := karatsuba(a[h:], b[h:], m)
+	// a1 and b1 are the longer halves
+	sa, sb := make([]*nt.Integer, n-h), make([]*nt.Integer, n-h)
+	for i := range sa {
+		sa[i], sb[i] = new(big.Int).Set(a[h+i]), new(big.Int).Set(b[h+i])
+		if i < h {
+			sa[i].Add(sa[i], a[i])
+			sb[i].Add(sb[i], b[i])
+		}
+	}
+	z1 := karatsuba(sa, sb, m)
+	for i := range z0 {
+		r[i].Add(r[i], z0[i])
+		z1[i].Sub(z1[i], z0[i])
+	}
+	for i := range z2 {
+		r[2*h+i].Add(r[2*h+i], z2[i])
+		z1[i].Sub(z1[i], z2[i])
+	}
+	for i := range z1 {
+		r[h+i].Add(r[h+i], z1[i])
+	}
+	reduceVector(r, m)
+	return r
+}
+
+// reduceVector reduces every coefficient modulo m when m isn't nil
+func reduceVector(v []*nt.Integer, m *nt.Integer) {
+	if m == nil {
+		return
+	}
+	for i := range v {
+		v[i].Mod(v[i], m)
+	}
+}
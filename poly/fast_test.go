@@ -0,0 +1,117 @@
+package poly
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// naiveMul is the reference schoolbook product
+func naiveMul(p, q Polynomial, m *nt.Integer) Polynomial {
+	r := make([]*nt.Integer, len(p)+len(q)-1)
+	for i := range r {
+		r[i] = big.NewInt(0)
+	}
+	for i := range p {
+		for j := range q {
+			r[i+j].Add(r[i+j], new(big.Int).Mul(p[i], q[j]))
+		}
+	}
+	reduceVector(r, m)
+	return NewPolynomialBigInt(r...)
+}
+
+func randomPoly(rr *rand.Rand, degree int, m *nt.Integer) Polynomial {
+	p := make(Polynomial, degree+1)
+	for i := range p {
+		if m != nil {
+			p[i] = new(big.Int).Rand(rr, m)
+		} else {
+			// signed 64 bit coefficients
+			p[i] = big.NewInt(rr.Int63() - rr.Int63())
+		}
+	}
+	p.trim()
+	return p
+}
+
+func TestMulFast(t *testing.T) {
+	rr := rand.New(rand.NewSource(42))
+	bn254, _ := new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+	mersenne := new(big.Int).Sub(new(big.Int).Lsh(nt.One, 127), nt.One)
+	moduli := []struct {
+		name string
+		m    *nt.Integer
+	}{
+		// 2n divides q - 1
+		{"NTT", nt.FromInt64(12289)},
+		{"NTTBig", bn254},
+		// no roots of unity of order 2n, the product fits three primes
+		{"CRT", nt.FromInt64(1000003)},
+		// the product doesn't fit three primes
+		{"LargeModulus", mersenne},
+		{"Integers", nil},
+	}
+	for _, mod := range moduli {
+		m := mod.m
+		t.Run("Test"+mod.name, func(t *testing.T) {
+			for _, deg := range [][2]int{{0, 5}, {70, 65}, {200, 100}, {257, 300}} {
+				p, q := randomPoly(rr, deg[0], m), randomPoly(rr, deg[1], m)
+				want := naiveMul(p, q, m)
+				got := p.MulFast(q, m)
+				if got.Compare(&want) != 0 {
+					t.Fatalf("MulFast doesn't match the schoolbook product for degrees %v", deg)
+				}
+				got = p.Mul(q, m)
+				if got.Compare(&want) != 0 {
+					t.Fatalf("Mul doesn't match the schoolbook product for degrees %v", deg)
+				}
+			}
+		})
+	}
+	t.Run("TestDispatch", func(t *testing.T) {
+		if transform(512, nt.FromInt64(12289)) == nil {
+			t.Error("12289 supports the negacyclic NTT of size 512")
+		}
+		if transform(512, nt.FromInt64(1000003)) != nil {
+			t.Error("1000003 doesn't support the negacyclic NTT of size 512")
+		}
+		p := randomPoly(rr, 100, nt.FromInt64(1000003))
+		if mulCRT(p, p, 256, nt.FromInt64(1000003)) == nil {
+			t.Error("products modulo 1000003 should fit the CRT basis")
+		}
+		if mulCRT(p, p, 256, mersenne) != nil {
+			t.Error("products modulo 2^127 - 1 shouldn't fit the CRT basis")
+		}
+	})
+	t.Run("TestTransformCache", func(t *testing.T) {
+		// cycling through moduli doesn't grow the cache past its bound and
+		// keeps the recently used entries
+		for i := 0; i < 2*maxTransforms; i++ {
+			transform(16, nt.FromInt64(int64(1000003+2*i)))
+			if transform(512, nt.FromInt64(12289)) == nil {
+				t.Fatal("12289 supports the negacyclic NTT of size 512")
+			}
+		}
+		transforms.Lock()
+		defer transforms.Unlock()
+		if transforms.lru.Len() > maxTransforms || len(transforms.cache) != transforms.lru.Len() {
+			t.Errorf("cache holds %d entries and %d keys, the bound is %d", transforms.lru.Len(), len(transforms.cache), maxTransforms)
+		}
+		if _, ok := transforms.cache["512:3001"]; !ok {
+			t.Error("the most recently used transform was evicted")
+		}
+	})
+	t.Run("TestKaratsuba", func(t *testing.T) {
+		for _, n := range []int{1, 2, 33, 64, 65, 129} {
+			p, q := randomPoly(rr, n-1, nil), randomPoly(rr, n/2, nil)
+			want := naiveMul(p, q, nil)
+			got := mulKaratsuba(p, q, nil)
+			if got.Compare(&want) != 0 {
+				t.Fatalf("Karatsuba doesn't match the schoolbook product for n = %d", n)
+			}
+		}
+	})
+}
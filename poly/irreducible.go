@@ -0,0 +1,206 @@
+package poly
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// PowMod computes p^k mod f with coefficients in Z/mZ using square and multiply
+func (p Polynomial) PowMod(k *nt.Integer, f Polynomial, m *nt.Integer) Polynomial {
+	res := NewPolynomialInts(1)
+	base := p.Mod(f, m)
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		res = res.Mul(res, m).Mod(f, m)
+		if k.Bit(i) == 1 {
+			res = res.Mul(base, m).Mod(f, m)
+		}
+	}
+	return res
+}
+
+// Monic divides the polynomial by its leading coefficient modulo m
+func (p Polynomial) Monic(m *nt.Integer) Polynomial {
+	q := p.Clone(0)
+	q.reduce(m)
+	if q.isZero() {
+		return q
+	}
+	inv := nt.ModInv(q[q.Degree()], m)
+	for i := range q {
+		q[i] = nt.ModMul(q[i], inv, m)
+	}
+	return q
+}
+
+// frobeniusChain returns x^(m^i) mod f for 1 <= i <= n
+func frobeniusChain(f Polynomial, n int, m *nt.Integer) []Polynomial {
+	chain := make([]Polynomial, n)
+	h := NewPolynomialInts(0, 1)
+	for i := 0; i < n; i++ {
+		h = h.PowMod(m, f, m)
+		chain[i] = h
+	}
+	return chain
+}
+
+// coprimeWithXPow returns true if gcd(f, h - x) = 1
+func coprimeWithXPow(f, h Polynomial, m *nt.Integer) bool {
+	g := f.GCD(h.Sub(NewPolynomialInts(0, 1), m), m)
+	return g.Degree() == 0 && !g.isZero()
+}
+
+// IsIrreducible tests whether f is irreducible over GF(m) with Ben-Or's
+// algorithm : f of degree n is irreducible if gcd(f, x^(m^i) - x) = 1 for
+// 1 <= i <= n/2, a factor of degree i divides x^(m^i) - x so random
+// polynomials are rejected as soon as their smallest factor is found.
+func (p Polynomial) IsIrreducible(m *nt.Integer) bool {
+	f := p.Monic(m)
+	n := f.Degree()
+	if n < 1 || f.isZero() {
+		return false
+	}
+	h := NewPolynomialInts(0, 1)
+	for i := 1; i <= n/2; i++ {
+		h = h.PowMod(m, f, m)
+		if !coprimeWithXPow(f, h, m) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsIrreducibleRabin tests whether f is irreducible over GF(m) with Rabin's
+// algorithm : f of degree n is irreducible if and only if it divides
+// x^(m^n) - x and gcd(f, x^(m^(n/q)) - x) = 1 for every prime q dividing n.
+func (p Polynomial) IsIrreducibleRabin(m *nt.Integer) bool {
+	f := p.Monic(m)
+	n := f.Degree()
+	if n < 1 || f.isZero() {
+		return false
+	}
+	chain := frobeniusChain(f, n, m)
+	for _, q := range nt.PrimeFactors(nt.FromInt64(int64(n))) {
+		if !coprimeWithXPow(f, chain[n/int(q.Int64())-1], m) {
+			return false
+		}
+	}
+	x := NewPolynomialInts(0, 1).Mod(f, m)
+	return chain[n-1].Compare(&x) == 0
+}
+
+// IsPrimitive tests whether f is a primitive polynomial over GF(m) i.e an
+// irreducible polynomial whose roots generate the multiplicative group of
+// GF(m^n), x has order m^n - 1 modulo f if x^((m^n - 1)/q) != 1 for every
+// prime q dividing m^n - 1 which must be factored.
+func (p Polynomial) IsPrimitive(m *nt.Integer) bool {
+	f := p.Monic(m)
+	if !f.IsIrreducible(m) || f[0].Sign() == 0 {
+		return false
+	}
+	order := nt.Sub(new(big.Int).Exp(m, big.NewInt(int64(f.Degree())), nil), nt.One)
+	x := NewPolynomialInts(0, 1)
+	one := NewPolynomialInts(1)
+	for _, q := range nt.PrimeFactors(order) {
+		h := x.PowMod(nt.Div(order, q), f, m)
+		if h.Compare(&one) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RandomIrreducible samples monic polynomials of the given degree over GF(m)
+// until one is irreducible, about one in degree of them is.
+func RandomIrreducible(degree int, m *nt.Integer) (Polynomial, error) {
+	if degree < 1 {
+		return nil, errors.New("degree must be positive")
+	}
+	if !nt.IsPrime(m) {
+		return nil, errors.New("modulus must be prime")
+	}
+	for {
+		f := make(Polynomial, degree+1)
+		for i := 0; i < degree; i++ {
+			c, err := rand.Int(rand.Reader, m)
+			if err != nil {
+				return nil, err
+			}
+			f[i] = c
+		}
+		f[degree] = big.NewInt(1)
+		if f.IsIrreducible(m) {
+			return f, nil
+		}
+	}
+}
+
+// conwayPolynomials holds the Conway polynomials C(p, k) of small fields
+// with coefficients from x^0 to x^(k-1), the leading coefficient is 1.
+var conwayPolynomials = map[[2]int64][]int64{
+	{2, 1}:  {1},
+	{2, 2}:  {1, 1},
+	{2, 3}:  {1, 1, 0},
+	{2, 4}:  {1, 1, 0, 0},
+	{2, 5}:  {1, 0, 1, 0, 0},
+	{2, 6}:  {1, 1, 0, 1, 1, 0},
+	{2, 7}:  {1, 1, 0, 0, 0, 0, 0},
+	{2, 8}:  {1, 0, 1, 1, 1, 0, 0, 0},
+	{2, 9}:  {1, 0, 0, 0, 1, 0, 0, 0, 0},
+	{2, 10}: {1, 1, 1, 1, 0, 1, 1, 0, 0, 0},
+	{2, 11}: {1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+	{2, 12}: {1, 1, 0, 1, 0, 1, 1, 1, 0, 0, 0, 0},
+	{2, 13}: {1, 1, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+	{2, 14}: {1, 0, 0, 1, 0, 1, 0, 1, 0, 0, 0, 0, 0, 0},
+	{2, 15}: {1, 0, 1, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+	{3, 1}:  {1},
+	{3, 2}:  {2, 2},
+	{3, 3}:  {1, 2, 0},
+	{3, 4}:  {2, 0, 0, 2},
+	{3, 5}:  {1, 2, 0, 0, 0},
+	{3, 6}:  {2, 2, 1, 0, 2, 0},
+	{3, 7}:  {1, 0, 2, 0, 0, 0, 0},
+	{3, 8}:  {2, 2, 2, 0, 1, 2, 0, 0},
+	{3, 9}:  {1, 1, 2, 2, 0, 0, 0, 0, 0},
+	{3, 10}: {2, 1, 0, 0, 2, 2, 2, 0, 0, 0},
+	{5, 1}:  {3},
+	{5, 2}:  {2, 4},
+	{5, 3}:  {3, 3, 0},
+	{5, 4}:  {2, 4, 4, 0},
+	{5, 5}:  {3, 4, 0, 0, 0},
+	{5, 6}:  {2, 0, 1, 4, 1, 0},
+	{7, 1}:  {4},
+	{7, 2}:  {3, 6},
+	{7, 3}:  {4, 0, 6},
+	{7, 4}:  {3, 4, 5, 0},
+	{7, 5}:  {4, 1, 0, 0, 0},
+	{11, 1}: {9},
+	{11, 2}: {2, 7},
+	{11, 3}: {9, 2, 0},
+	{11, 4}: {2, 10, 8, 0},
+	{13, 1}: {11},
+	{13, 2}: {2, 12},
+	{13, 3}: {11, 2, 0},
+	{13, 4}: {2, 12, 3, 0},
+}
+
+// ConwayPolynomial returns the Conway polynomial C(p, k), the least primitive
+// polynomial of degree k over GF(p) for an ordering where
+// x^k + sum (-1)^(k-i)a_ix^i is compared by (a_(k-1), ..., a_0), such that
+// C(p, d)(x^((p^k - 1)/(p^d - 1))) = 0 mod C(p, k) for every d dividing k.
+// They make the embeddings between extension fields of GF(p) compatible,
+// only the ones for small p^k are tabulated.
+func ConwayPolynomial(p, k int64) (Polynomial, error) {
+	coeffs, ok := conwayPolynomials[[2]int64{p, k}]
+	if !ok {
+		return nil, errors.New("conway polynomial isn't tabulated")
+	}
+	f := make(Polynomial, k+1)
+	for i, c := range coeffs {
+		f[i] = big.NewInt(c)
+	}
+	f[k] = big.NewInt(1)
+	return f, nil
+}
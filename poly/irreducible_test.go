@@ -0,0 +1,139 @@
+package poly
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// isConwayCompatible checks C(p, d)(x^((p^k - 1)/(p^d - 1))) = 0 mod f for
+// every proper divisor d of k
+func isConwayCompatible(f Polynomial, p, k int64) bool {
+	m := big.NewInt(p)
+	pk := nt.Sub(new(big.Int).Exp(m, big.NewInt(k), nil), nt.One)
+	x := NewPolynomialInts(0, 1)
+	for d := int64(1); d < k; d++ {
+		if k%d != 0 {
+			continue
+		}
+		cd, err := ConwayPolynomial(p, d)
+		if err != nil {
+			return false
+		}
+		pd := nt.Sub(new(big.Int).Exp(m, big.NewInt(d), nil), nt.One)
+		y := x.PowMod(nt.Div(pk, pd), f, m)
+		// Horner's rule modulo f
+		acc := NewPolynomialInts(0)
+		for i := cd.Degree(); i >= 0; i-- {
+			acc = acc.Mul(y, m).Add(NewPolynomialBigInt(new(big.Int).Set(cd[i])), m).Mod(f, m)
+		}
+		if !acc.isZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// conwaySearch finds C(p, k) from its definition enumerating the polynomials
+// x^k + sum (-1)^(k-i)a_ix^i by increasing (a_(k-1), ..., a_0)
+func conwaySearch(p, k int64) Polynomial {
+	m := big.NewInt(p)
+	a := make([]int64, k)
+	for {
+		// increment the vector with a_0 as the least significant digit
+		for i := int64(0); i < k; i++ {
+			a[i]++
+			if a[i] < p {
+				break
+			}
+			a[i] = 0
+		}
+		f := make(Polynomial, k+1)
+		for i := int64(0); i < k; i++ {
+			c := a[i]
+			if (k-i)%2 == 1 {
+				c = (p - c) % p
+			}
+			f[i] = big.NewInt(c)
+		}
+		f[k] = big.NewInt(1)
+		if f.IsPrimitive(m) && isConwayCompatible(f, p, k) {
+			return f
+		}
+	}
+}
+
+func TestIrreducible(t *testing.T) {
+
+	t.Run("TestIrreducibility", func(t *testing.T) {
+		p := nt.FromInt64(2)
+		cases := []struct {
+			f           Polynomial
+			irreducible bool
+			primitive   bool
+		}{
+			{NewPolynomialInts(1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1), false, false},
+			{NewPolynomialInts(1, 1, 0, 1), true, true},
+			{NewPolynomialInts(1, 1, 1, 1, 1), true, false},
+			{NewPolynomialInts(1, 1, 0, 1, 1, 0, 0, 0, 1), true, false},
+			{NewPolynomialInts(1, 0, 1, 1, 1, 0, 0, 0, 1), true, true},
+			// (x^2 + x + 1)^2
+			{NewPolynomialInts(1, 0, 1, 0, 1), false, false},
+			{NewPolynomialInts(0, 1), true, false},
+		}
+		for _, c := range cases {
+			if c.f.IsIrreducible(p) != c.irreducible {
+				t.Error("Ben-Or failed on", c.f)
+			}
+			if c.f.IsIrreducibleRabin(p) != c.irreducible {
+				t.Error("Rabin failed on", c.f)
+			}
+			if c.f.IsPrimitive(p) != c.primitive {
+				t.Error("primitivity test failed on", c.f)
+			}
+		}
+		// x^2 + 1 splits modulo 5 but not modulo 7
+		if NewPolynomialInts(1, 0, 1).IsIrreducible(nt.FromInt64(5)) {
+			t.Error("x^2 + 1 = (x + 2)(x + 3) mod 5")
+		}
+		if !NewPolynomialInts(1, 0, 1).IsIrreducible(nt.FromInt64(7)) {
+			t.Error("x^2 + 1 is irreducible mod 7")
+		}
+	})
+
+	t.Run("TestRandomIrreducible", func(t *testing.T) {
+		q, _ := new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+		for _, m := range []*nt.Integer{nt.FromInt64(2), nt.FromInt64(101), q} {
+			f, err := RandomIrreducible(6, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Degree() != 6 || !f.IsIrreducibleRabin(m) {
+				t.Error("random polynomial isn't irreducible", f)
+			}
+		}
+		if _, err := RandomIrreducible(3, nt.FromInt64(15)); err == nil {
+			t.Error("composite modulus should fail")
+		}
+	})
+
+	t.Run("TestConway", func(t *testing.T) {
+		for key := range conwayPolynomials {
+			p, k := key[0], key[1]
+			f, _ := ConwayPolynomial(p, k)
+			if !f.IsPrimitive(big.NewInt(p)) || !isConwayCompatible(f, p, k) {
+				t.Error("C", key, "isn't primitive or compatible")
+			}
+			// searching the whole table is slow, check minimality on the small ones
+			if new(big.Int).Exp(big.NewInt(p), big.NewInt(k), nil).BitLen() <= 10 {
+				if expected := conwaySearch(p, k); f.Compare(&expected) != 0 {
+					t.Error("C", key, "isn't minimal expected", expected)
+				}
+			}
+		}
+		if _, err := ConwayPolynomial(2, 1000); err == nil {
+			t.Error("C(2, 1000) isn't tabulated")
+		}
+	})
+}
@@ -217,12 +217,16 @@ func (p Polynomial) Sub(q Polynomial, m *nt.Integer) Polynomial {
 	return p.Add(r, m)
 }
 
-// Mul computes P * Q
+// Mul computes P * Q, the schoolbook product is used for small degrees and
+// MulFast above.
 func (p Polynomial) Mul(q Polynomial, m *nt.Integer) Polynomial {
 	if m != nil {
 		p.reduce(m)
 		q.reduce(m)
 	}
+	if p.Degree() >= fastMulThreshold && q.Degree() >= fastMulThreshold {
+		return p.MulFast(q, m)
+	}
 	var r Polynomial = make([]*nt.Integer, p.Degree()+q.Degree()+1)
 	for i := 0; i < len(r); i++ {
 		r[i] = big.NewInt(0)
@@ -311,6 +315,21 @@ func (p Polynomial) GCD(q Polynomial, m *nt.Integer) Polynomial {
 
 }
 
+// XGCD returns (D, S, T) such that D = S*P + T*Q is a greatest common divisor
+// of P and Q (extended Euclidean algorithm), m must be prime.
+func (p Polynomial) XGCD(q Polynomial, m *nt.Integer) (d, s, t Polynomial) {
+	r0, r1 := p.Clone(0), q.Clone(0)
+	s0, s1 := NewPolynomialInts(1), NewPolynomialInts(0)
+	t0, t1 := NewPolynomialInts(0), NewPolynomialInts(1)
+	for !r1.isZero() {
+		quo, rem := r0.Div(r1, m)
+		r0, r1 = r1, rem
+		s0, s1 = s1, s0.Sub(quo.Mul(s1, m), m)
+		t0, t1 = t1, t0.Sub(quo.Mul(t1, m), m)
+	}
+	return r0, s0, t0
+}
+
 // Mod reduces a polynomial modulo another polynomial
 func (p Polynomial) Mod(q Polynomial, m *nt.Integer) Polynomial {
 
@@ -139,6 +139,20 @@ func TestPolynomial(t *testing.T) {
 		}
 
 	})
+	t.Run("TestXGCD", func(t *testing.T) {
+		m := nt.FromInt64(101)
+		// p = (x + 1)(x^2 + 3) and q = (x + 1)(x + 5)
+		p := NewPolynomialInts(3, 3, 1, 1)
+		q := NewPolynomialInts(5, 6, 1)
+		d, s, u := p.XGCD(q, m)
+		if d.Degree() != 1 {
+			t.Errorf("expected a gcd of degree 1 got %v", d)
+		}
+		bezout := s.Mul(p, m).Add(u.Mul(q, m), m)
+		if bezout.Compare(&d) != 0 {
+			t.Errorf("S*P + T*Q = %v != %v", bezout, d)
+		}
+	})
 	t.Run("TestCompose", func(t *testing.T) {
 		f := NewPolynomialInts(0, 1, 1)
 		g := NewPolynomialInts(1, 1)
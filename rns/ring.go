@@ -0,0 +1,209 @@
+package rns
+
+import (
+	"errors"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/ntt"
+)
+
+var errDegree = errors.New("number of coefficients doesn't match the ring degree")
+
+// Ring is the polynomial ring Z_Q[x]/(x^n + 1) over an RNS basis, every
+// modulus must be an NTT friendly prime with q_i = 1 mod 2n so that each
+// limb is multiplied with its own word sized transform.
+type Ring struct {
+	N     int
+	Basis *Basis
+	ntts  []*ntt.NTTParams64
+}
+
+// Poly is an element of the ring stored as one limb of n residues per
+// modulus, limbs are either all in coefficient or all in NTT form.
+type Poly struct {
+	Coeffs [][]uint64
+}
+
+// NewRing returns the ring of degree n over the given basis
+func NewRing(n int, basis *Basis) (*Ring, error) {
+	r := &Ring{N: n, Basis: basis, ntts: make([]*ntt.NTTParams64, basis.Len())}
+	for i, qi := range basis.Moduli {
+		p, err := ntt.GenParams64(int64(n), qi)
+		if err != nil {
+			return nil, err
+		}
+		r.ntts[i] = p
+	}
+	return r, nil
+}
+
+// NewPoly returns the zero polynomial
+func (r *Ring) NewPoly() *Poly {
+	p := &Poly{Coeffs: make([][]uint64, r.Basis.Len())}
+	for i := range p.Coeffs {
+		p.Coeffs[i] = make([]uint64, r.N)
+	}
+	return p
+}
+
+// Copy returns a copy of p
+func (p *Poly) Copy() *Poly {
+	c := &Poly{Coeffs: make([][]uint64, len(p.Coeffs))}
+	for i := range p.Coeffs {
+		c.Coeffs[i] = append([]uint64{}, p.Coeffs[i]...)
+	}
+	return c
+}
+
+// FromCoeffs decomposes integer coefficients, which may be negative, into
+// RNS limbs
+func (r *Ring) FromCoeffs(coeffs []*nt.Integer) (*Poly, error) {
+	if len(coeffs) != r.N {
+		return nil, errDegree
+	}
+	p := r.NewPoly()
+	for j, c := range coeffs {
+		for i, v := range r.Basis.Decompose(c) {
+			p.Coeffs[i][j] = v
+		}
+	}
+	return p, nil
+}
+
+// residues returns the residues of the j-th coefficient of p
+func (p *Poly) residues(j int) []uint64 {
+	v := make([]uint64, len(p.Coeffs))
+	for i := range p.Coeffs {
+		v[i] = p.Coeffs[i][j]
+	}
+	return v
+}
+
+// ToCoeffs recomposes the coefficients of p in [0, Q)
+func (r *Ring) ToCoeffs(p *Poly) []*nt.Integer {
+	out := make([]*nt.Integer, r.N)
+	for j := range out {
+		// the limbs come from the ring so their count always matches
+		out[j], _ = r.Basis.Recompose(p.residues(j))
+	}
+	return out
+}
+
+// ToCoeffsCentered recomposes the coefficients of p in [-Q/2, Q/2)
+func (r *Ring) ToCoeffsCentered(p *Poly) []*nt.Integer {
+	out := make([]*nt.Integer, r.N)
+	for j := range out {
+		out[j], _ = r.Basis.RecomposeCentered(p.residues(j))
+	}
+	return out
+}
+
+// Add returns a + b
+func (r *Ring) Add(a, b *Poly) *Poly {
+	c := r.NewPoly()
+	for i, qi := range r.Basis.Moduli {
+		for j := range c.Coeffs[i] {
+			c.Coeffs[i][j] = (a.Coeffs[i][j] + b.Coeffs[i][j]) % qi
+		}
+	}
+	return c
+}
+
+// Sub returns a - b
+func (r *Ring) Sub(a, b *Poly) *Poly {
+	c := r.NewPoly()
+	for i, qi := range r.Basis.Moduli {
+		for j := range c.Coeffs[i] {
+			c.Coeffs[i][j] = (a.Coeffs[i][j] + qi - b.Coeffs[i][j]) % qi
+		}
+	}
+	return c
+}
+
+// Neg returns -a
+func (r *Ring) Neg(a *Poly) *Poly {
+	return r.Sub(r.NewPoly(), a)
+}
+
+// MulScalar returns c a for an integer c
+func (r *Ring) MulScalar(a *Poly, c *nt.Integer) *Poly {
+	s := r.Basis.Decompose(c)
+	b := r.NewPoly()
+	for i, qi := range r.Basis.Moduli {
+		for j := range b.Coeffs[i] {
+			b.Coeffs[i][j] = mulMod(a.Coeffs[i][j], s[i], qi)
+		}
+	}
+	return b
+}
+
+// NTT transforms every limb of p in place
+func (r *Ring) NTT(p *Poly) {
+	for i, t := range r.ntts {
+		t.NTT64(p.Coeffs[i])
+	}
+}
+
+// InvNTT inverts the transform of every limb of p in place
+func (r *Ring) InvNTT(p *Poly) {
+	for i, t := range r.ntts {
+		t.InvNTT64(p.Coeffs[i])
+	}
+}
+
+// MulNTT returns the pointwise product of a and b in NTT form
+func (r *Ring) MulNTT(a, b *Poly) *Poly {
+	c := r.NewPoly()
+	for i, qi := range r.Basis.Moduli {
+		for j := range c.Coeffs[i] {
+			c.Coeffs[i][j] = mulMod(a.Coeffs[i][j], b.Coeffs[i][j], qi)
+		}
+	}
+	return c
+}
+
+// Mul returns a b for a and b in coefficient form
+func (r *Ring) Mul(a, b *Poly) *Poly {
+	c := r.NewPoly()
+	for i, t := range r.ntts {
+		c.Coeffs[i] = t.PolyMul64(a.Coeffs[i], b.Coeffs[i])
+	}
+	return c
+}
+
+// Rescale returns round(p / q_l) in the ring over the basis without its
+// last modulus q_l, p must be in coefficient form.
+func (r *Ring) Rescale(p *Poly) (*Ring, *Poly, error) {
+	basis, err := r.Basis.DropLast()
+	if err != nil {
+		return nil, nil, err
+	}
+	next := &Ring{N: r.N, Basis: basis, ntts: r.ntts[:basis.Len()]}
+	out := next.NewPoly()
+	for j := 0; j < r.N; j++ {
+		v, err := r.Basis.Rescale(p.residues(j))
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range v {
+			out.Coeffs[i][j] = v[i]
+		}
+	}
+	return next, out, nil
+}
+
+// Extend returns the residues of the centered coefficients of p, an element
+// of the ring r, in the ring to of the same degree
+func (r *Ring) Extend(p *Poly, to *Ring) (*Poly, error) {
+	if to.N != r.N {
+		return nil, errDegree
+	}
+	e := NewExtension(r.Basis, to.Basis)
+	out := to.NewPoly()
+	for j := 0; j < r.N; j++ {
+		for i, v := range e.Extend(p.residues(j)) {
+			out.Coeffs[i][j] = v
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,142 @@
+package rns
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// negacyclic multiplies a and b in Z_q[x]/(x^n + 1) over the integers
+func negacyclic(a, b []*nt.Integer, q *nt.Integer) []*nt.Integer {
+	n := len(a)
+	c := make([]*nt.Integer, n)
+	for i := range c {
+		c[i] = nt.FromInt64(0)
+	}
+	for i := range a {
+		for j := range b {
+			if k := i + j; k < n {
+				c[k] = nt.Add(c[k], nt.Mul(a[i], b[j]))
+			} else {
+				c[k-n] = nt.Sub(c[k-n], nt.Mul(a[i], b[j]))
+			}
+		}
+	}
+	for i := range c {
+		c[i] = nt.Mod(c[i], q)
+	}
+	return c
+}
+
+func randomCoeffs(t *testing.T, n int, q *nt.Integer) []*nt.Integer {
+	v := make([]*nt.Integer, n)
+	for i := range v {
+		x, err := rand.Int(rand.Reader, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v[i] = x
+	}
+	return v
+}
+
+func TestRing(t *testing.T) {
+	const n = 64
+	moduli := testBasis(t, 50, n, 4)
+	basis, _ := NewBasis(moduli[:3])
+	r, err := NewRing(n, basis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("TestNewRing", func(t *testing.T) {
+		b, _ := NewBasis([]uint64{7681, 12289})
+		if _, err := NewRing(1024, b); err == nil {
+			t.Error("7681 doesn't support the negacyclic NTT of size 1024")
+		}
+	})
+	t.Run("TestRoundTrip", func(t *testing.T) {
+		a := randomCoeffs(t, n, basis.Q)
+		p, err := r.FromCoeffs(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := p.Copy()
+		r.NTT(q)
+		r.InvNTT(q)
+		got := r.ToCoeffs(q)
+		for i := range a {
+			if !nt.Equal(got[i], a[i]) {
+				t.Fatal("InvNTT(NTT(p)) != p")
+			}
+		}
+		if _, err := r.FromCoeffs(a[1:]); err == nil {
+			t.Error("wrong number of coefficients should be rejected")
+		}
+	})
+	t.Run("TestArithmetic", func(t *testing.T) {
+		a, b := randomCoeffs(t, n, basis.Q), randomCoeffs(t, n, basis.Q)
+		pa, _ := r.FromCoeffs(a)
+		pb, _ := r.FromCoeffs(b)
+		sum, diff := r.ToCoeffs(r.Add(pa, pb)), r.ToCoeffs(r.Sub(pa, pb))
+		for i := range a {
+			if !nt.Equal(sum[i], nt.ModAdd(a[i], b[i], basis.Q)) {
+				t.Fatal("Add doesn't match big integer addition")
+			}
+			if !nt.Equal(diff[i], nt.ModSub(a[i], b[i], basis.Q)) {
+				t.Fatal("Sub doesn't match big integer subtraction")
+			}
+		}
+		want := negacyclic(a, b, basis.Q)
+		got := r.ToCoeffs(r.Mul(pa, pb))
+		na, nb := pa.Copy(), pb.Copy()
+		r.NTT(na)
+		r.NTT(nb)
+		prod := r.MulNTT(na, nb)
+		r.InvNTT(prod)
+		gotNTT := r.ToCoeffs(prod)
+		for i := range want {
+			if !nt.Equal(got[i], want[i]) || !nt.Equal(gotNTT[i], want[i]) {
+				t.Fatal("RNS product doesn't match the negacyclic product modulo Q")
+			}
+		}
+	})
+	t.Run("TestRescale", func(t *testing.T) {
+		a := randomCoeffs(t, n, basis.Q)
+		p, _ := r.FromCoeffs(a)
+		next, q, err := r.Rescale(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ql := new(nt.Integer).SetUint64(moduli[2])
+		got := next.ToCoeffs(q)
+		for i := range a {
+			want := nt.Div(nt.Add(nt.Mul(a[i], nt.FromInt64(2)), ql), nt.Mul(ql, nt.FromInt64(2)))
+			if !nt.Equal(got[i], nt.Mod(want, next.Basis.Q)) {
+				t.Fatal("Rescale doesn't round the quotient by the last modulus")
+			}
+		}
+	})
+	t.Run("TestExtend", func(t *testing.T) {
+		pb, _ := NewBasis(moduli[3:])
+		to, err := NewRing(n, pb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := make([]*nt.Integer, n)
+		for i := range a {
+			a[i] = randomCentered(t, basis.Q)
+		}
+		p, _ := r.FromCoeffs(a)
+		q, err := r.Extend(p, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := to.ToCoeffs(q)
+		for i := range a {
+			if !nt.Equal(got[i], nt.Mod(a[i], pb.Q)) {
+				t.Fatal("Extend doesn't carry the centered coefficients")
+			}
+		}
+	})
+}
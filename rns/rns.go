@@ -0,0 +1,223 @@
+// Package rns implements the residue number system, an integer modulo
+// Q = q_0 q_1 ... q_(k-1) with pairwise coprime word sized q_i is stored as
+// its residues modulo every q_i so that ring operations act independently
+// on each residue. Leaving the representation goes through the CRT with
+// arbitrary precision integers while changing basis and dividing by a
+// modulus stay in word arithmetic.
+// ref : A Full RNS Variant of FV like Somewhat Homomorphic Encryption Schemes
+// (Bajard, Eynard, Hasan, Zucca)
+// ref : An Improved RNS Variant of the BFV Homomorphic Encryption Scheme
+// (Halevi, Polyakov, Shoup)
+package rns
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/actuallyachraf/algebra/nt"
+)
+
+// maxModulusBits bounds the size of the moduli which leaves room for lazy
+// additions in word arithmetic
+const maxModulusBits = 62
+
+var (
+	errBasis   = errors.New("moduli must be pairwise coprime and between 2 and 2^62")
+	errLimbs   = errors.New("number of residues doesn't match the basis")
+	errRescale = errors.New("basis must have at least two moduli to rescale")
+)
+
+// Basis is a set of pairwise coprime moduli q_i with product Q, alongside
+// the CRT constants Q/q_i and (Q/q_i)^-1 mod q_i and the inverses of the
+// last modulus q_l^-1 mod q_i used by Rescale.
+type Basis struct {
+	Moduli   []uint64
+	Q        *nt.Integer
+	qHat     []*nt.Integer
+	qHatInv  []uint64
+	qLastInv []uint64
+}
+
+// NewBasis returns the basis of the given moduli
+func NewBasis(moduli []uint64) (*Basis, error) {
+	if len(moduli) == 0 {
+		return nil, errBasis
+	}
+	b := &Basis{Moduli: append([]uint64{}, moduli...), Q: nt.FromInt64(1)}
+	for i, qi := range moduli {
+		if qi < 2 || bits.Len64(qi) > maxModulusBits {
+			return nil, errBasis
+		}
+		for _, qj := range moduli[:i] {
+			if gcd(qi, qj) != 1 {
+				return nil, errBasis
+			}
+		}
+		b.Q = nt.Mul(b.Q, new(nt.Integer).SetUint64(qi))
+	}
+	b.qHat = make([]*nt.Integer, len(moduli))
+	b.qHatInv = make([]uint64, len(moduli))
+	for i, qi := range moduli {
+		q := new(nt.Integer).SetUint64(qi)
+		b.qHat[i] = nt.Div(b.Q, q)
+		b.qHatInv[i] = nt.ModInv(nt.Mod(b.qHat[i], q), q).Uint64()
+	}
+	l := len(moduli) - 1
+	b.qLastInv = make([]uint64, l)
+	for i, qi := range moduli[:l] {
+		q := new(nt.Integer).SetUint64(qi)
+		b.qLastInv[i] = nt.ModInv(new(nt.Integer).SetUint64(moduli[l]%qi), q).Uint64()
+	}
+	return b, nil
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// mulMod returns a b mod q
+func mulMod(a, b, q uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi%q, lo, q)
+}
+
+// Len returns the number of moduli
+func (b *Basis) Len() int {
+	return len(b.Moduli)
+}
+
+// Decompose returns the residues of x modulo every q_i, x may be negative
+func (b *Basis) Decompose(x *nt.Integer) []uint64 {
+	r := make([]uint64, len(b.Moduli))
+	for i, qi := range b.Moduli {
+		r[i] = nt.Mod(x, new(nt.Integer).SetUint64(qi)).Uint64()
+	}
+	return r
+}
+
+// Recompose returns the integer x in [0, Q) with the given residues using
+// the CRT x = sum [r_i (Q/q_i)^-1]_q_i Q/q_i mod Q
+func (b *Basis) Recompose(r []uint64) (*nt.Integer, error) {
+	if len(r) != len(b.Moduli) {
+		return nil, errLimbs
+	}
+	x := nt.FromInt64(0)
+	for i, qi := range b.Moduli {
+		y := mulMod(r[i]%qi, b.qHatInv[i], qi)
+		x.Add(x, nt.Mul(new(nt.Integer).SetUint64(y), b.qHat[i]))
+	}
+	return x.Mod(x, b.Q), nil
+}
+
+// RecomposeCentered returns the integer x in [-Q/2, Q/2) with the given
+// residues
+func (b *Basis) RecomposeCentered(r []uint64) (*nt.Integer, error) {
+	x, err := b.Recompose(r)
+	if err != nil {
+		return nil, err
+	}
+	if nt.Mul(x, nt.FromInt64(2)).Cmp(b.Q) >= 0 {
+		x.Sub(x, b.Q)
+	}
+	return x, nil
+}
+
+// Extension holds the constants of the basis extension from a basis Q to
+// a basis P, the residues Q/q_i mod p_j and Q mod p_j.
+type Extension struct {
+	From, To *Basis
+	qHatModP [][]uint64
+	qModP    []uint64
+}
+
+// NewExtension precomputes the extension from the basis from to the basis to
+func NewExtension(from, to *Basis) *Extension {
+	e := &Extension{From: from, To: to, qHatModP: make([][]uint64, len(from.Moduli))}
+	for i := range from.Moduli {
+		e.qHatModP[i] = to.Decompose(from.qHat[i])
+	}
+	e.qModP = to.Decompose(from.Q)
+	return e
+}
+
+// scaled returns y_i = [r_i (Q/q_i)^-1]_q_i
+func (b *Basis) scaled(r []uint64) []uint64 {
+	y := make([]uint64, len(r))
+	for i, qi := range b.Moduli {
+		y[i] = mulMod(r[i]%qi, b.qHatInv[i], qi)
+	}
+	return y
+}
+
+// convert returns sum y_i (Q/q_i) - alpha Q modulo every p_j
+func (e *Extension) convert(y []uint64, alpha uint64) []uint64 {
+	out := make([]uint64, len(e.To.Moduli))
+	for j, pj := range e.To.Moduli {
+		var acc uint64
+		for i := range y {
+			acc = (acc + mulMod(y[i]%pj, e.qHatModP[i][j], pj)) % pj
+		}
+		corr := mulMod(alpha%pj, e.qModP[j], pj)
+		out[j] = (acc + pj - corr) % pj
+	}
+	return out
+}
+
+// ExtendApprox computes the residues modulo P of the integer
+// sum y_i Q/q_i where y_i = [r_i (Q/q_i)^-1]_q_i, which equals x + alpha Q
+// for some 0 <= alpha < k where x in [0, Q) is represented by r. This is the
+// fast basis conversion of Bajard et al. which never leaves word arithmetic,
+// the error alpha Q is tolerated or corrected by the caller.
+func (e *Extension) ExtendApprox(r []uint64) []uint64 {
+	return e.convert(e.From.scaled(r), 0)
+}
+
+// Extend computes the residues modulo P of the centered representative x in
+// [-Q/2, Q/2) of r. Following Halevi, Polyakov and Shoup the overflow alpha
+// of the fast conversion is the rounding of sum y_i / q_i which is computed
+// in floating point and subtracted, the result is exact unless x/Q is
+// within the floating point error of 1/2.
+func (e *Extension) Extend(r []uint64) []uint64 {
+	y := e.From.scaled(r)
+	// sum y_i Q/q_i = x + alpha Q with sum y_i/q_i = alpha + x/Q
+	var v float64
+	for i, qi := range e.From.Moduli {
+		v += float64(y[i]) / float64(qi)
+	}
+	return e.convert(y, uint64(math.Round(v)))
+}
+
+// DropLast returns the basis without its last modulus
+func (b *Basis) DropLast() (*Basis, error) {
+	if len(b.Moduli) < 2 {
+		return nil, errRescale
+	}
+	return NewBasis(b.Moduli[:len(b.Moduli)-1])
+}
+
+// Rescale divides x by the last modulus q_l and rounds, it returns the
+// residues of round(x/q_l) modulo q_0 ... q_(l-1) computed as
+// (x + q_l/2 - [x + q_l/2]_q_l) q_l^-1 on every remaining residue.
+func (b *Basis) Rescale(r []uint64) ([]uint64, error) {
+	l := len(b.Moduli) - 1
+	if l < 1 {
+		return nil, errRescale
+	}
+	if len(r) != len(b.Moduli) {
+		return nil, errLimbs
+	}
+	ql := b.Moduli[l]
+	half := ql >> 1
+	last := (r[l]%ql + half) % ql
+	out := make([]uint64, l)
+	for i, qi := range b.Moduli[:l] {
+		// x + q_l/2 - last mod q_i
+		v := (r[i]%qi + half%qi + qi - last%qi) % qi
+		out[i] = mulMod(v, b.qLastInv[i], qi)
+	}
+	return out, nil
+}
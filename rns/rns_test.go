@@ -0,0 +1,125 @@
+package rns
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/actuallyachraf/algebra/nt"
+	"github.com/actuallyachraf/algebra/ntt"
+)
+
+func testBasis(t *testing.T, bits int, n int64, count int) []uint64 {
+	primes, err := ntt.GenPrimes(bits, n, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moduli := make([]uint64, count)
+	for i := range primes {
+		moduli[i] = primes[i].Uint64()
+	}
+	return moduli
+}
+
+func randomCentered(t *testing.T, q *nt.Integer) *nt.Integer {
+	x, err := rand.Int(rand.Reader, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nt.Mul(x, nt.FromInt64(2)).Cmp(q) >= 0 {
+		x.Sub(x, q)
+	}
+	return x
+}
+
+func TestBasis(t *testing.T) {
+	moduli := testBasis(t, 50, 1024, 5)
+	b, err := NewBasis(moduli[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewBasis(moduli[3:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("TestNewBasis", func(t *testing.T) {
+		if _, err := NewBasis([]uint64{6, 9}); err == nil {
+			t.Error("moduli sharing a factor should be rejected")
+		}
+		if _, err := NewBasis([]uint64{1 << 63}); err == nil {
+			t.Error("moduli over 62 bits should be rejected")
+		}
+		if _, err := NewBasis(nil); err == nil {
+			t.Error("empty basis should be rejected")
+		}
+	})
+	t.Run("TestRecompose", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			x := randomCentered(t, b.Q)
+			got, err := b.RecomposeCentered(b.Decompose(x))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !nt.Equal(got, x) {
+				t.Fatalf("RecomposeCentered(Decompose(x)) = %v expected %v", got, x)
+			}
+			got, _ = b.Recompose(b.Decompose(x))
+			if !nt.Equal(got, nt.Mod(x, b.Q)) {
+				t.Fatalf("Recompose(Decompose(x)) = %v expected %v", got, nt.Mod(x, b.Q))
+			}
+		}
+		if _, err := b.Recompose([]uint64{1}); err == nil {
+			t.Error("wrong number of residues should be rejected")
+		}
+	})
+	t.Run("TestExtend", func(t *testing.T) {
+		e := NewExtension(b, p)
+		for i := 0; i < 100; i++ {
+			x := randomCentered(t, b.Q)
+			r := b.Decompose(x)
+			got := e.Extend(r)
+			want := p.Decompose(x)
+			for j := range want {
+				if got[j] != want[j] {
+					t.Fatalf("Extend(%v) = %v expected %v", x, got, want)
+				}
+			}
+			// the fast conversion is off by a multiple alpha Q with alpha < k
+			approx := e.ExtendApprox(r)
+			found := false
+			for alpha := int64(0); alpha < int64(b.Len()) && !found; alpha++ {
+				want := p.Decompose(nt.Add(nt.Mod(x, b.Q), nt.Mul(nt.FromInt64(alpha), b.Q)))
+				found = true
+				for j := range want {
+					found = found && approx[j] == want[j]
+				}
+			}
+			if !found {
+				t.Fatalf("ExtendApprox(%v) isn't x + alpha Q with 0 <= alpha < k", x)
+			}
+		}
+	})
+	t.Run("TestRescale", func(t *testing.T) {
+		next, err := b.DropLast()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ql := new(nt.Integer).SetUint64(b.Moduli[b.Len()-1])
+		for i := 0; i < 100; i++ {
+			x, _ := rand.Int(rand.Reader, b.Q)
+			r, err := b.Rescale(b.Decompose(x))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// round(x / q_l) = floor((2x + q_l) / 2q_l)
+			want := nt.Div(nt.Add(nt.Mul(x, nt.FromInt64(2)), ql), nt.Mul(ql, nt.FromInt64(2)))
+			got, _ := next.Recompose(r)
+			if !nt.Equal(got, nt.Mod(want, next.Q)) {
+				t.Fatalf("Rescale(%v) = %v expected %v", x, got, want)
+			}
+		}
+		single, _ := NewBasis(moduli[:1])
+		if _, err := single.Rescale([]uint64{1}); err == nil {
+			t.Error("a single modulus can't be rescaled")
+		}
+	})
+}